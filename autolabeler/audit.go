@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/retry"
+)
+
+// auditLogKey is the ConfigMap key holding the audit entries, one JSON
+// object per line, oldest first.
+const auditLogKey = "audit.log"
+
+// auditEntry records one label the autolabeler added.
+type auditEntry struct {
+	Time     time.Time `json:"time"`
+	Resource string    `json:"resource"`
+	Object   string    `json:"object"`
+	Team     string    `json:"team"`
+}
+
+// auditLog appends labeling actions to a ConfigMap, keeping only the newest
+// maxEntries so the ConfigMap stays well below the 1MiB object size limit.
+// A nil *auditLog records nothing.
+type auditLog struct {
+	client     kubernetes.Interface
+	namespace  string
+	name       string
+	maxEntries int
+	now        func() time.Time
+
+	// mu serializes appends from concurrent workers, which would otherwise
+	// just conflict and retry.
+	mu sync.Mutex
+}
+
+// newAuditLog returns an auditLog writing to the ConfigMap ref, given as
+// "namespace/name".
+func newAuditLog(client kubernetes.Interface, ref string, maxEntries int) (*auditLog, error) {
+	namespace, name, err := cache.SplitMetaNamespaceKey(ref)
+	if err != nil || namespace == "" || name == "" {
+		return nil, fmt.Errorf("invalid audit ConfigMap %q, expected namespace/name", ref)
+	}
+	if maxEntries < 1 {
+		return nil, fmt.Errorf("audit ConfigMap must keep at least 1 entry, got %d", maxEntries)
+	}
+	return &auditLog{client: client, namespace: namespace, name: name, maxEntries: maxEntries, now: time.Now}, nil
+}
+
+// record appends an entry for object of resource being labeled with team,
+// creating the ConfigMap if needed and dropping the oldest entries past
+// maxEntries.
+func (a *auditLog) record(resource, object, team string) error {
+	if a == nil {
+		return nil
+	}
+	line, err := json.Marshal(auditEntry{Time: a.now().UTC(), Resource: resource, Object: object, Team: team})
+	if err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	ctx := context.TODO()
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cm, err := a.client.CoreV1().ConfigMaps(a.namespace).Get(ctx, a.name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			_, err = a.client.CoreV1().ConfigMaps(a.namespace).Create(ctx, &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: a.name, Namespace: a.namespace},
+				Data:       map[string]string{auditLogKey: string(line) + "\n"},
+			}, metav1.CreateOptions{})
+			if apierrors.IsAlreadyExists(err) {
+				// Created by another replica meanwhile; retry as a conflict
+				return apierrors.NewConflict(corev1.Resource("configmaps"), a.name, err)
+			}
+			return err
+		}
+		if err != nil {
+			return err
+		}
+
+		if cm.Data == nil {
+			cm.Data = map[string]string{}
+		}
+		cm.Data[auditLogKey] = trimAuditLog(cm.Data[auditLogKey]+string(line)+"\n", a.maxEntries)
+		_, err = a.client.CoreV1().ConfigMaps(a.namespace).Update(ctx, cm, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// trimAuditLog keeps the last maxEntries lines of log.
+func trimAuditLog(log string, maxEntries int) string {
+	lines := strings.SplitAfter(log, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) <= maxEntries {
+		return log
+	}
+	return strings.Join(lines[len(lines)-maxEntries:], "")
+}
@@ -2,53 +2,243 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"flag"
 	"fmt"
+	"os"
+	"os/signal"
 	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
 	"time"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	corev1listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
 	"k8s.io/client-go/util/homedir"
 	"k8s.io/client-go/util/workqueue"
 )
 
-func getClientset() (*kubernetes.Clientset, error) {
+func getConfig() (*rest.Config, error) {
 	// Try in-cluster config first (works when running inside a pod)
 	config, err := rest.InClusterConfig()
 	if err != nil {
 		// Fall back to kubeconfig (for local development)
 		kubeconfig := filepath.Join(homedir.HomeDir(), ".kube", "config")
-		config, err = clientcmd.BuildConfigFromFlags("", kubeconfig)
-		if err != nil {
-			return nil, err
+		return clientcmd.BuildConfigFromFlags("", kubeconfig)
+	}
+	return config, nil
+}
+
+// optOutLabel lets a namespace owner opt out of automatic labeling by setting it to "true".
+const optOutLabel = "autolabeler/opt-out"
+
+// defaultTeam is the team label value used when no parent supplies one.
+const defaultTeam = "unassigned"
+
+// teamPatch returns the merge patch that adds the team label with value team.
+func teamPatch(team string) []byte {
+	patch, _ := json.Marshal(map[string]any{
+		"metadata": map[string]any{"labels": map[string]string{"team": team}},
+	})
+	return patch
+}
+
+// teamJSONPatch returns a JSON patch that adds the team label with value team
+// to an object whose labels, as last seen, are labels. A test op first asserts
+// that the label is still absent, or that the object still has no labels at
+// all, so the server rejects the patch if another writer got there first.
+// A test against null passes only for a missing path.
+func teamJSONPatch(team string, labels map[string]string) []byte {
+	ops := []map[string]any{
+		{"op": "test", "path": "/metadata/labels/team", "value": nil},
+		{"op": "add", "path": "/metadata/labels/team", "value": team},
+	}
+	if labels == nil {
+		ops = []map[string]any{
+			{"op": "test", "path": "/metadata/labels", "value": nil},
+			{"op": "add", "path": "/metadata/labels", "value": map[string]string{"team": team}},
 		}
 	}
+	patch, _ := json.Marshal(ops)
+	return patch
+}
+
+// labelPatch returns the patch of patchType that adds the team label to an
+// object currently carrying labels.
+func labelPatch(patchType types.PatchType, team string, labels map[string]string) []byte {
+	if patchType == types.JSONPatchType {
+		return teamJSONPatch(team, labels)
+	}
+	return teamPatch(team)
+}
+
+// parsePatchType parses the --patch-type flag: "merge" or "json".
+func parsePatchType(arg string) (types.PatchType, error) {
+	switch arg {
+	case "merge":
+		return types.MergePatchType, nil
+	case "json":
+		return types.JSONPatchType, nil
+	}
+	return "", fmt.Errorf("invalid --patch-type %q, expected merge or json", arg)
+}
 
-	return kubernetes.NewForConfig(config)
+// inheritedTeam returns the team label of the parent object named by the
+// inheritAnnotation annotation, looked up with getParent. It falls back to
+// fallback if inheritance is disabled, the annotation is unset, or the
+// parent is missing or has no team label.
+func inheritedTeam(inheritAnnotation string, annotations map[string]string, fallback string, getParent func(ref string) (map[string]string, error)) string {
+	if inheritAnnotation == "" {
+		return fallback
+	}
+	ref := annotations[inheritAnnotation]
+	if ref == "" {
+		return fallback
+	}
+	labels, err := getParent(ref)
+	if err != nil {
+		fmt.Printf("Cannot inherit team from parent %s: %v, using %s\n", ref, err, fallback)
+		return fallback
+	}
+	if team := labels["team"]; team != "" {
+		return team
+	}
+	fmt.Printf("Parent %s has no team label, using %s\n", ref, fallback)
+	return fallback
+}
+
+// parsePrefixMap turns a comma-separated --prefix-map value of prefix=team
+// entries into a map, ignoring surrounding spaces and empty entries.
+func parsePrefixMap(arg string) (map[string]string, error) {
+	prefixes := make(map[string]string)
+	for _, entry := range strings.Split(arg, ",") {
+		if entry = strings.TrimSpace(entry); entry == "" {
+			continue
+		}
+		prefix, team, ok := strings.Cut(entry, "=")
+		prefix, team = strings.TrimSpace(prefix), strings.TrimSpace(team)
+		if !ok || prefix == "" || team == "" {
+			return nil, fmt.Errorf("prefix map entry %q must be prefix=team", entry)
+		}
+		prefixes[prefix] = team
+	}
+	return prefixes, nil
+}
+
+// prefixTeam returns the team mapped to the longest prefix of name in
+// prefixes, or defaultTeam if none matches.
+func prefixTeam(prefixes map[string]string, name string) string {
+	team, longest := defaultTeam, -1
+	for prefix, t := range prefixes {
+		if strings.HasPrefix(name, prefix) && len(prefix) > longest {
+			team, longest = t, len(prefix)
+		}
+	}
+	return team
+}
+
+// defaultSkipNamespaces are the system namespaces left alone unless
+// --skip-namespaces says otherwise.
+const defaultSkipNamespaces = "kube-system,kube-public,kube-node-lease,default"
+
+// parseSkipNamespaces turns a comma-separated --skip-namespaces value into a
+// set, ignoring surrounding spaces and empty entries.
+func parseSkipNamespaces(arg string) map[string]struct{} {
+	skip := make(map[string]struct{})
+	for _, name := range strings.Split(arg, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			skip[name] = struct{}{}
+		}
+	}
+	return skip
+}
+
+// namespacesGVR is the default watched resource, served by the typed Namespace informer.
+var namespacesGVR = schema.GroupVersionResource{Version: "v1", Resource: "namespaces"}
+
+// parseResource turns a --resource value into a GVR. It accepts the kubectl
+// form "resource.version.group" (e.g. "deployments.v1.apps" or "configmaps.v1.");
+// a bare resource name is taken to be a core/v1 resource.
+func parseResource(arg string) (schema.GroupVersionResource, error) {
+	if arg == "" {
+		return schema.GroupVersionResource{}, fmt.Errorf("resource must not be empty")
+	}
+	if gvr, _ := schema.ParseResourceArg(arg); gvr != nil {
+		return *gvr, nil
+	}
+	if strings.Contains(arg, ".") {
+		return schema.GroupVersionResource{}, fmt.Errorf("resource %q must be resource.version.group or a core/v1 resource name", arg)
+	}
+	return schema.GroupVersionResource{Version: "v1", Resource: arg}, nil
 }
 
 func main() {
-	clientset, err := getClientset()
+	var metricsAddr, resource, skipNamespaces, inheritAnnotation, prefixMap, patchTypeArg, auditConfigMap string
+	var leaseName, leaseNamespace string
+	var workers, maxRetries, auditMaxEntries int
+	var leaderElect, dryRun bool
+	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metrics endpoint binds to. Empty disables it.")
+	flag.StringVar(&resource, "resource", "namespaces", "The resource to label, as resource.version.group (e.g. deployments.v1.apps); a bare name means a core/v1 resource.")
+	flag.StringVar(&skipNamespaces, "skip-namespaces", defaultSkipNamespaces, "Comma-separated namespaces whose objects are never labeled.")
+	flag.StringVar(&inheritAnnotation, "inherit-from-annotation", "", "Annotation naming a parent object of the same resource whose team label is copied down. Empty disables inheritance.")
+	flag.StringVar(&prefixMap, "prefix-map", "", "Comma-separated prefix=team entries; an object whose name starts with a prefix gets that team instead of unassigned, the longest prefix winning.")
+	flag.StringVar(&patchTypeArg, "patch-type", "merge", "How the team label is added: merge, or json for a JSON patch the server rejects if the label was added since the object was read.")
+	flag.StringVar(&auditConfigMap, "audit-configmap", "", "ConfigMap, as namespace/name, that each label added is recorded in with its object, team and time. Empty disables the audit record.")
+	flag.IntVar(&auditMaxEntries, "audit-max-entries", 1000, "Number of most recent entries the audit ConfigMap keeps.")
+	flag.BoolVar(&dryRun, "dry-run", false, "Log the objects that would be labeled, with their patch, without patching them.")
+	flag.IntVar(&workers, "workers", 1, "Number of workers processing the queue concurrently.")
+	flag.IntVar(&maxRetries, "max-retries", 5, "Number of times a failing key is requeued before it is dropped.")
+	flag.BoolVar(&leaderElect, "leader-elect", false, "Enable leader election so only one replica labels objects at a time.")
+	flag.StringVar(&leaseName, "leader-elect-lease-name", "autolabeler", "The name of the Lease used for leader election.")
+	flag.StringVar(&leaseNamespace, "leader-elect-namespace", "default", "The namespace of the Lease used for leader election.")
+	flag.Parse()
+
+	if workers < 1 {
+		panic(fmt.Sprintf("--workers must be at least 1, got %d", workers))
+	}
+	if maxRetries < 0 {
+		panic(fmt.Sprintf("--max-retries must not be negative, got %d", maxRetries))
+	}
+	skip := parseSkipNamespaces(skipNamespaces)
+	prefixes, err := parsePrefixMap(prefixMap)
+	if err != nil {
+		panic(err)
+	}
+	patchType, err := parsePatchType(patchTypeArg)
+	if err != nil {
+		panic(err)
+	}
+	gvr, err := parseResource(resource)
 	if err != nil {
 		panic(err)
 	}
 
-	// Create the factory (resync every 30 seconds)
-	factory := informers.NewSharedInformerFactory(clientset, 30*time.Second)
-	// Get the Namespace informer from the factory
-	nsInformer := factory.Core().V1().Namespaces()
+	if metricsAddr != "" {
+		go serveMetrics(metricsAddr)
+	}
+
+	config, err := getConfig()
+	if err != nil {
+		panic(err)
+	}
 
 	// Create a rate-limiting workqueue
 	queue := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[string]())
-
-	// Register event handlers on the informer before factory.Start()
-	nsInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+	handler := cache.ResourceEventHandlerFuncs{
 		AddFunc: func(obj interface{}) {
 			key, err := cache.MetaNamespaceKeyFunc(obj)
 			if err == nil {
@@ -61,33 +251,191 @@ func main() {
 				queue.Add(key)
 			}
 		},
-	})
+	}
 
-	// Start the factory and wait for cache sync
+	// Stop the informers and the workers on SIGINT/SIGTERM
 	stopCh := make(chan struct{})
-	factory.Start(stopCh)
+	stop := stopFunc(stopCh, queue)
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	go shutdownOnSignal(signals, stop)
+
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		panic(err)
+	}
+	var audit *auditLog
+	if auditConfigMap != "" {
+		audit, err = newAuditLog(clientset, auditConfigMap, auditMaxEntries)
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	// run starts the informers and the workers, and returns once the queue
+	// has been shut down and drained.
+	run := func() {
+		var process func(key string) error
+
+		if gvr == namespacesGVR {
+			// Create the factory (resync every 30 seconds)
+			factory := informers.NewSharedInformerFactory(clientset, 30*time.Second)
+			// Get the Namespace informer from the factory
+			nsInformer := factory.Core().V1().Namespaces()
+			// Register event handlers on the informer before factory.Start()
+			nsInformer.Informer().AddEventHandler(handler)
+
+			factory.Start(stopCh)
+			fmt.Println("Waiting for cache sync...")
+			for t, ok := range factory.WaitForCacheSync(stopCh) {
+				fmt.Printf("  %v synced: %v\n", t, ok)
+			}
+			process = func(key string) error {
+				return reconcile(clientset, nsInformer.Lister(), skip, inheritAnnotation, prefixes, dryRun, patchType, audit, key)
+			}
+		} else {
+			dynamicClient, err := dynamic.NewForConfig(config)
+			if err != nil {
+				panic(err)
+			}
+
+			// Any other resource goes through a dynamic informer and unstructured objects
+			factory := dynamicinformer.NewDynamicSharedInformerFactory(dynamicClient, 30*time.Second)
+			informer := factory.ForResource(gvr)
+			informer.Informer().AddEventHandler(handler)
+
+			factory.Start(stopCh)
+			fmt.Println("Waiting for cache sync...")
+			for r, ok := range factory.WaitForCacheSync(stopCh) {
+				fmt.Printf("  %v synced: %v\n", r, ok)
+			}
+			process = func(key string) error {
+				return reconcileDynamic(dynamicClient, gvr, informer.Lister(), skip, inheritAnnotation, prefixes, dryRun, patchType, audit, key)
+			}
+		}
+
+		fmt.Printf("Starting %d worker(s)...\n", workers)
+		runWorkers(workers, queue, maxRetries, process)
+		fmt.Println("Queue shut down")
+	}
+
+	if !leaderElect {
+		run()
+		return
+	}
+
+	identity, err := os.Hostname()
+	if err != nil {
+		panic(err)
+	}
+	// Cancel the election on shutdown so a leader releases the Lease
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-stopCh
+		cancel()
+	}()
+
+	leading := make(chan struct{})
+	electionDone := make(chan struct{})
+	go func() {
+		defer close(electionDone)
+		runLeaderElection(ctx, clientset, leaseNamespace, leaseName, identity,
+			func() { close(leading) },
+			func() {
+				fmt.Println("Lost leadership, stopping workers...")
+				stop()
+			})
+	}()
 
-	fmt.Println("Waiting for cache sync...")
-	synced := factory.WaitForCacheSync(stopCh)
-	for t, ok := range synced {
-		fmt.Printf("  %v synced: %v\n", t, ok)
+	fmt.Printf("Waiting to acquire lease %s/%s as %s...\n", leaseNamespace, leaseName, identity)
+	select {
+	case <-leading:
+		fmt.Println("Acquired leadership")
+		run()
+	case <-stopCh:
 	}
+	<-electionDone
+}
+
+// runLeaderElection campaigns for the Lease namespace/name as identity until
+// ctx is cancelled or leadership is lost. onStartedLeading is called once the
+// Lease is acquired, and onStoppedLeading when the election ends, whether or
+// not this replica ever led.
+func runLeaderElection(ctx context.Context, client kubernetes.Interface, namespace, name, identity string, onStartedLeading, onStoppedLeading func()) {
+	lock := &resourcelock.LeaseLock{
+		LeaseMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Client:     client.CoordinationV1(),
+		LockConfig: resourcelock.ResourceLockConfig{Identity: identity},
+	}
+	leaderelection.RunOrDie(ctx, leaderelection.LeaderElectionConfig{
+		Lock:            lock,
+		ReleaseOnCancel: true,
+		LeaseDuration:   15 * time.Second,
+		RenewDeadline:   10 * time.Second,
+		RetryPeriod:     2 * time.Second,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(context.Context) { onStartedLeading() },
+			OnStoppedLeading: onStoppedLeading,
+		},
+	})
+}
+
+// runWorkers runs n workers on the queue and waits until all of them have
+// returned, i.e. the queue was shut down and drained.
+func runWorkers(n int, queue workqueue.TypedRateLimitingInterface[string], maxRetries int, process func(key string) error) {
+	var wg sync.WaitGroup
+	for range n {
+		wg.Go(func() {
+			runWorker(queue, maxRetries, process)
+		})
+	}
+	wg.Wait()
+}
+
+// stopFunc returns a function that closes stopCh to stop the informers and
+// shuts the queue down so runWorker returns once the items already queued
+// have been processed. Both a signal and losing leadership stop the
+// autolabeler, so the returned function may be called more than once.
+func stopFunc(stopCh chan struct{}, queue workqueue.TypedInterface[string]) func() {
+	return sync.OnceFunc(func() {
+		close(stopCh)
+		queue.ShutDown()
+	})
+}
+
+// shutdownOnSignal waits for a signal, then calls stop.
+func shutdownOnSignal(signals <-chan os.Signal, stop func()) {
+	sig := <-signals
+	fmt.Printf("Received %v, shutting down...\n", sig)
+	stop()
+}
 
-	// Worker loop — process items from the queue
-	fmt.Println("Starting worker...")
+// runWorker processes keys from the queue until it is shut down and drained.
+// A key that keeps failing is requeued with backoff up to maxRetries times,
+// then dropped until its object changes again.
+func runWorker(queue workqueue.TypedRateLimitingInterface[string], maxRetries int, process func(key string) error) {
 	for {
 		// Get the next key from the queue (blocks until one is available)
 		key, shutdown := queue.Get()
 		if shutdown {
-			fmt.Println("Queue shut down")
 			return
 		}
 
 		// Process the key
-		err := reconcile(clientset, nsInformer.Lister(), key)
-		if err != nil {
+		err := process(key)
+		if delay, ok := apierrors.SuggestsClientDelay(err); ok && delay > 0 {
+			// The API server is throttling us; come back when it asked us to
+			fmt.Printf("Throttled reconciling %s: %v, retrying in %ds\n", key, err, delay)
+			queue.AddAfter(key, time.Duration(delay)*time.Second)
+		} else if err != nil && queue.NumRequeues(key) < maxRetries {
 			fmt.Printf("Error reconciling %s: %v, requeuing\n", key, err)
 			queue.AddRateLimited(key) // requeue with backoff
+		} else if err != nil {
+			fmt.Printf("Error reconciling %s: %v, giving up after %d retries\n", key, err, maxRetries)
+			queue.Forget(key) // drop the key rather than hammer the API server
 		} else {
 			queue.Forget(key) // clear rate limiter tracking
 		}
@@ -97,32 +445,168 @@ func main() {
 	}
 }
 
-func reconcile(clientset kubernetes.Interface, lister corev1listers.NamespaceLister, key string) error {
+// reconcile labels the namespace key. If inheritAnnotation is set, the team
+// comes from the namespace that annotation names; otherwise, or if that has
+// none, from the longest matching prefix in prefixes, and finally defaultTeam.
+// The label is added with a patch of patchType, merge or JSON; with dryRun
+// the patch is logged instead of sent. Labels added are recorded in audit,
+// if set.
+func reconcile(clientset kubernetes.Interface, lister corev1listers.NamespaceLister, skip map[string]struct{}, inheritAnnotation string, prefixes map[string]string, dryRun bool, patchType types.PatchType, audit *auditLog, key string) error {
 	ns, err := lister.Get(key)
 	if err != nil {
 		return err // will be requeued
 	}
 
-	// Skip system namespaces
-	switch ns.Name {
-	case "kube-system", "kube-public", "kube-node-lease", "default":
+	if outcome := labelOutcome(skip, ns.Name, ns.Labels); outcome != outcomeLabeled {
+		reconcileOutcomes.WithLabelValues(outcome).Inc()
 		return nil
 	}
 
-	// Check if "team" label exists
-	if _, exists := ns.Labels["team"]; exists {
-		return nil // already labeled, nothing to do
+	team := inheritedTeam(inheritAnnotation, ns.Annotations, prefixTeam(prefixes, ns.Name), func(ref string) (map[string]string, error) {
+		parent, err := lister.Get(ref)
+		if err != nil {
+			return nil, err
+		}
+		return parent.Labels, nil
+	})
+
+	patch := labelPatch(patchType, team, ns.Labels)
+	if dryRun {
+		fmt.Printf("Dry run: would label namespace %s with patch %s\n", ns.Name, patch)
+		reconcileOutcomes.WithLabelValues(outcomeDryRun).Inc()
+		return nil
 	}
 
 	// Patch the namespace to add the label
-	fmt.Printf("Labeling namespace %s with team=unassigned\n", ns.Name)
-	patch := []byte(`{"metadata":{"labels":{"team":"unassigned"}}}`)
+	fmt.Printf("Labeling namespace %s with team=%s\n", ns.Name, team)
 	_, err = clientset.CoreV1().Namespaces().Patch(
 		context.TODO(),
 		ns.Name,
-		types.MergePatchType,
+		patchType,
+		patch,
+		metav1.PatchOptions{},
+	)
+	if err != nil {
+		return err
+	}
+	reconcileOutcomes.WithLabelValues(outcomeLabeled).Inc()
+	recordAudit(audit, "namespaces", ns.Name, team)
+	return nil
+}
+
+// reconcileDynamic is reconcile for an arbitrary resource, read from a
+// dynamic informer's lister and patched through the dynamic client. A parent
+// named by inheritAnnotation is a "namespace/name" key of the same resource;
+// a bare name is looked up in the object's own namespace.
+func reconcileDynamic(client dynamic.Interface, gvr schema.GroupVersionResource, lister cache.GenericLister, skip map[string]struct{}, inheritAnnotation string, prefixes map[string]string, dryRun bool, patchType types.PatchType, audit *auditLog, key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+
+	var obj interface{}
+	if namespace == "" {
+		obj, err = lister.Get(name)
+	} else {
+		obj, err = lister.ByNamespace(namespace).Get(name)
+	}
+	if err != nil {
+		return err // will be requeued
+	}
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return err
+	}
+
+	// Cluster-scoped namespaces are their own namespace for the system check
+	systemKey := namespace
+	if gvr == namespacesGVR {
+		systemKey = name
+	}
+	if outcome := labelOutcome(skip, systemKey, accessor.GetLabels()); outcome != outcomeLabeled {
+		reconcileOutcomes.WithLabelValues(outcome).Inc()
+		return nil
+	}
+
+	team := inheritedTeam(inheritAnnotation, accessor.GetAnnotations(), prefixTeam(prefixes, name), func(ref string) (map[string]string, error) {
+		return dynamicParentLabels(lister, namespace, ref)
+	})
+
+	patch := labelPatch(patchType, team, accessor.GetLabels())
+	if dryRun {
+		fmt.Printf("Dry run: would label %s %s with patch %s\n", gvr.Resource, key, patch)
+		reconcileOutcomes.WithLabelValues(outcomeDryRun).Inc()
+		return nil
+	}
+
+	fmt.Printf("Labeling %s %s with team=%s\n", gvr.Resource, key, team)
+	_, err = client.Resource(gvr).Namespace(namespace).Patch(
+		context.TODO(),
+		name,
+		patchType,
 		patch,
 		metav1.PatchOptions{},
 	)
-	return err
+	if err != nil {
+		return err
+	}
+	reconcileOutcomes.WithLabelValues(outcomeLabeled).Inc()
+	recordAudit(audit, gvr.Resource, key, team)
+	return nil
+}
+
+// recordAudit records a label added in audit. The label is already in place,
+// so a failure is only logged: retrying the key would find it labeled.
+func recordAudit(audit *auditLog, resource, object, team string) {
+	if err := audit.record(resource, object, team); err != nil {
+		fmt.Printf("Failed to record labeling %s %s in the audit ConfigMap: %v\n", resource, object, err)
+	}
+}
+
+// dynamicParentLabels returns the labels of the parent ref, a
+// "namespace/name" key or a bare name in namespace, read from lister.
+func dynamicParentLabels(lister cache.GenericLister, namespace, ref string) (map[string]string, error) {
+	parentNamespace, parentName, err := cache.SplitMetaNamespaceKey(ref)
+	if err != nil {
+		return nil, err
+	}
+	if parentNamespace == "" {
+		parentNamespace = namespace
+	}
+
+	var obj interface{}
+	if parentNamespace == "" {
+		obj, err = lister.Get(parentName)
+	} else {
+		obj, err = lister.ByNamespace(parentNamespace).Get(parentName)
+	}
+	if err != nil {
+		return nil, err
+	}
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return nil, err
+	}
+	return accessor.GetLabels(), nil
+}
+
+// labelOutcome decides whether an object living in namespace with the given
+// labels should be labeled, returning outcomeLabeled if so and the skip
+// outcome otherwise. Namespaces in skip count as system namespaces.
+func labelOutcome(skip map[string]struct{}, namespace string, labels map[string]string) string {
+	// Skip system namespaces
+	if _, ok := skip[namespace]; ok {
+		return outcomeSkippedSystem
+	}
+
+	// Respect objects that opted out
+	if labels[optOutLabel] == "true" {
+		return outcomeSkippedOptOut
+	}
+
+	// Check if "team" label exists
+	if _, exists := labels["team"]; exists {
+		return outcomeSkippedAlreadyLabeled
+	}
+	return outcomeLabeled
 }
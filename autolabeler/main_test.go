@@ -2,14 +2,36 @@ package main
 
 import (
 	"context"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"sync/atomic"
+	"syscall"
 	"testing"
+	"time"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
 )
 
+// defaultSkip is the skip set used when --skip-namespaces is not given.
+var defaultSkip = parseSkipNamespaces(defaultSkipNamespaces)
+
 func newNamespace(name string, labels map[string]string) *corev1.Namespace {
 	return &corev1.Namespace{
 		ObjectMeta: metav1.ObjectMeta{
@@ -30,7 +52,7 @@ func TestReconcile_AddsLabelToUnlabeledNamespace(t *testing.T) {
 	factory.WaitForCacheSync(stopCh)
 	defer close(stopCh)
 
-	err := reconcile(fakeClient, nsInformer.Lister(), "test-ns")
+	err := reconcile(fakeClient, nsInformer.Lister(), defaultSkip, "", nil, false, types.MergePatchType, nil, "test-ns")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -44,6 +66,66 @@ func TestReconcile_AddsLabelToUnlabeledNamespace(t *testing.T) {
 	}
 }
 
+// captureStdout returns what fn prints to stdout.
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("failed to create pipe: %v", err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	fn()
+	w.Close()
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("failed to read captured stdout: %v", err)
+	}
+	return string(out)
+}
+
+func TestReconcile_DryRunLogsWithoutPatching(t *testing.T) {
+	fakeClient := fake.NewClientset(newNamespace("test-ns", nil), newNamespace("kube-system", nil))
+	factory := informers.NewSharedInformerFactory(fakeClient, 0)
+	nsInformer := factory.Core().V1().Namespaces()
+	nsInformer.Informer()
+	stopCh := make(chan struct{})
+	factory.Start(stopCh)
+	factory.WaitForCacheSync(stopCh)
+	defer close(stopCh)
+	fakeClient.ClearActions()
+
+	out := captureStdout(t, func() {
+		for _, name := range []string{"test-ns", "kube-system"} {
+			if err := reconcile(fakeClient, nsInformer.Lister(), defaultSkip, "", nil, true, types.MergePatchType, nil, name); err != nil {
+				t.Fatalf("unexpected error for %s: %v", name, err)
+			}
+		}
+	})
+
+	want := `Dry run: would label namespace test-ns with patch {"metadata":{"labels":{"team":"unassigned"}}}`
+	if !strings.Contains(out, want) {
+		t.Errorf("expected the decision to be logged as %q, got: %q", want, out)
+	}
+	if strings.Contains(out, "kube-system") {
+		t.Errorf("expected the skipped namespace not to be logged, got: %q", out)
+	}
+	for _, action := range fakeClient.Actions() {
+		if action.GetVerb() == "patch" {
+			t.Errorf("expected no patch in dry-run mode, got %v", action)
+		}
+	}
+	updated, err := fakeClient.CoreV1().Namespaces().Get(context.TODO(), "test-ns", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get namespace: %v", err)
+	}
+	if len(updated.Labels) != 0 {
+		t.Errorf("expected labels to be unchanged, got: %v", updated.Labels)
+	}
+}
+
 func TestReconcile_SkipsNamespaceWithExistingLabel(t *testing.T) {
 	ns := newNamespace("test-ns", map[string]string{"team": "backend"})
 	fakeClient := fake.NewClientset(ns)
@@ -55,7 +137,7 @@ func TestReconcile_SkipsNamespaceWithExistingLabel(t *testing.T) {
 	factory.WaitForCacheSync(stopCh)
 	defer close(stopCh)
 
-	err := reconcile(fakeClient, nsInformer.Lister(), "test-ns")
+	err := reconcile(fakeClient, nsInformer.Lister(), defaultSkip, "", nil, false, types.MergePatchType, nil, "test-ns")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -84,7 +166,7 @@ func TestReconcile_SkipsSystemNamespaces(t *testing.T) {
 			factory.WaitForCacheSync(stopCh)
 			defer close(stopCh)
 
-			err := reconcile(fakeClient, nsInformer.Lister(), name)
+			err := reconcile(fakeClient, nsInformer.Lister(), defaultSkip, "", nil, false, types.MergePatchType, nil, name)
 			if err != nil {
 				t.Fatalf("unexpected error: %v", err)
 			}
@@ -100,6 +182,45 @@ func TestReconcile_SkipsSystemNamespaces(t *testing.T) {
 	}
 }
 
+func TestReconcile_SkipsCustomNamespaces(t *testing.T) {
+	skip := parseSkipNamespaces(" istio-system, monitoring ,,")
+	if len(skip) != 2 {
+		t.Fatalf("expected 2 skipped namespaces, got %v", skip)
+	}
+
+	fakeClient := fake.NewClientset(newNamespace("monitoring", nil), newNamespace("default", nil))
+	factory := informers.NewSharedInformerFactory(fakeClient, 0)
+	nsInformer := factory.Core().V1().Namespaces()
+	nsInformer.Informer()
+	stopCh := make(chan struct{})
+	factory.Start(stopCh)
+	factory.WaitForCacheSync(stopCh)
+	defer close(stopCh)
+
+	for _, name := range []string{"monitoring", "default"} {
+		if err := reconcile(fakeClient, nsInformer.Lister(), skip, "", nil, false, types.MergePatchType, nil, name); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	monitoring, err := fakeClient.CoreV1().Namespaces().Get(context.TODO(), "monitoring", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get namespace: %v", err)
+	}
+	if _, exists := monitoring.Labels["team"]; exists {
+		t.Errorf("skipped namespace monitoring should not have team label, got: %v", monitoring.Labels)
+	}
+
+	// The custom set replaces the defaults rather than adding to them
+	def, err := fakeClient.CoreV1().Namespaces().Get(context.TODO(), "default", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get namespace: %v", err)
+	}
+	if def.Labels["team"] != "unassigned" {
+		t.Errorf("expected default to be labeled when not in the skip set, got: %v", def.Labels)
+	}
+}
+
 func TestReconcile_NonExistentNamespace(t *testing.T) {
 	fakeClient := fake.NewClientset()
 	factory := informers.NewSharedInformerFactory(fakeClient, 0)
@@ -110,7 +231,7 @@ func TestReconcile_NonExistentNamespace(t *testing.T) {
 	factory.WaitForCacheSync(stopCh)
 	defer close(stopCh)
 
-	err := reconcile(fakeClient, nsInformer.Lister(), "does-not-exist")
+	err := reconcile(fakeClient, nsInformer.Lister(), defaultSkip, "", nil, false, types.MergePatchType, nil, "does-not-exist")
 	if err == nil {
 		t.Fatal("expected error for non-existent namespace, got nil")
 	}
@@ -127,7 +248,7 @@ func TestReconcile_PreservesExistingLabels(t *testing.T) {
 	factory.WaitForCacheSync(stopCh)
 	defer close(stopCh)
 
-	err := reconcile(fakeClient, nsInformer.Lister(), "test-ns")
+	err := reconcile(fakeClient, nsInformer.Lister(), defaultSkip, "", nil, false, types.MergePatchType, nil, "test-ns")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -143,3 +264,606 @@ func TestReconcile_PreservesExistingLabels(t *testing.T) {
 		t.Errorf("existing label env=production was lost, got: %v", updated.Labels)
 	}
 }
+
+func TestReconcile_JSONPatchAddsLabel(t *testing.T) {
+	for _, labels := range []map[string]string{nil, {"env": "production"}} {
+		ns := newNamespace("test-ns", labels)
+		fakeClient := fake.NewClientset(ns)
+		indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+		if err := indexer.Add(ns); err != nil {
+			t.Fatal(err)
+		}
+
+		err := reconcile(fakeClient, corev1listers.NewNamespaceLister(indexer), defaultSkip, "", nil, false, types.JSONPatchType, nil, "test-ns")
+		if err != nil {
+			t.Fatalf("unexpected error with labels %v: %v", labels, err)
+		}
+
+		updated, err := fakeClient.CoreV1().Namespaces().Get(context.TODO(), "test-ns", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("failed to get namespace: %v", err)
+		}
+		if updated.Labels["team"] != "unassigned" || updated.Labels["env"] != labels["env"] {
+			t.Errorf("expected team=unassigned added to %v, got: %v", labels, updated.Labels)
+		}
+	}
+}
+
+func TestReconcile_JSONPatchRejectedWhenLabelAddedConcurrently(t *testing.T) {
+	for _, labels := range []map[string]string{nil, {"env": "production"}} {
+		// The cache still shows the namespace unlabeled, but another writer
+		// has labeled it on the server since.
+		cached := newNamespace("test-ns", labels)
+		indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+		if err := indexer.Add(cached); err != nil {
+			t.Fatal(err)
+		}
+		current := cached.DeepCopy()
+		current.Labels = map[string]string{"team": "platform", "env": "production"}
+		fakeClient := fake.NewClientset(current)
+
+		err := reconcile(fakeClient, corev1listers.NewNamespaceLister(indexer), defaultSkip, "", nil, false, types.JSONPatchType, nil, "test-ns")
+		if err == nil {
+			t.Fatalf("expected the patch to be rejected with cached labels %v", labels)
+		}
+
+		updated, err := fakeClient.CoreV1().Namespaces().Get(context.TODO(), "test-ns", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("failed to get namespace: %v", err)
+		}
+		if updated.Labels["team"] != "platform" {
+			t.Errorf("the other writer's team label was overwritten, got: %v", updated.Labels)
+		}
+	}
+}
+
+func TestReconcile_LabelsTeamFromNamePrefix(t *testing.T) {
+	prefixes, err := parsePrefixMap(" team-backend=backend-team, team-frontend=frontend-team,,team-backend-legacy=legacy-team")
+	if err != nil {
+		t.Fatalf("unexpected error parsing prefix map: %v", err)
+	}
+	if len(prefixes) != 3 {
+		t.Fatalf("expected 3 prefixes, got %v", prefixes)
+	}
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "team-backend-prod", want: "backend-team"},
+		{name: "team-frontend-dev", want: "frontend-team"},
+		{name: "sandbox", want: "unassigned"},
+		{name: "team-backend-legacy-prod", want: "legacy-team"},
+	}
+	var objects []runtime.Object
+	for _, tt := range tests {
+		objects = append(objects, newNamespace(tt.name, nil))
+	}
+	fakeClient := fake.NewClientset(objects...)
+	factory := informers.NewSharedInformerFactory(fakeClient, 0)
+	nsInformer := factory.Core().V1().Namespaces()
+	nsInformer.Informer()
+	stopCh := make(chan struct{})
+	factory.Start(stopCh)
+	factory.WaitForCacheSync(stopCh)
+	defer close(stopCh)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := reconcile(fakeClient, nsInformer.Lister(), defaultSkip, "", prefixes, false, types.MergePatchType, nil, tt.name); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			updated, err := fakeClient.CoreV1().Namespaces().Get(context.TODO(), tt.name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("failed to get namespace: %v", err)
+			}
+			if updated.Labels["team"] != tt.want {
+				t.Errorf("expected team=%s, got: %v", tt.want, updated.Labels)
+			}
+		})
+	}
+}
+
+func TestParsePrefixMap_RejectsMalformedEntries(t *testing.T) {
+	for _, arg := range []string{"backend", "=backend-team", "backend="} {
+		if _, err := parsePrefixMap(arg); err == nil {
+			t.Errorf("expected an error for %q", arg)
+		}
+	}
+}
+
+func TestReconcile_InheritsTeamFromParent(t *testing.T) {
+	const annotation = "autolabeler/parent"
+	parent := newNamespace("project-a", map[string]string{"team": "payments"})
+	child := newNamespace("project-a-dev", nil)
+	child.Annotations = map[string]string{annotation: "project-a"}
+	orphan := newNamespace("orphan-dev", nil)
+	orphan.Annotations = map[string]string{annotation: "missing-project"}
+	fakeClient := fake.NewClientset(parent, child, orphan)
+	factory := informers.NewSharedInformerFactory(fakeClient, 0)
+	nsInformer := factory.Core().V1().Namespaces()
+	nsInformer.Informer()
+	stopCh := make(chan struct{})
+	factory.Start(stopCh)
+	factory.WaitForCacheSync(stopCh)
+	defer close(stopCh)
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "project-a-dev", want: "payments"},
+		{name: "orphan-dev", want: "unassigned"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := reconcile(fakeClient, nsInformer.Lister(), defaultSkip, annotation, nil, false, types.MergePatchType, nil, tt.name); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			updated, err := fakeClient.CoreV1().Namespaces().Get(context.TODO(), tt.name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("failed to get namespace: %v", err)
+			}
+			if updated.Labels["team"] != tt.want {
+				t.Errorf("expected team=%s, got: %v", tt.want, updated.Labels)
+			}
+		})
+	}
+}
+
+func TestReconcile_CountsOutcomes(t *testing.T) {
+	tests := []struct {
+		name    string
+		ns      *corev1.Namespace
+		outcome string
+	}{
+		{"system", newNamespace("kube-system", nil), outcomeSkippedSystem},
+		{"already labeled", newNamespace("labeled-ns", map[string]string{"team": "backend"}), outcomeSkippedAlreadyLabeled},
+		{"opt out", newNamespace("opt-out-ns", map[string]string{optOutLabel: "true"}), outcomeSkippedOptOut},
+		{"labeled", newNamespace("fresh-ns", nil), outcomeLabeled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := fake.NewClientset(tt.ns)
+			factory := informers.NewSharedInformerFactory(fakeClient, 0)
+			nsInformer := factory.Core().V1().Namespaces()
+			nsInformer.Informer()
+			stopCh := make(chan struct{})
+			factory.Start(stopCh)
+			factory.WaitForCacheSync(stopCh)
+			defer close(stopCh)
+
+			before := map[string]float64{}
+			for _, o := range []string{outcomeLabeled, outcomeSkippedSystem, outcomeSkippedAlreadyLabeled, outcomeSkippedOptOut} {
+				before[o] = testutil.ToFloat64(reconcileOutcomes.WithLabelValues(o))
+			}
+
+			if err := reconcile(fakeClient, nsInformer.Lister(), defaultSkip, "", nil, false, types.MergePatchType, nil, tt.ns.Name); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			for o, b := range before {
+				want := b
+				if o == tt.outcome {
+					want++
+				}
+				if got := testutil.ToFloat64(reconcileOutcomes.WithLabelValues(o)); got != want {
+					t.Errorf("outcome %q: expected %v, got %v", o, want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestReconcile_SkipsOptedOutNamespace(t *testing.T) {
+	ns := newNamespace("test-ns", map[string]string{optOutLabel: "true"})
+	fakeClient := fake.NewClientset(ns)
+	factory := informers.NewSharedInformerFactory(fakeClient, 0)
+	nsInformer := factory.Core().V1().Namespaces()
+	nsInformer.Informer()
+	stopCh := make(chan struct{})
+	factory.Start(stopCh)
+	factory.WaitForCacheSync(stopCh)
+	defer close(stopCh)
+
+	err := reconcile(fakeClient, nsInformer.Lister(), defaultSkip, "", nil, false, types.MergePatchType, nil, "test-ns")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	updated, err := fakeClient.CoreV1().Namespaces().Get(context.TODO(), "test-ns", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get namespace: %v", err)
+	}
+	if _, exists := updated.Labels["team"]; exists {
+		t.Errorf("opted-out namespace should not have team label, got: %v", updated.Labels)
+	}
+}
+
+func TestReconcile_RecordsLabelInAuditConfigMap(t *testing.T) {
+	ns := newNamespace("test-ns", nil)
+	fakeClient := fake.NewClientset(ns)
+	factory := informers.NewSharedInformerFactory(fakeClient, 0)
+	nsInformer := factory.Core().V1().Namespaces()
+	nsInformer.Informer()
+	stopCh := make(chan struct{})
+	factory.Start(stopCh)
+	factory.WaitForCacheSync(stopCh)
+	defer close(stopCh)
+
+	audit, err := newAuditLog(fakeClient, "audit/autolabeler-audit", 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	audit.now = func() time.Time { return time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC) }
+
+	if err := reconcile(fakeClient, nsInformer.Lister(), defaultSkip, "", nil, false, types.MergePatchType, audit, "test-ns"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cm, err := fakeClient.CoreV1().ConfigMaps("audit").Get(context.TODO(), "autolabeler-audit", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get audit ConfigMap: %v", err)
+	}
+	want := `{"time":"2026-10-15T12:00:00Z","resource":"namespaces","object":"test-ns","team":"unassigned"}` + "\n"
+	if got := cm.Data[auditLogKey]; got != want {
+		t.Errorf("audit log = %q, want %q", got, want)
+	}
+}
+
+func TestAuditLog_TrimsOldestEntries(t *testing.T) {
+	fakeClient := fake.NewClientset()
+	audit, err := newAuditLog(fakeClient, "audit/autolabeler-audit", 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, name := range []string{"ns-1", "ns-2", "ns-3", "ns-4", "ns-5"} {
+		if err := audit.record("namespaces", name, "unassigned"); err != nil {
+			t.Fatalf("failed to record %s: %v", name, err)
+		}
+	}
+
+	cm, err := fakeClient.CoreV1().ConfigMaps("audit").Get(context.TODO(), "autolabeler-audit", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get audit ConfigMap: %v", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(cm.Data[auditLogKey], "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 entries, got %d: %q", len(lines), cm.Data[auditLogKey])
+	}
+	for i, want := range []string{"ns-3", "ns-4", "ns-5"} {
+		if !strings.Contains(lines[i], `"object":"`+want+`"`) {
+			t.Errorf("entry %d = %s, want %s", i, lines[i], want)
+		}
+	}
+}
+
+func TestNewAuditLog_RejectsMalformedArguments(t *testing.T) {
+	for _, ref := range []string{"", "audit", "/audit", "audit/"} {
+		if _, err := newAuditLog(fake.NewClientset(), ref, 10); err == nil {
+			t.Errorf("expected an error for %q", ref)
+		}
+	}
+	if _, err := newAuditLog(fake.NewClientset(), "audit/log", 0); err == nil {
+		t.Error("expected an error for 0 entries")
+	}
+}
+
+func TestReconcileDynamic_LabelsConfigMaps(t *testing.T) {
+	gvr := schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+	fresh := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "app-config", Namespace: "apps", Labels: map[string]string{"env": "production"}}}
+	system := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "coredns", Namespace: "kube-system"}}
+	fakeClient := dynamicfake.NewSimpleDynamicClient(scheme.Scheme, fresh, system)
+	factory := dynamicinformer.NewDynamicSharedInformerFactory(fakeClient, 0)
+	informer := factory.ForResource(gvr)
+	informer.Informer()
+	stopCh := make(chan struct{})
+	factory.Start(stopCh)
+	factory.WaitForCacheSync(stopCh)
+	defer close(stopCh)
+
+	for _, key := range []string{"apps/app-config", "kube-system/coredns"} {
+		if err := reconcileDynamic(fakeClient, gvr, informer.Lister(), defaultSkip, "", nil, false, types.MergePatchType, nil, key); err != nil {
+			t.Fatalf("unexpected error reconciling %s: %v", key, err)
+		}
+	}
+
+	updated, err := fakeClient.Resource(gvr).Namespace("apps").Get(context.TODO(), "app-config", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get configmap: %v", err)
+	}
+	if labels := updated.GetLabels(); labels["team"] != "unassigned" || labels["env"] != "production" {
+		t.Errorf("expected team=unassigned alongside env=production, got: %v", labels)
+	}
+
+	skipped, err := fakeClient.Resource(gvr).Namespace("kube-system").Get(context.TODO(), "coredns", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get configmap: %v", err)
+	}
+	if _, exists := skipped.GetLabels()["team"]; exists {
+		t.Errorf("configmap in a system namespace should not have team label, got: %v", skipped.GetLabels())
+	}
+}
+
+func TestParseResource(t *testing.T) {
+	tests := []struct {
+		arg     string
+		want    schema.GroupVersionResource
+		wantErr bool
+	}{
+		{arg: "namespaces", want: namespacesGVR},
+		{arg: "nodes", want: schema.GroupVersionResource{Version: "v1", Resource: "nodes"}},
+		{arg: "configmaps.v1.", want: schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}},
+		{arg: "deployments.v1.apps", want: schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}},
+		{arg: "", wantErr: true},
+		{arg: "deployments.apps", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.arg, func(t *testing.T) {
+			got, err := parseResource(tt.arg)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error for %q, got %v", tt.arg, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRunWorker_ReturnsAfterShutdownSignal(t *testing.T) {
+	fakeClient := fake.NewClientset(newNamespace("test-ns", nil))
+	factory := informers.NewSharedInformerFactory(fakeClient, 0)
+	nsInformer := factory.Core().V1().Namespaces()
+	nsInformer.Informer()
+	stopCh := make(chan struct{})
+	factory.Start(stopCh)
+	factory.WaitForCacheSync(stopCh)
+
+	queue := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[string]())
+	queue.Add("test-ns")
+	signals := make(chan os.Signal, 1)
+	go shutdownOnSignal(signals, stopFunc(stopCh, queue))
+
+	done := make(chan struct{})
+	go func() {
+		runWorker(queue, 5, func(key string) error {
+			return reconcile(fakeClient, nsInformer.Lister(), defaultSkip, "", nil, false, types.MergePatchType, nil, key)
+		})
+		close(done)
+	}()
+
+	signals <- syscall.SIGTERM
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("worker did not return after the shutdown signal")
+	}
+
+	select {
+	case <-stopCh:
+	default:
+		t.Error("expected stopCh to be closed")
+	}
+	updated, err := fakeClient.CoreV1().Namespaces().Get(context.TODO(), "test-ns", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get namespace: %v", err)
+	}
+	if updated.Labels["team"] != "unassigned" {
+		t.Errorf("expected the queued namespace to be processed before exiting, got: %v", updated.Labels)
+	}
+}
+
+// recordingQueue records AddAfter calls and shuts the queue down on the first
+// one, so runWorker returns once the throttled key has been handled.
+type recordingQueue struct {
+	workqueue.TypedRateLimitingInterface[string]
+	delays []time.Duration
+}
+
+func (q *recordingQueue) AddAfter(key string, d time.Duration) {
+	q.delays = append(q.delays, d)
+	q.ShutDown()
+}
+
+func TestRunWorker_RequeuesAfterRetryAfterWhenThrottled(t *testing.T) {
+	fakeClient := fake.NewClientset(newNamespace("test-ns", nil))
+	fakeClient.PrependReactor("patch", "namespaces", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewTooManyRequests("slow down", 7)
+	})
+	factory := informers.NewSharedInformerFactory(fakeClient, 0)
+	nsInformer := factory.Core().V1().Namespaces()
+	nsInformer.Informer()
+	stopCh := make(chan struct{})
+	factory.Start(stopCh)
+	factory.WaitForCacheSync(stopCh)
+	defer close(stopCh)
+
+	queue := &recordingQueue{TypedRateLimitingInterface: workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[string]())}
+	queue.Add("test-ns")
+	runWorker(queue, 5, func(key string) error {
+		return reconcile(fakeClient, nsInformer.Lister(), defaultSkip, "", nil, false, types.MergePatchType, nil, key)
+	})
+
+	if len(queue.delays) != 1 || queue.delays[0] != 7*time.Second {
+		t.Fatalf("expected one requeue after 7s, got %v", queue.delays)
+	}
+	if n := queue.NumRequeues("test-ns"); n != 0 {
+		t.Errorf("expected the throttled key not to go through the rate limiter, got %d requeues", n)
+	}
+}
+
+// forgettingQueue shuts the queue down when a key is forgotten, so runWorker
+// returns once a key has been dropped.
+type forgettingQueue struct {
+	workqueue.TypedRateLimitingInterface[string]
+	forgotten []string
+}
+
+func (q *forgettingQueue) Forget(key string) {
+	q.forgotten = append(q.forgotten, key)
+	q.TypedRateLimitingInterface.Forget(key)
+	q.ShutDown()
+}
+
+func TestRunWorker_DropsKeyAfterMaxRetries(t *testing.T) {
+	fakeClient := fake.NewClientset(newNamespace("test-ns", nil))
+	var patches int
+	fakeClient.PrependReactor("patch", "namespaces", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		patches++
+		return true, nil, apierrors.NewForbidden(schema.GroupResource{Resource: "namespaces"}, "test-ns", errors.New("denied by webhook"))
+	})
+	factory := informers.NewSharedInformerFactory(fakeClient, 0)
+	nsInformer := factory.Core().V1().Namespaces()
+	nsInformer.Informer()
+	stopCh := make(chan struct{})
+	factory.Start(stopCh)
+	factory.WaitForCacheSync(stopCh)
+	defer close(stopCh)
+
+	queue := &forgettingQueue{TypedRateLimitingInterface: workqueue.NewTypedRateLimitingQueue(
+		workqueue.NewTypedItemExponentialFailureRateLimiter[string](time.Millisecond, time.Millisecond))}
+	queue.Add("test-ns")
+	done := make(chan struct{})
+	go func() {
+		runWorker(queue, 3, func(key string) error {
+			return reconcile(fakeClient, nsInformer.Lister(), defaultSkip, "", nil, false, types.MergePatchType, nil, key)
+		})
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("worker did not drop the failing key")
+	}
+
+	if patches != 4 {
+		t.Errorf("expected the first attempt plus 3 retries, got %d patches", patches)
+	}
+	if len(queue.forgotten) != 1 || queue.forgotten[0] != "test-ns" {
+		t.Errorf("expected test-ns to be forgotten once, got %v", queue.forgotten)
+	}
+	if n := queue.NumRequeues("test-ns"); n != 0 {
+		t.Errorf("expected the dropped key to be cleared from the rate limiter, got %d requeues", n)
+	}
+	if n := queue.Len(); n != 0 {
+		t.Errorf("expected the dropped key not to be requeued, %d keys queued", n)
+	}
+}
+
+func TestRunWorkers_LabelsAllNamespacesConcurrently(t *testing.T) {
+	names := []string{"ns-a", "ns-b", "ns-c", "ns-d", "ns-e", "ns-f", "ns-g"}
+	var objects []runtime.Object
+	for _, name := range names {
+		objects = append(objects, newNamespace(name, nil))
+	}
+	fakeClient := fake.NewClientset(objects...)
+	factory := informers.NewSharedInformerFactory(fakeClient, 0)
+	nsInformer := factory.Core().V1().Namespaces()
+	nsInformer.Informer()
+	stopCh := make(chan struct{})
+	factory.Start(stopCh)
+	factory.WaitForCacheSync(stopCh)
+	defer close(stopCh)
+
+	queue := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[string]())
+	for _, name := range names {
+		queue.Add(name)
+	}
+	// Everything is queued up front, so shutting down now lets the workers
+	// drain the queue and return.
+	queue.ShutDown()
+
+	var active, maxActive atomic.Int32
+	done := make(chan struct{})
+	go func() {
+		runWorkers(3, queue, 5, func(key string) error {
+			n := active.Add(1)
+			defer active.Add(-1)
+			for {
+				m := maxActive.Load()
+				if n <= m || maxActive.CompareAndSwap(m, n) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			return reconcile(fakeClient, nsInformer.Lister(), defaultSkip, "", nil, false, types.MergePatchType, nil, key)
+		})
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("workers did not return after the queue was drained")
+	}
+
+	for _, name := range names {
+		ns, err := fakeClient.CoreV1().Namespaces().Get(context.TODO(), name, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("failed to get namespace %s: %v", name, err)
+		}
+		if ns.Labels["team"] != "unassigned" {
+			t.Errorf("expected namespace %s to be labeled, got: %v", name, ns.Labels)
+		}
+	}
+	if maxActive.Load() < 2 {
+		t.Errorf("expected keys to be processed concurrently, at most %d ran at once", maxActive.Load())
+	}
+}
+
+func TestRunLeaderElection_AcquiresAndReleasesLease(t *testing.T) {
+	fakeClient := fake.NewClientset()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	leading := make(chan struct{})
+	stopped := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		runLeaderElection(ctx, fakeClient, "default", "autolabeler", "replica-a",
+			func() { close(leading) },
+			func() { close(stopped) })
+		close(done)
+	}()
+
+	select {
+	case <-leading:
+	case <-time.After(5 * time.Second):
+		t.Fatal("did not acquire leadership")
+	}
+	lease, err := fakeClient.CoordinationV1().Leases("default").Get(context.TODO(), "autolabeler", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get lease: %v", err)
+	}
+	if lease.Spec.HolderIdentity == nil || *lease.Spec.HolderIdentity != "replica-a" {
+		t.Errorf("expected replica-a to hold the lease, got: %v", lease.Spec.HolderIdentity)
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("leader election did not return after cancel")
+	}
+	select {
+	case <-stopped:
+	default:
+		t.Error("expected onStoppedLeading to be called")
+	}
+	lease, err = fakeClient.CoordinationV1().Leases("default").Get(context.TODO(), "autolabeler", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get lease: %v", err)
+	}
+	if lease.Spec.HolderIdentity != nil && *lease.Spec.HolderIdentity != "" {
+		t.Errorf("expected the lease to be released, still held by %s", *lease.Spec.HolderIdentity)
+	}
+}
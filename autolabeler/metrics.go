@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Reconcile outcomes, used as the "outcome" label of reconcileOutcomes.
+const (
+	outcomeLabeled               = "labeled"
+	outcomeSkippedSystem         = "skipped_system"
+	outcomeSkippedAlreadyLabeled = "skipped_already_labeled"
+	outcomeSkippedOptOut         = "skipped_opt_out"
+	outcomeDryRun                = "dry_run"
+)
+
+// reconcileOutcomes counts why each reconcile did (or did not) label a namespace.
+var reconcileOutcomes = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "autolabeler_reconcile_outcomes_total",
+		Help: "Number of namespace reconciles by outcome.",
+	},
+	[]string{"outcome"},
+)
+
+func init() {
+	prometheus.MustRegister(reconcileOutcomes)
+}
+
+// serveMetrics exposes the Prometheus metrics endpoint on addr.
+func serveMetrics(addr string) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	fmt.Printf("Serving metrics on %s/metrics\n", addr)
+	if err := http.ListenAndServe(addr, mux); err != nil {
+		fmt.Printf("Metrics server failed: %v\n", err)
+	}
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"unicode/utf8"
+)
+
+// MaxBodyLength is GitHub's limit on the length of an issue body, in characters.
+const MaxBodyLength = 65536
+
+// MaxTitleLength is GitHub's limit on the length of an issue title, in characters.
+const MaxTitleLength = 256
+
+var (
+	// ErrBodyTooLong is wrapped by the errors of CheckBodyLength.
+	ErrBodyTooLong = errors.New("issue body too long")
+	// ErrTitleTooLong is wrapped by the errors of CheckTitleLength.
+	ErrTitleTooLong = errors.New("issue title too long")
+)
+
+// BodyTruncatedNotice ends bodies that TruncateBody cut down to MaxBodyLength.
+const BodyTruncatedNotice = "\n\n_This issue body was truncated to fit GitHub's size limit._"
+
+// CheckBodyLength returns an error if body is longer than MaxBodyLength
+// characters. The controller and admission checks both use it, so they agree
+// on what GitHub would reject.
+func CheckBodyLength(body string) error {
+	if n := utf8.RuneCountInString(body); n > MaxBodyLength {
+		return fmt.Errorf("%w: body is %d characters, more than GitHub's limit of %d", ErrBodyTooLong, n, MaxBodyLength)
+	}
+	return nil
+}
+
+// CheckTitleLength returns an error if title is longer than MaxTitleLength
+// characters. Unlike bodies, titles are never truncated.
+func CheckTitleLength(title string) error {
+	if n := utf8.RuneCountInString(title); n > MaxTitleLength {
+		return fmt.Errorf("%w: title is %d characters, more than GitHub's limit of %d", ErrTitleTooLong, n, MaxTitleLength)
+	}
+	return nil
+}
+
+// TruncateBody shortens body to at most limit characters, ending it with
+// BodyTruncatedNotice. Bodies within the limit are returned unchanged. The
+// limit is MaxBodyLength less whatever is appended to the body afterwards.
+func TruncateBody(body string, limit int) string {
+	if utf8.RuneCountInString(body) <= limit {
+		return body
+	}
+	keep := max(limit-utf8.RuneCountInString(BodyTruncatedNotice), 0)
+	return string([]rune(body)[:keep]) + BodyTruncatedNotice
+}
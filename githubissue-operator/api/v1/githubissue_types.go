@@ -23,6 +23,77 @@ import (
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
+// SyncMode selects when a GitHubIssue is re-synced with the remote issue.
+type SyncMode string
+
+const (
+	// SyncModePoll re-syncs on spec changes and on a fixed interval.
+	SyncModePoll SyncMode = "poll"
+	// SyncModeEvent re-syncs only on spec changes and external triggers.
+	SyncModeEvent SyncMode = "event"
+)
+
+// DeletionPolicy selects what happens to the remote issue when its
+// GitHubIssue is deleted.
+type DeletionPolicy string
+
+const (
+	// DeletionPolicyClose closes the remote issue.
+	DeletionPolicyClose DeletionPolicy = "Close"
+	// DeletionPolicyOrphan leaves the remote issue as it is.
+	DeletionPolicyOrphan DeletionPolicy = "Orphan"
+	// DeletionPolicyDelete deletes the remote issue, or closes it where the
+	// issue provider cannot delete issues.
+	DeletionPolicyDelete DeletionPolicy = "Delete"
+)
+
+// TaskMode selects how task list checkboxes changed on GitHub are treated.
+type TaskMode string
+
+const (
+	// TaskModeEnforce resets every checkbox to the Done of its task.
+	TaskModeEnforce TaskMode = "enforce"
+	// TaskModeAdditive keeps boxes checked on GitHub checked.
+	TaskModeAdditive TaskMode = "additive"
+)
+
+// IssueTask is one item of the task list rendered into the issue body.
+type IssueTask struct {
+	// Text describes the task. It must fit on one line.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:Pattern=`^[^\r\n]+$`
+	Text string `json:"text"`
+
+	// Done checks the task's box
+	// +optional
+	Done bool `json:"done,omitempty"`
+}
+
+// LabelSpec describes how a repository label should look.
+type LabelSpec struct {
+	// Name of the label
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Color is a six digit hex color without the leading "#", e.g. "d73a4a"
+	// +kubebuilder:validation:Pattern=`^[0-9a-fA-F]{6}$`
+	Color string `json:"color"`
+
+	// Description is a short description of the label
+	// +kubebuilder:validation:MaxLength=100
+	// +optional
+	Description string `json:"description,omitempty"`
+}
+
+// PostedComment records a comment of spec.comments that is on the remote issue.
+type PostedComment struct {
+	// ID is the provider's ID of the comment
+	ID int64 `json:"id"`
+
+	// Hash identifies the comment text
+	Hash string `json:"hash"`
+}
+
 // GitHubIssueSpec defines the desired state of GitHubIssue
 type GitHubIssueSpec struct {
 	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
@@ -31,17 +102,184 @@ type GitHubIssueSpec struct {
 	// Repository in format "owner/repo"
 	Repo string `json:"repo"`
 
+	// TransferTo moves the remote issue to another repository, in format
+	// "owner/repo". Once the transfer is done the controller sets Repo to it
+	// and clears this field. Ignored by providers that cannot transfer issues.
+	// +optional
+	TransferTo string `json:"transferTo,omitempty"`
+
 	// Issue title
 	Title string `json:"title"`
 
-	// Issue body/description
+	// Issue body/description. It may contain Go template actions resolved
+	// against the CR, e.g. "Raised by {{ .Namespace }}/{{ .Name }}"; available
+	// fields are .Name, .Namespace and .Cluster.
 	Body string `json:"body,omitempty"`
 
-	// Labels to apply
+	// TruncateBody cuts a body longer than GitHub's 65536 character limit down
+	// to size, ending it with a notice. By default an oversized body is not
+	// sent and the BodyTooLarge condition is set instead.
+	// +optional
+	TruncateBody bool `json:"truncateBody,omitempty"`
+
+	// Tasks are rendered after Body as a GitHub task list, one "- [ ] text"
+	// checkbox per task, checked when the task is done. TruncateBody never
+	// cuts them off.
+	// +optional
+	Tasks []IssueTask `json:"tasks,omitempty"`
+
+	// TaskMode selects what happens to checkboxes changed on GitHub. With
+	// "enforce" (the default) every box is reset to the Done of its task.
+	// With "additive" boxes checked on GitHub stay checked, so tasks can be
+	// ticked off there as well as in the spec.
+	// +kubebuilder:validation:Enum=enforce;additive
+	// +kubebuilder:default=enforce
+	// +optional
+	TaskMode TaskMode `json:"taskMode,omitempty"`
+
+	// Labels to apply. Entries may contain Go template actions resolved against
+	// the CR, e.g. "env-{{ .Namespace }}"; available fields are .Name, .Namespace
+	// and .Cluster.
+	// The controller also adds a "k8s-owner-<hash>" label identifying this CR.
 	Labels []string `json:"labels,omitempty"`
 
-	// Secret name containing GitHub token (key: "token")
-	TokenSecretRef string `json:"tokenSecretRef"`
+	// LabelSpecs sets the color and description of repository labels, such as
+	// those in Labels. Missing labels are created and differing ones restyled
+	// before the issue is created or synced. Ignored by providers that cannot
+	// manage labels.
+	// +optional
+	LabelSpecs []LabelSpec `json:"labelSpecs,omitempty"`
+
+	// Assignees are the logins of the users the issue is assigned to, e.g. the
+	// on-call engineers. Assignees changed on GitHub are reset to this list;
+	// when it is empty the controller leaves assignees alone. Ignored by
+	// providers that cannot assign issues, such as GitLab.
+	// +optional
+	Assignees []string `json:"assignees,omitempty"`
+
+	// Milestone is the title of the milestone to add the issue to, e.g.
+	// "v1.2". It is created if the repository has no milestone by that title.
+	// Unset leaves the milestone alone. Ignored by providers that do not
+	// support milestones, such as GitLab.
+	// +optional
+	Milestone string `json:"milestone,omitempty"`
+
+	// AdoptExisting, before creating the remote issue, searches Repo for an
+	// open issue with exactly this title that no other GitHubIssue manages,
+	// and takes it over instead of opening a duplicate.
+	// +optional
+	AdoptExisting bool `json:"adoptExisting,omitempty"`
+
+	// CommentOnUpdate posts a comment on the issue summarizing each
+	// title, body or label change the controller pushes. Ignored by providers
+	// that cannot post comments.
+	// +optional
+	CommentOnUpdate bool `json:"commentOnUpdate,omitempty"`
+
+	// Comments are posted on the issue, each exactly once and in order. A
+	// comment deleted on GitHub is posted again. Comments removed from the
+	// list, or left behind when the GitHubIssue is deleted, stay on the issue.
+	// Ignored by providers that cannot post comments.
+	// +optional
+	Comments []string `json:"comments,omitempty"`
+
+	// Pinned pins the issue to the repository. Providers without pin support
+	// ignore it and report the FeatureUnsupported condition.
+	// +optional
+	Pinned bool `json:"pinned,omitempty"`
+
+	// LockOnClose also locks the remote issue when it is closed because this
+	// GitHubIssue is deleted, so the archived issue cannot be edited once
+	// nothing manages it. Ignored by providers that cannot lock issues.
+	// +optional
+	LockOnClose bool `json:"lockOnClose,omitempty"`
+
+	// DeletionPolicy selects what happens to the remote issue when this
+	// GitHubIssue is deleted: "Close" (the default) closes it, "Orphan" stops
+	// managing it and leaves it open for people to handle, and "Delete"
+	// deletes it, falling back to closing it when the provider cannot delete
+	// issues.
+	// +kubebuilder:validation:Enum=Close;Orphan;Delete
+	// +kubebuilder:default=Close
+	// +optional
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// State is the desired state of the remote issue: "open" (the default) or "closed"
+	// +kubebuilder:validation:Enum=open;closed
+	// +kubebuilder:default=open
+	// +optional
+	State string `json:"state,omitempty"`
+
+	// CloseReason is recorded when the issue is closed: "completed" or
+	// "not_planned". Only used when State is "closed"; empty accepts any reason.
+	// +kubebuilder:validation:Enum=completed;not_planned
+	// +optional
+	CloseReason string `json:"closeReason,omitempty"`
+
+	// AutoCloseAfter closes the remote issue once this long has passed since
+	// the controller created it, e.g. "168h" for a one-week reminder. The issue
+	// then stays closed as if State were "closed".
+	// +optional
+	AutoCloseAfter *metav1.Duration `json:"autoCloseAfter,omitempty"`
+
+	// SyncMode selects how the remote issue is kept in sync. "poll" (the default)
+	// also re-checks it periodically, every 5 minutes unless the operator's
+	// --resync-interval says otherwise, to correct drift made on GitHub. "event"
+	// only syncs when the CR changes or a sync is triggered externally, e.g.
+	// through the sync-now endpoint, which saves API calls.
+	// +kubebuilder:validation:Enum=poll;event
+	// +kubebuilder:default=poll
+	// +optional
+	SyncMode SyncMode `json:"syncMode,omitempty"`
+
+	// TTLSecondsAfterClosed, when set, deletes this GitHubIssue once the remote
+	// issue has been closed for this many seconds. The controller then leaves
+	// closed issues closed instead of reopening them.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	TTLSecondsAfterClosed *int32 `json:"ttlSecondsAfterClosed,omitempty"`
+
+	// MaxRetries bounds how many times a failing create or sync is retried.
+	// Once exceeded the controller sets the Stuck condition and stops
+	// retrying until the spec changes. Unset retries forever.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxRetries *int32 `json:"maxRetries,omitempty"`
+
+	// Secret name containing GitHub token (key: "token"), or GitHub App
+	// credentials (keys: "app-id", "installation-id", "private-key").
+	// One of TokenSecretRef, TokenSecretRefs, TokenFile or TokenEnv must be set.
+	// +optional
+	TokenSecretRef string `json:"tokenSecretRef,omitempty"`
+
+	// TokenSecretRefs names further Secrets (key: "token") whose tokens are
+	// used in turn with TokenSecretRef, spreading API calls across their rate
+	// limits: the token with the most quota left goes first, and one whose
+	// limit is exhausted waits for it to reset. Secrets without a usable token
+	// are skipped.
+	// +optional
+	TokenSecretRefs []string `json:"tokenSecretRefs,omitempty"`
+
+	// TokenSecretNamespace is the namespace of the Secrets named by
+	// TokenSecretRef and TokenSecretRefs, e.g. a central namespace shared by
+	// several teams. Defaults to the namespace of the GitHubIssue; any other
+	// must be listed in the operator's --token-secret-namespaces.
+	// +optional
+	TokenSecretNamespace string `json:"tokenSecretNamespace,omitempty"`
+
+	// TokenFile is the path of a file in the operator's pod that holds the
+	// GitHub token, e.g. one mounted by an external secrets agent. It is used
+	// when the Secrets yield no token, and must be in the operator's
+	// --token-file-dir.
+	// +optional
+	TokenFile string `json:"tokenFile,omitempty"`
+
+	// TokenEnv names an environment variable of the operator's pod that holds
+	// the GitHub token, e.g. one injected by an external secrets agent. It is
+	// used when neither the Secrets nor TokenFile yield a token, and must start
+	// with the operator's --token-env-prefix.
+	// +optional
+	TokenEnv string `json:"tokenEnv,omitempty"`
 }
 
 // GitHubIssueStatus defines the observed state of GitHubIssue
@@ -49,15 +287,68 @@ type GitHubIssueStatus struct {
 	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
 	// Important: Run "make" to regenerate code after modifying this file
 
+	// Repo the remote issue lives in
+	Repo string `json:"repo,omitempty"`
+
 	// GitHub issue number
 	IssueNumber int `json:"issueNumber,omitempty"`
 
 	// URL to the issue
 	IssueURL string `json:"issueURL,omitempty"`
 
+	// CreatedAt is when the controller created the remote issue
+	// +optional
+	CreatedAt *metav1.Time `json:"createdAt,omitempty"`
+
+	// Author is the login of the user who opened the remote issue, as
+	// reported by the issue provider
+	// +optional
+	Author string `json:"author,omitempty"`
+
+	// IssueCreatedAt is when the remote issue was opened, as reported by the
+	// issue provider. It differs from CreatedAt for adopted issues.
+	// +optional
+	IssueCreatedAt *metav1.Time `json:"issueCreatedAt,omitempty"`
+
+	// Labels on the remote issue, sorted by name
+	Labels []string `json:"labels,omitempty"`
+
 	// Current state: open, closed
 	State string `json:"state,omitempty"`
 
+	// Reason for the current state, e.g. completed, not_planned, reopened
+	StateReason string `json:"stateReason,omitempty"`
+
+	// ClosedSince is when the remote issue was first seen closed
+	ClosedSince *metav1.Time `json:"closedSince,omitempty"`
+
+	// FailedAttempts counts consecutive failed syncs of the current generation
+	FailedAttempts int32 `json:"failedAttempts,omitempty"`
+
+	// LastError is the error of the most recent failed sync, cleared by a
+	// successful one
+	// +optional
+	LastError string `json:"lastError,omitempty"`
+
+	// ObservedGeneration is the most recent generation the controller has
+	// acted on: synced successfully, or failed FailedAttempts times
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// ObservedSpecHash is a hash of the desired remote issue as of the last
+	// sync that was verified against the issue provider
+	// +optional
+	ObservedSpecHash string `json:"observedSpecHash,omitempty"`
+
+	// LastVerifiedAt is when the remote issue was last read and found to
+	// match ObservedSpecHash. Only recorded when the controller is allowed to
+	// skip syncs of an unchanged spec.
+	// +optional
+	LastVerifiedAt *metav1.Time `json:"lastVerifiedAt,omitempty"`
+
+	// Comments are the remote comments posted for spec.comments, in the same order
+	// +optional
+	Comments []PostedComment `json:"comments,omitempty"`
+
 	// Conditions for status reporting
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
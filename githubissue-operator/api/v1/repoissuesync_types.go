@@ -0,0 +1,101 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+// IssueTemplate describes one issue a RepoIssueSync keeps in its repo.
+type IssueTemplate struct {
+	// Issue title. Titles identify issues, so they must be unique within a RepoIssueSync.
+	// +kubebuilder:validation:MinLength=1
+	Title string `json:"title"`
+
+	// Issue body/description
+	Body string `json:"body,omitempty"`
+
+	// Labels to apply when the issue is created
+	Labels []string `json:"labels,omitempty"`
+}
+
+// RepoIssueSyncSpec defines the desired state of RepoIssueSync
+type RepoIssueSyncSpec struct {
+	// Repository in format "owner/repo"
+	Repo string `json:"repo"`
+
+	// Issues that should exist and be open in the repo
+	// +listType=map
+	// +listMapKey=title
+	Issues []IssueTemplate `json:"issues,omitempty"`
+
+	// Secret name containing GitHub token (key: "token")
+	TokenSecretRef string `json:"tokenSecretRef"`
+}
+
+// SyncedIssue is the remote issue created for one IssueTemplate.
+type SyncedIssue struct {
+	// Title of the template the issue was created from
+	Title string `json:"title"`
+
+	// GitHub issue number
+	IssueNumber int `json:"issueNumber"`
+
+	// URL to the issue
+	IssueURL string `json:"issueURL,omitempty"`
+
+	// Current state: open, closed
+	State string `json:"state,omitempty"`
+}
+
+// RepoIssueSyncStatus defines the observed state of RepoIssueSync
+type RepoIssueSyncStatus struct {
+	// Issues managed by this RepoIssueSync, in spec order
+	Issues []SyncedIssue `json:"issues,omitempty"`
+
+	// Conditions for status reporting
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// RepoIssueSync is the Schema for the repoissuesyncs API. It keeps a set of
+// issues open in one repo; deleting it leaves the issues as they are.
+type RepoIssueSync struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RepoIssueSyncSpec   `json:"spec,omitempty"`
+	Status RepoIssueSyncStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// RepoIssueSyncList contains a list of RepoIssueSync
+type RepoIssueSyncList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RepoIssueSync `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RepoIssueSync{}, &RepoIssueSyncList{})
+}
@@ -17,21 +17,29 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"crypto/tls"
 	"flag"
 	"net/http"
 	"os"
+	"strings"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	"go.opentelemetry.io/otel"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
@@ -69,8 +77,80 @@ func main() {
 	var devMode bool
 	flag.BoolVar(&devMode, "dev", false,
 		"Use MockProvider instead of real GitHub API. Exposes mock state on :8082.")
+	var providerName, githubEnterpriseURL, githubEnterpriseUploadURL, gitlabURL, giteaURL, bitbucketURL string
+	flag.StringVar(&providerName, "provider", "github",
+		"Issue provider to reconcile against: github, gitlab, gitea or bitbucket. Ignored with --dev.")
+	flag.StringVar(&githubEnterpriseURL, "github-enterprise-url", os.Getenv("GITHUB_ENTERPRISE_URL"),
+		"Base URL of a GitHub Enterprise Server used by --provider=github instead of github.com. "+
+			"Defaults to $GITHUB_ENTERPRISE_URL.")
+	flag.StringVar(&githubEnterpriseUploadURL, "github-enterprise-upload-url", os.Getenv("GITHUB_ENTERPRISE_UPLOAD_URL"),
+		"Upload URL of the GitHub Enterprise Server, if it differs from --github-enterprise-url. "+
+			"Defaults to $GITHUB_ENTERPRISE_UPLOAD_URL.")
+	flag.StringVar(&gitlabURL, "gitlab-url", providers.DefaultGitLabURL,
+		"Base URL of the GitLab instance used by --provider=gitlab.")
+	flag.StringVar(&giteaURL, "gitea-url", "",
+		"Base URL of the Gitea instance used by --provider=gitea. Required with it.")
+	flag.StringVar(&bitbucketURL, "bitbucket-url", providers.DefaultBitbucketURL,
+		"Base URL of the Bitbucket API used by --provider=bitbucket.")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	var syncNowAddr string
+	var syncNowToken string
+	flag.StringVar(&syncNowAddr, "sync-now-bind-address", "",
+		"The address the admin sync-now endpoint binds to. Empty disables the endpoint.")
+	flag.StringVar(&syncNowToken, "sync-now-token", "",
+		"Bearer token required by the sync-now endpoint.")
+	var cleanupTimeout time.Duration
+	flag.DurationVar(&cleanupTimeout, "cleanup-timeout", 2*time.Minute,
+		"How long a deleted GitHubIssue waits for a usable token, or retries closing its remote issue, before the remote issue is orphaned.")
+	var specHashWindow time.Duration
+	flag.DurationVar(&specHashWindow, "spec-hash-window", 0,
+		"How long a GitHubIssue sync verified against GitHub is trusted; reconciles with an unchanged spec "+
+			"within it do not call GitHub. Zero always reads the remote issue.")
+	var resyncInterval time.Duration
+	flag.DurationVar(&resyncInterval, "resync-interval", 5*time.Minute,
+		"How often a GitHubIssue in poll mode is synced again to detect and correct drift on GitHub.")
+	var providerTimeout time.Duration
+	flag.DurationVar(&providerTimeout, "provider-timeout", 30*time.Second,
+		"How long a single call to the issue provider may take before it is canceled and the sync retried.")
+	var otelEndpoint string
+	flag.StringVar(&otelEndpoint, "otel-endpoint", "",
+		"OTLP/gRPC endpoint URL, e.g. http://otel-collector:4317, to export reconcile and issue provider "+
+			"traces to. Empty disables tracing.")
+	var clusterName string
+	flag.StringVar(&clusterName, "cluster-name", "",
+		"Name of this cluster, available to GitHubIssue templates as {{ .Cluster }}.")
+	var auditOnly bool
+	flag.BoolVar(&auditOnly, "audit-only", false,
+		"Compute desired state and log what would change, without writing to the cluster or the issue provider.")
+	var finalizerName string
+	flag.StringVar(&finalizerName, "finalizer-name", controller.DefaultFinalizerName,
+		"Finalizer that guards remote issue cleanup. Use distinct names for instances that share a cluster.")
+	var watchNamespace string
+	flag.StringVar(&watchNamespace, "watch-namespace", "",
+		"Only watch and reconcile resources in this namespace, so the operator can run with a "+
+			"namespaced Role instead of a ClusterRole. Token Secrets must be in this namespace too. "+
+			"Empty watches all namespaces.")
+	var tokenSecretNamespaces string
+	flag.StringVar(&tokenSecretNamespaces, "token-secret-namespaces", "",
+		"Comma-separated namespaces GitHubIssues may read token Secrets from with spec.tokenSecretNamespace, "+
+			"besides their own. Empty allows only their own namespace.")
+	var tokenFileDir string
+	flag.StringVar(&tokenFileDir, "token-file-dir", "",
+		"Directory GitHubIssues may read their token from with spec.tokenFile. Empty disallows spec.tokenFile.")
+	var tokenEnvPrefix string
+	flag.StringVar(&tokenEnvPrefix, "token-env-prefix", "",
+		"Prefix of the environment variables GitHubIssues may read their token from with spec.tokenEnv. "+
+			"Empty disallows spec.tokenEnv.")
+	var rbacCheckTimeout time.Duration
+	flag.DurationVar(&rbacCheckTimeout, "rbac-check-timeout", 30*time.Second,
+		"How long the startup RBAC check may take before the operator gives up.")
+	var enableWebhooks bool
+	flag.BoolVar(&enableWebhooks, "enable-webhooks", false,
+		"Serve the GitHubIssue validating and defaulting webhooks. Requires serving certificates and the config/webhook manifests.")
+	var defaultIssueLabel string
+	flag.StringVar(&defaultIssueLabel, "default-issue-label", controller.DefaultIssueLabel,
+		"Label the defaulting webhook adds to every GitHubIssue. Empty adds no label.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -79,6 +159,19 @@ func main() {
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
+	if errs := validation.IsQualifiedName(finalizerName); len(errs) > 0 || !strings.Contains(finalizerName, "/") {
+		setupLog.Error(nil, "--finalizer-name must be a qualified name such as example.com/cleanup", "finalizerName", finalizerName)
+		os.Exit(1)
+	}
+	if providerTimeout <= 0 {
+		setupLog.Error(nil, "--provider-timeout must be positive", "providerTimeout", providerTimeout)
+		os.Exit(1)
+	}
+	if resyncInterval <= 0 {
+		setupLog.Error(nil, "--resync-interval must be positive", "resyncInterval", resyncInterval)
+		os.Exit(1)
+	}
+
 	// if the enable-http2 flag is false (the default), http/2 should be disabled
 	// due to its vulnerabilities. More specifically, disabling http/2 will
 	// prevent from being vulnerable to the HTTP/2 Stream Cancellation and
@@ -99,8 +192,15 @@ func main() {
 		TLSOpts: tlsOpts,
 	})
 
+	var cacheOpts cache.Options
+	if watchNamespace != "" {
+		setupLog.Info("watching a single namespace", "namespace", watchNamespace)
+		cacheOpts.DefaultNamespaces = map[string]cache.Config{watchNamespace: {}}
+	}
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme: scheme,
+		Cache:  cacheOpts,
 		Metrics: metricsserver.Options{
 			BindAddress:   metricsAddr,
 			SecureServing: secureMetrics,
@@ -127,6 +227,16 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Probe RBAC before anything else runs, so a misconfigured Role fails the
+	// rollout with a clear message instead of a stream of forbidden reconciles.
+	rbacCtx, cancelRBACCheck := context.WithTimeout(context.Background(), rbacCheckTimeout)
+	err = checkPermissions(rbacCtx, mgr.GetClient(), watchNamespace, requiredPermissions)
+	cancelRBACCheck()
+	if err != nil {
+		setupLog.Error(err, "operator lacks the permissions it needs")
+		os.Exit(1)
+	}
+
 	var issueProvider providers.IssueProvider
 	if devMode {
 		setupLog.Info("running in dev mode with MockProvider")
@@ -145,24 +255,130 @@ func main() {
 			}
 		}()
 	} else {
-		issueProvider = providers.NewGitHubProvider()
+		switch providerName {
+		case "github":
+			issueProvider = providers.NewGitHubProvider()
+			if githubEnterpriseURL != "" {
+				setupLog.Info("using GitHub Enterprise Server", "url", githubEnterpriseURL)
+				issueProvider = providers.NewGitHubEnterpriseProvider(githubEnterpriseURL, githubEnterpriseUploadURL)
+			}
+		case "gitlab":
+			setupLog.Info("using GitLab issue provider", "url", gitlabURL)
+			issueProvider = providers.NewGitLabProvider(gitlabURL)
+		case "gitea":
+			if giteaURL == "" {
+				setupLog.Error(nil, "--gitea-url is required with --provider=gitea")
+				os.Exit(1)
+			}
+			setupLog.Info("using Gitea issue provider", "url", giteaURL)
+			issueProvider = providers.NewGiteaProvider(giteaURL)
+		case "bitbucket":
+			setupLog.Info("using Bitbucket issue provider", "url", bitbucketURL)
+			issueProvider = providers.NewBitbucketProvider(bitbucketURL)
+		default:
+			setupLog.Error(nil, "--provider must be github, gitlab, gitea or bitbucket", "provider", providerName)
+			os.Exit(1)
+		}
+	}
+
+	// Time provider calls for the metrics endpoint. Writes skipped in
+	// audit-only mode never reach the provider and are not timed.
+	metrics.Registry.MustRegister(providers.ProviderCallDuration)
+	issueProvider = providers.NewMeteredProvider(issueProvider)
+
+	// Without an endpoint the global tracer provider stays a no-op, and so do
+	// the spans of reconciles and provider calls
+	if otelEndpoint != "" {
+		tracerProvider, err := newTracerProvider(context.Background(), otelEndpoint)
+		if err != nil {
+			setupLog.Error(err, "unable to set up tracing")
+			os.Exit(1)
+		}
+		defer func() { _ = tracerProvider.Shutdown(context.Background()) }()
+		otel.SetTracerProvider(tracerProvider)
+		setupLog.Info("exporting traces", "endpoint", otelEndpoint)
+	}
+	tracer := otel.Tracer(tracerName)
+	issueProvider = providers.NewTracedProvider(issueProvider, tracer)
+
+	k8sClient := mgr.GetClient()
+	if auditOnly {
+		setupLog.Info("running in audit-only mode, no changes will be made")
+		k8sClient = controller.NewAuditOnlyClient(k8sClient)
+		issueProvider = controller.NewAuditOnlyProvider(issueProvider)
+	}
+
+	var syncNow chan event.GenericEvent
+	if syncNowAddr != "" {
+		if syncNowToken == "" {
+			setupLog.Error(nil, "--sync-now-token is required when --sync-now-bind-address is set")
+			os.Exit(1)
+		}
+		syncNow = make(chan event.GenericEvent)
+
+		// Start the admin endpoint that forces an immediate reconcile
+		go func() {
+			mux := http.NewServeMux()
+			mux.Handle("/sync", &controller.SyncNowHandler{Token: syncNowToken, Events: syncNow})
+			setupLog.Info("starting sync-now server", "addr", syncNowAddr)
+			setupLog.Info("  POST /sync?namespace=x&name=y  - reconcile a GitHubIssue now")
+			if err := http.ListenAndServe(syncNowAddr, mux); err != nil {
+				setupLog.Error(err, "sync-now server failed")
+			}
+		}()
 	}
 
+	var secretNamespaces []string
+	for ns := range strings.SplitSeq(tokenSecretNamespaces, ",") {
+		if ns = strings.TrimSpace(ns); ns != "" {
+			secretNamespaces = append(secretNamespaces, ns)
+		}
+	}
+	tokenSources := controller.TokenSources{
+		SecretNamespaces: secretNamespaces,
+		FileDir:          tokenFileDir,
+		EnvPrefix:        tokenEnvPrefix,
+	}
 	if err = (&controller.GitHubIssueReconciler{
-		Client:        mgr.GetClient(),
-		Scheme:        mgr.GetScheme(),
-		IssueProvider: issueProvider,
+		Client:          k8sClient,
+		Scheme:          mgr.GetScheme(),
+		IssueProvider:   issueProvider,
+		SyncNow:         syncNow,
+		CleanupTimeout:  cleanupTimeout,
+		FinalizerName:   finalizerName,
+		SpecHashWindow:  specHashWindow,
+		ResyncInterval:  resyncInterval,
+		ProviderTimeout: providerTimeout,
+		WatchNamespace:  watchNamespace,
+		TokenSources:    tokenSources,
+		Recorder:        mgr.GetEventRecorderFor("githubissue-controller"),
+		Tracer:          tracer,
+		ClusterName:     clusterName,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "GitHubIssue")
 		os.Exit(1)
 	}
+	if err = (&controller.RepoIssueSyncReconciler{
+		Client:          k8sClient,
+		Scheme:          mgr.GetScheme(),
+		IssueProvider:   issueProvider,
+		Tracer:          tracer,
+		ProviderTimeout: providerTimeout,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "RepoIssueSync")
+		os.Exit(1)
+	}
+	if enableWebhooks {
+		(&controller.GitHubIssueValidator{TokenSources: tokenSources}).SetupWebhookWithManager(mgr)
+		(&controller.GitHubIssueDefaulter{Label: defaultIssueLabel}).SetupWebhookWithManager(mgr)
+	}
 	//+kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		setupLog.Error(err, "unable to set up health check")
 		os.Exit(1)
 	}
-	if err := mgr.AddReadyzCheck("readyz", healthz.Ping); err != nil {
+	if err := mgr.AddReadyzCheck("readyz", cacheSyncedCheck(mgr.GetCache())); err != nil {
 		setupLog.Error(err, "unable to set up ready check")
 		os.Exit(1)
 	}
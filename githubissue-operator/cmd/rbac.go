@@ -0,0 +1,90 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	issuesv1 "github.com/zhangbiao2009/controller_exercise/githubissue-operator/api/v1"
+)
+
+// requiredPermissions mirrors the kubebuilder RBAC markers on the reconcilers.
+// Keep the two in sync when a controller starts using another resource.
+var requiredPermissions = []authorizationv1.ResourceAttributes{
+	{Resource: "secrets", Verb: "get"},
+	{Resource: "secrets", Verb: "list"},
+	{Resource: "secrets", Verb: "watch"},
+	{Resource: "events", Verb: "create"},
+	{Resource: "events", Verb: "patch"},
+	{Group: issuesv1.GroupVersion.Group, Resource: "githubissues", Verb: "get"},
+	{Group: issuesv1.GroupVersion.Group, Resource: "githubissues", Verb: "list"},
+	{Group: issuesv1.GroupVersion.Group, Resource: "githubissues", Verb: "watch"},
+	{Group: issuesv1.GroupVersion.Group, Resource: "githubissues", Verb: "create"},
+	{Group: issuesv1.GroupVersion.Group, Resource: "githubissues", Verb: "update"},
+	{Group: issuesv1.GroupVersion.Group, Resource: "githubissues", Verb: "delete"},
+	{Group: issuesv1.GroupVersion.Group, Resource: "githubissues", Subresource: "status", Verb: "update"},
+	{Group: issuesv1.GroupVersion.Group, Resource: "githubissues", Subresource: "finalizers", Verb: "update"},
+	{Group: issuesv1.GroupVersion.Group, Resource: "repoissuesyncs", Verb: "get"},
+	{Group: issuesv1.GroupVersion.Group, Resource: "repoissuesyncs", Verb: "list"},
+	{Group: issuesv1.GroupVersion.Group, Resource: "repoissuesyncs", Verb: "watch"},
+	{Group: issuesv1.GroupVersion.Group, Resource: "repoissuesyncs", Subresource: "status", Verb: "update"},
+}
+
+// checkPermissions asks the API server, through SelfSubjectAccessReviews,
+// whether the operator may perform every request in required within namespace
+// ("" meaning cluster-wide). All denials are reported together so that a
+// misconfigured Role can be fixed in one go.
+func checkPermissions(ctx context.Context, c client.Client, namespace string, required []authorizationv1.ResourceAttributes) error {
+	var denied []string
+	for _, attrs := range required {
+		attrs.Namespace = namespace
+		review := &authorizationv1.SelfSubjectAccessReview{
+			Spec: authorizationv1.SelfSubjectAccessReviewSpec{ResourceAttributes: &attrs},
+		}
+		if err := c.Create(ctx, review); err != nil {
+			return fmt.Errorf("failed to review access to %s: %w", describeAccess(attrs), err)
+		}
+		if !review.Status.Allowed {
+			denied = append(denied, describeAccess(attrs))
+		}
+	}
+	if len(denied) > 0 {
+		scope := "cluster-wide"
+		if namespace != "" {
+			scope = fmt.Sprintf("in namespace %q", namespace)
+		}
+		return fmt.Errorf("missing RBAC permissions %s: %s", scope, strings.Join(denied, ", "))
+	}
+	return nil
+}
+
+// describeAccess renders a request as e.g. "update githubissues/status.issues.github.example.com".
+func describeAccess(attrs authorizationv1.ResourceAttributes) string {
+	resource := attrs.Resource
+	if attrs.Subresource != "" {
+		resource += "/" + attrs.Subresource
+	}
+	if attrs.Group != "" {
+		resource += "." + attrs.Group
+	}
+	return attrs.Verb + " " + resource
+}
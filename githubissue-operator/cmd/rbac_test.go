@@ -0,0 +1,75 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+// reviewingClient answers SelfSubjectAccessReviews, denying those deny matches.
+func reviewingClient(deny func(authorizationv1.ResourceAttributes) bool) client.Client {
+	return fake.NewClientBuilder().WithScheme(scheme).WithInterceptorFuncs(interceptor.Funcs{
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			review, ok := obj.(*authorizationv1.SelfSubjectAccessReview)
+			if !ok {
+				return c.Create(ctx, obj, opts...)
+			}
+			review.Status.Allowed = !deny(*review.Spec.ResourceAttributes)
+			return nil
+		},
+	}).Build()
+}
+
+func TestCheckPermissions_AllAllowed(t *testing.T) {
+	c := reviewingClient(func(authorizationv1.ResourceAttributes) bool { return false })
+	if err := checkPermissions(context.Background(), c, "team-a", requiredPermissions); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestCheckPermissions_ReportsDeniedVerb(t *testing.T) {
+	var namespaces []string
+	c := reviewingClient(func(attrs authorizationv1.ResourceAttributes) bool {
+		namespaces = append(namespaces, attrs.Namespace)
+		return attrs.Resource == "githubissues" && attrs.Subresource == "" && attrs.Verb == "delete"
+	})
+
+	err := checkPermissions(context.Background(), c, "team-a", requiredPermissions)
+	if err == nil {
+		t.Fatal("expected the denied verb to be reported")
+	}
+	for _, want := range []string{`in namespace "team-a"`, "delete githubissues.issues.github.example.com"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %q", err, want)
+		}
+	}
+	if strings.Contains(err.Error(), "update githubissues") {
+		t.Errorf("error %q reports an allowed verb", err)
+	}
+	for _, ns := range namespaces {
+		if ns != "team-a" {
+			t.Fatalf("expected every review to target team-a, got %q", ns)
+		}
+	}
+}
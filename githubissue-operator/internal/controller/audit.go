@@ -0,0 +1,198 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/zhangbiao2009/controller_exercise/githubissue-operator/pkg/providers"
+)
+
+// errAuditOnly is returned in place of every write made in audit-only mode.
+// The reconcile stops there and the skipped write is logged as a would-change.
+var errAuditOnly = errors.New("audit-only mode, write skipped")
+
+// NewAuditOnlyClient wraps c so that reads pass through but every write,
+// including status and other subresource writes, is skipped and fails with
+// errAuditOnly. Reconcilers registered through SetupWithManager turn that
+// error into a "would change" log line instead of a failed reconcile.
+func NewAuditOnlyClient(c client.Client) client.Client {
+	return &auditOnlyClient{Client: c}
+}
+
+type auditOnlyClient struct {
+	client.Client
+}
+
+func (c *auditOnlyClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	return wouldChange("create", obj)
+}
+
+func (c *auditOnlyClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	return wouldChange("update", obj)
+}
+
+func (c *auditOnlyClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	return wouldChange("patch", obj)
+}
+
+func (c *auditOnlyClient) Apply(ctx context.Context, obj runtime.ApplyConfiguration, opts ...client.ApplyOption) error {
+	return fmt.Errorf("%w: would apply %T", errAuditOnly, obj)
+}
+
+func (c *auditOnlyClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	return wouldChange("delete", obj)
+}
+
+func (c *auditOnlyClient) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) error {
+	return fmt.Errorf("%w: would delete all %s", errAuditOnly, kindOf(obj))
+}
+
+func (c *auditOnlyClient) Status() client.SubResourceWriter {
+	return auditOnlySubResource{SubResourceClient: c.Client.SubResource("status"), name: "status"}
+}
+
+func (c *auditOnlyClient) SubResource(subResource string) client.SubResourceClient {
+	return auditOnlySubResource{SubResourceClient: c.Client.SubResource(subResource), name: subResource}
+}
+
+// auditOnlySubResource passes subresource reads through and skips writes.
+type auditOnlySubResource struct {
+	client.SubResourceClient
+	name string
+}
+
+func (s auditOnlySubResource) Create(ctx context.Context, obj client.Object, subResource client.Object, opts ...client.SubResourceCreateOption) error {
+	return wouldChange("create "+s.name+" of", obj)
+}
+
+func (s auditOnlySubResource) Update(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+	return wouldChange("update "+s.name+" of", obj)
+}
+
+func (s auditOnlySubResource) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+	return wouldChange("patch "+s.name+" of", obj)
+}
+
+func (s auditOnlySubResource) Apply(ctx context.Context, obj runtime.ApplyConfiguration, opts ...client.SubResourceApplyOption) error {
+	return fmt.Errorf("%w: would apply %s of %T", errAuditOnly, s.name, obj)
+}
+
+// wouldChange describes a skipped write to obj.
+func wouldChange(verb string, obj client.Object) error {
+	return fmt.Errorf("%w: would %s %s %s", errAuditOnly, verb, kindOf(obj), client.ObjectKeyFromObject(obj))
+}
+
+// kindOf names the kind of obj, falling back to its Go type for typed
+// objects whose TypeMeta is empty.
+func kindOf(obj client.Object) string {
+	if kind := obj.GetObjectKind().GroupVersionKind().Kind; kind != "" {
+		return kind
+	}
+	return reflect.TypeOf(obj).Elem().Name()
+}
+
+// skipAuditedWrites ends a reconcile quietly when it reached a write skipped
+// in audit-only mode, logging what would have changed. Other results pass
+// through unchanged.
+func skipAuditedWrites(r reconcile.Reconciler) reconcile.Reconciler {
+	return reconcile.Func(func(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+		result, err := r.Reconcile(ctx, req)
+		if errors.Is(err, errAuditOnly) {
+			log.FromContext(ctx).Info("audit-only: stopping at first change", "change", err.Error())
+			return ctrl.Result{}, nil
+		}
+		return result, err
+	})
+}
+
+// NewAuditOnlyProvider wraps p so that reads pass through but every remote
+// write fails with errAuditOnly. Capabilities are those of p.
+func NewAuditOnlyProvider(p providers.IssueProvider) providers.IssueProvider {
+	return &auditOnlyProvider{IssueProvider: p}
+}
+
+type auditOnlyProvider struct {
+	providers.IssueProvider
+}
+
+func (p *auditOnlyProvider) Create(ctx context.Context, token string, input providers.CreateIssueInput) (*providers.Issue, error) {
+	return nil, fmt.Errorf("%w: would create issue %q in %s", errAuditOnly, input.Title, input.Repo)
+}
+
+func (p *auditOnlyProvider) Update(ctx context.Context, token string, repo string, issueNumber int, input providers.UpdateIssueInput) (*providers.Issue, error) {
+	return nil, wouldChangeIssue("update", repo, issueNumber)
+}
+
+func (p *auditOnlyProvider) Close(ctx context.Context, token string, repo string, issueNumber int) error {
+	return wouldChangeIssue("close", repo, issueNumber)
+}
+
+func (p *auditOnlyProvider) Reopen(ctx context.Context, token string, repo string, issueNumber int) error {
+	return wouldChangeIssue("reopen", repo, issueNumber)
+}
+
+func (p *auditOnlyProvider) Delete(ctx context.Context, token string, repo string, issueNumber int) error {
+	return wouldChangeIssue("delete", repo, issueNumber)
+}
+
+func (p *auditOnlyProvider) SetPinned(ctx context.Context, token string, repo string, issueNumber int, pinned bool) error {
+	return wouldChangeIssue(fmt.Sprintf("set pinned=%t on", pinned), repo, issueNumber)
+}
+
+func (p *auditOnlyProvider) Lock(ctx context.Context, token string, repo string, issueNumber int) error {
+	return wouldChangeIssue("lock", repo, issueNumber)
+}
+
+func (p *auditOnlyProvider) EnsureLabels(ctx context.Context, token string, repo string, labels []providers.LabelSpec) error {
+	return fmt.Errorf("%w: would ensure %d labels in %s", errAuditOnly, len(labels), repo)
+}
+
+func (p *auditOnlyProvider) AddComment(ctx context.Context, token string, repo string, issueNumber int, body string) (*providers.Comment, error) {
+	return nil, wouldChangeIssue("comment on", repo, issueNumber)
+}
+
+// ListComments is a read and passes through, where p can list comments at all.
+func (p *auditOnlyProvider) ListComments(ctx context.Context, token string, repo string, issueNumber int) ([]*providers.Comment, error) {
+	commenter, ok := p.IssueProvider.(providers.Commenter)
+	if !ok {
+		return nil, fmt.Errorf("issue provider cannot list comments")
+	}
+	return commenter.ListComments(ctx, token, repo, issueNumber)
+}
+
+func (p *auditOnlyProvider) Transfer(ctx context.Context, token string, fromRepo string, issueNumber int, toRepo string) (*providers.Issue, error) {
+	return nil, wouldChangeIssue("transfer to "+toRepo, fromRepo, issueNumber)
+}
+
+func (p *auditOnlyProvider) Capabilities() providers.ProviderCapabilities {
+	return providers.CapabilitiesOf(p.IssueProvider)
+}
+
+// wouldChangeIssue describes a skipped write to a remote issue.
+func wouldChangeIssue(verb string, repo string, issueNumber int) error {
+	return fmt.Errorf("%w: would %s issue %s#%d", errAuditOnly, verb, repo, issueNumber)
+}
@@ -17,41 +17,203 @@ limitations under the License.
 package controller
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
 	"slices"
 	"sort"
+	"strconv"
+	"strings"
 	"time"
 
+	"go.opentelemetry.io/otel/trace"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/utils/clock"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	issuesv1 "github.com/zhangbiao2009/controller_exercise/githubissue-operator/api/v1"
 	"github.com/zhangbiao2009/controller_exercise/githubissue-operator/pkg/providers"
 )
 
-const githubIssueFinalizer = "issues.github.example.com/cleanup"
+// DefaultFinalizerName is the cleanup finalizer used when FinalizerName is empty.
+const DefaultFinalizerName = "issues.github.example.com/cleanup"
+
+// defaultCleanupTimeout bounds how long finalization waits for a usable token,
+// or retries closing and locking, before giving up on the remote issue.
+const defaultCleanupTimeout = 2 * time.Minute
+
+// defaultResyncInterval is how often a synced issue is checked for drift.
+const defaultResyncInterval = 5 * time.Minute
+
+// defaultProviderTimeout bounds a single call to the issue provider.
+const defaultProviderTimeout = 30 * time.Second
+
+// Bounds of the delay before a failed create or sync is retried. It doubles
+// with every consecutive failure, starting at minSyncBackoff.
+const (
+	minSyncBackoff = 5 * time.Second
+	maxSyncBackoff = 5 * time.Minute
+)
+
+// tokenSecretKey is the key in the referenced Secret that holds the GitHub token.
+const tokenSecretKey = "token"
+
+// Keys of a Secret that authenticates as a GitHub App installation instead of
+// holding a token.
+const (
+	appIDSecretKey             = "app-id"
+	appInstallationIDSecretKey = "installation-id"
+	appPrivateKeySecretKey     = "private-key"
+)
+
+// issueNumberAnnotation records the number of the remote issue created for a
+// CR. It is written with a regular update before status, so a failed status
+// write after creation is recovered from instead of creating a duplicate.
+const issueNumberAnnotation = "issues.github.example.com/issue-number"
+
+// Condition types reported on GitHubIssue status.
+const (
+	// conditionSecretInvalid is True when the token Secret exists but cannot be used.
+	conditionSecretInvalid = "SecretInvalid"
+	// conditionTemplateInvalid is True when a templated spec field fails to render.
+	conditionTemplateInvalid = "TemplateInvalid"
+	// conditionOwnershipConflict is True when the remote issue is marked as
+	// managed by another GitHubIssue; this CR then leaves it alone.
+	conditionOwnershipConflict = "OwnershipConflict"
+	// conditionFeatureUnsupported is True when the spec asks for something the
+	// issue provider does not support; that part of the spec is skipped.
+	conditionFeatureUnsupported = "FeatureUnsupported"
+	// conditionStuck is True when syncing failed more than spec.maxRetries
+	// times; the controller stops retrying until the spec changes.
+	conditionStuck = "Stuck"
+	// conditionBodyTooLarge is True when the body exceeds GitHub's size limit
+	// and spec.truncateBody is off; nothing is sent until it fits.
+	conditionBodyTooLarge = "BodyTooLarge"
+	// conditionAutoClosed is True once spec.autoCloseAfter has elapsed and the
+	// issue is kept closed because of it.
+	conditionAutoClosed = "AutoClosed"
+	// conditionInSync is True once the remote issue matches the spec. Its
+	// reason tells whether that was confirmed by reading the remote issue
+	// (RemoteVerified) or assumed from an unchanged spec hash (SpecHashMatched).
+	conditionInSync = "InSync"
+	// conditionDrift is True when the last sync found the remote issue's
+	// content edited away from the spec; its message names the fields that
+	// were put back. It is removed once a sync finds no drift.
+	conditionDrift = "Drift"
+	// conditionReady sums up the others: True once the remote issue has been
+	// created or synced, False with the reason when the last reconcile could
+	// not get that far.
+	conditionReady = "Ready"
+)
+
+// secretInvalidError describes why an existing token Secret is unusable.
+// Unlike a missing Secret, it is surfaced as a condition instead of an error.
+type secretInvalidError struct {
+	reason  string
+	message string
+}
+
+func (e *secretInvalidError) Error() string {
+	return e.message
+}
 
 // GitHubIssueReconciler reconciles a GitHubIssue object
 type GitHubIssueReconciler struct {
 	client.Client
 	Scheme        *runtime.Scheme
 	IssueProvider providers.IssueProvider
+
+	// SyncNow, when set, delivers out-of-band reconcile requests (see SyncNowHandler).
+	SyncNow <-chan event.GenericEvent
+
+	// CleanupTimeout bounds how long a deleted CR keeps its finalizer while the
+	// token is unavailable (e.g. the Secret was removed with its namespace) or
+	// closing the remote issue keeps failing. After it elapses the remote issue
+	// is orphaned. Zero means defaultCleanupTimeout.
+	CleanupTimeout time.Duration
+
+	// FinalizerName is the finalizer that guards remote cleanup. Instances that
+	// share a cluster (e.g. shards) can use distinct names. Empty means DefaultFinalizerName.
+	FinalizerName string
+
+	// Clock is used for time-based decisions. Nil means the real clock.
+	Clock clock.PassiveClock
+
+	// Recorder, when set, records Kubernetes events about GitHubIssues.
+	Recorder record.EventRecorder
+
+	// Tracer starts a span for every reconcile. Nil records nothing.
+	Tracer trace.Tracer
+
+	// ClusterName is exposed to spec templates as .Cluster, e.g. to tell
+	// issues from several clusters apart. Empty renders as "".
+	ClusterName string
+
+	// SpecHashWindow is how long a sync verified against the issue provider is
+	// trusted: until it elapses, reconciles whose spec hash is unchanged skip
+	// the remote issue entirely. Zero always reads the remote issue.
+	SpecHashWindow time.Duration
+
+	// ResyncInterval is how often a GitHubIssue in poll mode is synced again
+	// to detect and correct drift. Zero means defaultResyncInterval.
+	ResyncInterval time.Duration
+
+	// ProviderTimeout bounds each call to the issue provider, so that a hung
+	// connection fails the sync instead of blocking a worker. Zero means
+	// defaultProviderTimeout.
+	ProviderTimeout time.Duration
+
+	// WatchNamespace is the only namespace the manager's cache holds, as set
+	// with --watch-namespace. Token Secrets in other namespaces cannot be read
+	// or watched, so they are reported as invalid. Empty means all namespaces.
+	WatchNamespace string
+
+	// TokenSources limits the namespaces spec.tokenSecretNamespace and the
+	// files and environment variables spec.tokenFile and spec.tokenEnv may name.
+	TokenSources TokenSources
+
+	// repoLocks serializes provider calls per repo so concurrent reconciles do
+	// not trip GitHub's secondary rate limits on a single repo.
+	repoLocks keyedMutex
+
+	// tokens rotates between the tokens of CRs that list several Secrets.
+	tokens tokenPool
 }
 
 //+kubebuilder:rbac:groups=issues.github.example.com,resources=githubissues,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=issues.github.example.com,resources=githubissues/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=issues.github.example.com,resources=githubissues/finalizers,verbs=update
+// Secrets are read cluster-wide: spec.tokenSecretNamespace may point a
+// GitHubIssue at token Secrets outside its own namespace.
 //+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 // Reconcile ensures the remote GitHub issue matches the desired state in the GitHubIssue CR.
 func (r *GitHubIssueReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	return tracedReconcile(ctx, r.Tracer, "GitHubIssue.Reconcile", req, r.reconcile)
+}
+
+// reconcile does the work of Reconcile within its span.
+func (r *GitHubIssueReconciler) reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 
 	// 1. Fetch the GitHubIssue instance
@@ -65,10 +227,43 @@ func (r *GitHubIssueReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	}
 
 	// 2. Get GitHub token (needed for all provider operations, including deletion cleanup)
-	token, err := r.getToken(ctx, &issue)
+	token, secret, err := r.getToken(ctx, &issue)
 	if err != nil {
+		if !issue.DeletionTimestamp.IsZero() {
+			return r.handleDeletionWithoutToken(ctx, &issue, err)
+		}
+		var invalid *secretInvalidError
+		if errors.As(err, &invalid) {
+			logger.Info("token Secret is invalid", "reason", invalid.reason, "message", invalid.message)
+			if err := r.setCondition(ctx, &issue, conditionSecretInvalid, metav1.ConditionTrue, invalid.reason, invalid.message); err != nil {
+				return ctrl.Result{}, err
+			}
+			if err := r.setNotReady(ctx, &issue, "SecretInvalid", invalid.message); err != nil {
+				return ctrl.Result{}, err
+			}
+			return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
+		}
+		reason := "SecretUnavailable"
+		if apierrors.IsNotFound(err) {
+			reason = "SecretMissing"
+		}
+		if condErr := r.setNotReady(ctx, &issue, reason, err.Error()); condErr != nil {
+			return ctrl.Result{}, condErr
+		}
 		return ctrl.Result{RequeueAfter: 30 * time.Second}, err
 	}
+	if err := r.clearCondition(ctx, &issue, conditionSecretInvalid); err != nil {
+		return ctrl.Result{}, err
+	}
+	if secret != "" {
+		// Let the next pick know how much quota this Secret's token has left
+		ctx = providers.WithRateObserver(ctx, func(remaining int, reset time.Time) {
+			r.tokens.observe(secret, remaining, reset)
+		})
+	}
+
+	// From here on the provider may be called; one reconcile per repo at a time
+	defer r.repoLocks.lock(repoKey(issue.Spec.Repo))()
 
 	// 3. Handle deletion
 	if !issue.DeletionTimestamp.IsZero() {
@@ -83,61 +278,491 @@ func (r *GitHubIssueReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return result, err
 	}
 
-	// 5. Create or sync the remote issue
-	if issue.Status.IssueNumber == 0 {
-		if err := r.createRemoteIssue(ctx, &issue, token); err != nil {
+	// 5. Render templated spec fields into the desired remote content
+	desired, err := renderDesired(&issue, r.ClusterName)
+	if err != nil {
+		logger.Info("spec template is invalid", "error", err.Error())
+		// Only a spec change can fix a bad template, which triggers a new reconcile.
+		if err := r.setCondition(ctx, &issue, conditionTemplateInvalid, metav1.ConditionTrue, "RenderFailed", err.Error()); err != nil {
 			return ctrl.Result{}, err
 		}
+		return ctrl.Result{}, r.setNotReady(ctx, &issue, "TemplateInvalid", err.Error())
+	}
+	if err := r.clearCondition(ctx, &issue, conditionTemplateInvalid); err != nil {
+		return ctrl.Result{}, err
+	}
+	// Fields the provider never reports would look like drift on every sync
+	caps := providers.CapabilitiesOf(r.IssueProvider)
+	if !caps.Assignees {
+		desired.Assignees = nil
+	}
+	if !caps.Milestones {
+		desired.Milestone = ""
+	}
+	if err := issuesv1.CheckTitleLength(desired.Title); err != nil {
+		logger.Info("issue title is too long", "error", err.Error())
+		r.event(&issue, corev1.EventTypeWarning, "TitleTooLong", err.Error())
+		return ctrl.Result{}, r.setNotReady(ctx, &issue, "TitleTooLong", err.Error())
+	}
+	if err := issuesv1.CheckBodyLength(desired.Body); err != nil {
+		logger.Info("issue body is too large", "error", err.Error())
+		r.event(&issue, corev1.EventTypeWarning, "BodyTooLarge", err.Error())
+		if err := r.setCondition(ctx, &issue, conditionBodyTooLarge, metav1.ConditionTrue, "BodyTooLarge", err.Error()); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, r.setNotReady(ctx, &issue, "BodyTooLarge", err.Error())
+	}
+	if err := r.clearCondition(ctx, &issue, conditionBodyTooLarge); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// 6. Move the remote issue to spec.transferTo
+	if err := r.transferRemoteIssue(ctx, &issue, token); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// 7. Create or sync the remote issue, unless retries for this spec are exhausted
+	if isStuck(&issue) {
+		logger.Info("sync retries exhausted, waiting for a spec change", "failedAttempts", issue.Status.FailedAttempts)
+		return ctrl.Result{}, r.setNotReady(ctx, &issue, "Stuck",
+			fmt.Sprintf("sync failed %d times, waiting for a spec change", issue.Status.FailedAttempts))
+	}
+	if issue.Status.IssueNumber == 0 {
+		err = r.adoptOrCreateRemoteIssue(ctx, &issue, desired, token)
 	} else {
-		if err := r.syncRemoteIssue(ctx, &issue, token); err != nil {
+		err = r.syncIfChanged(ctx, &issue, desired, token)
+	}
+	var rateLimited *providers.RateLimitError
+	if errors.As(err, &rateLimited) {
+		// Not a failed attempt: nothing is wrong with the spec, the provider
+		// just has to be left alone until the limit resets
+		logger.Info("provider rate limit exhausted, waiting for it to reset", "reset", rateLimited.Reset)
+		if secret != "" {
+			r.tokens.exhausted(secret, rateLimited.Reset)
+		}
+		if err := r.setNotReady(ctx, &issue, "RateLimited", rateLimited.Error()); err != nil {
 			return ctrl.Result{}, err
 		}
+		wait, _ := providers.RetryAfter(rateLimited, r.now())
+		return ctrl.Result{RequeueAfter: wait}, nil
+	}
+	if errors.Is(err, errAuditOnly) {
+		return ctrl.Result{}, err
+	}
+	if err != nil {
+		reason := "ProviderError"
+		if errors.Is(err, context.DeadlineExceeded) {
+			reason = "ProviderTimeout"
+			err = fmt.Errorf("issue provider did not respond within %s: %w", r.providerTimeout(), err)
+		}
+		if condErr := r.setNotReady(ctx, &issue, reason, err.Error()); condErr != nil {
+			// The sync error matters more; the condition is set on the retry
+			logger.Error(condErr, "failed to record the sync failure")
+		}
+		return r.recordFailedAttempt(ctx, &issue, err)
+	}
+	if err := r.resetFailedAttempts(ctx, &issue); err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := r.setReady(ctx, &issue); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// 8. Delete the CR once its remote issue has been closed for the TTL
+	if expiring, result, err := r.expireClosed(ctx, &issue); expiring {
+		return result, err
 	}
 
-	// 6. Periodic resync to detect and correct drift
-	return ctrl.Result{RequeueAfter: 5 * time.Minute}, nil
+	// 9. Periodic resync to detect and correct drift, unless only events should
+	// trigger syncs; either way come back when the issue is due to auto-close
+	requeueAfter := r.ResyncInterval
+	if requeueAfter == 0 {
+		requeueAfter = defaultResyncInterval
+	}
+	if issue.Spec.SyncMode == issuesv1.SyncModeEvent {
+		requeueAfter = 0
+	}
+	if d := r.untilAutoClose(&issue); d > 0 && (requeueAfter == 0 || d < requeueAfter) {
+		requeueAfter = d
+	}
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
 }
 
 // ---------------------------------------------------------------------------
 // Helper methods — one per reconciliation phase
 // ---------------------------------------------------------------------------
 
-// getToken reads the GitHub API token from the Secret referenced by the CR.
-func (r *GitHubIssueReconciler) getToken(ctx context.Context, issue *issuesv1.GitHubIssue) (string, error) {
-	var secret corev1.Secret
-	key := types.NamespacedName{
-		Name:      issue.Spec.TokenSecretRef,
-		Namespace: issue.Namespace,
+// getToken reads the GitHub API token of the CR from the first source that
+// yields one, in order: the Secrets, spec.tokenFile and spec.tokenEnv, and
+// returns it with the namespace/name of the Secret it came from, if any. When
+// none does, the error of the first source that is set is returned.
+func (r *GitHubIssueReconciler) getToken(ctx context.Context, issue *issuesv1.GitHubIssue) (string, string, error) {
+	logger := log.FromContext(ctx)
+	var firstErr error
+	if refs := tokenSecretRefs(issue); len(refs) > 0 {
+		token, secret, err := r.getSecretToken(ctx, issue, refs)
+		if err == nil {
+			return token, secret, nil
+		}
+		firstErr = err
+	}
+	if issue.Spec.TokenFile != "" {
+		if firstErr != nil {
+			logger.Info("no usable token Secret, reading spec.tokenFile", "error", firstErr.Error())
+		}
+		path, err := r.TokenSources.checkFile(issue.Spec.TokenFile)
+		var token string
+		if err == nil {
+			token, err = readTokenFile(path)
+		}
+		if err == nil {
+			return token, "", nil
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	if issue.Spec.TokenEnv != "" {
+		if firstErr != nil {
+			logger.Info("no usable token Secret or file, reading spec.tokenEnv", "error", firstErr.Error())
+		}
+		err := r.TokenSources.checkEnv(issue.Spec.TokenEnv)
+		var token string
+		if err == nil {
+			token, err = readTokenEnv(issue.Spec.TokenEnv)
+		}
+		if err == nil {
+			return token, "", nil
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	if firstErr == nil {
+		firstErr = fmt.Errorf("no token source set: one of spec.tokenSecretRef, spec.tokenSecretRefs, spec.tokenFile or spec.tokenEnv is required")
+	}
+	return "", "", firstErr
+}
+
+// tokenSecretRefs returns the names of the token Secrets of issue, TokenSecretRef first.
+func tokenSecretRefs(issue *issuesv1.GitHubIssue) []string {
+	var refs []string
+	if issue.Spec.TokenSecretRef != "" {
+		refs = append(refs, issue.Spec.TokenSecretRef)
+	}
+	return append(refs, issue.Spec.TokenSecretRefs...)
+}
+
+// tokenSecretNamespace returns the namespace of the token Secrets of issue.
+func tokenSecretNamespace(issue *issuesv1.GitHubIssue) string {
+	if issue.Spec.TokenSecretNamespace != "" {
+		return issue.Spec.TokenSecretNamespace
+	}
+	return issue.Namespace
+}
+
+// getSecretToken reads the GitHub API token from the Secrets named by refs and
+// returns it with the namespace/name of its Secret. With more than one Secret
+// the token with the most quota left is picked (see tokenPool), and unusable
+// Secrets are skipped as long as another one holds a token. When none does,
+// the error for the first Secret is returned; a Secret that exists but is
+// unusable yields a *secretInvalidError.
+func (r *GitHubIssueReconciler) getSecretToken(ctx context.Context, issue *issuesv1.GitHubIssue, refs []string) (string, string, error) {
+	namespace := tokenSecretNamespace(issue)
+	if r.WatchNamespace != "" && namespace != r.WatchNamespace {
+		return "", "", &secretInvalidError{
+			reason: "NamespaceNotWatched",
+			message: fmt.Sprintf("token Secrets in namespace %q cannot be read: the operator only watches namespace %q",
+				namespace, r.WatchNamespace),
+		}
+	}
+	if err := r.TokenSources.checkSecretNamespace(issue.Namespace, namespace); err != nil {
+		return "", "", err
+	}
+	var tokens, secrets []string
+	var firstErr error
+	for _, ref := range refs {
+		key := types.NamespacedName{Name: ref, Namespace: namespace}
+		token, err := readToken(ctx, r.Client, key)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		tokens = append(tokens, token)
+		secrets = append(secrets, key.String())
+	}
+	if len(tokens) == 0 {
+		return "", "", firstErr
+	}
+	if len(tokens) < len(refs) {
+		log.FromContext(ctx).Info("skipping unusable token Secrets", "error", firstErr.Error())
+	}
+	picked := r.tokens.pick(secrets, r.now())
+	return tokens[picked], secrets[picked], nil
+}
+
+// readTokenFile reads the GitHub API token from the file at path.
+func readTokenFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("unable to read token file: %w", err)
+	}
+	token := strings.TrimSpace(string(data))
+	if token == "" {
+		return "", fmt.Errorf("token file %s is empty", path)
 	}
-	if err := r.Get(ctx, key, &secret); err != nil {
+	return token, nil
+}
+
+// readTokenEnv reads the GitHub API token from the environment variable name.
+func readTokenEnv(name string) (string, error) {
+	token := strings.TrimSpace(os.Getenv(name))
+	if token == "" {
+		return "", fmt.Errorf("environment variable %s is unset or empty", name)
+	}
+	return token, nil
+}
+
+// readToken reads the GitHub API token from the given Secret. A Secret with
+// "app-id" and "private-key" but no "token" key holds GitHub App credentials
+// instead, which are returned encoded as a token (see providers.AppCredentials).
+// It is shared by every reconciler that talks to the issue provider.
+func readToken(ctx context.Context, c client.Reader, key types.NamespacedName) (string, error) {
+	var secret corev1.Secret
+	if err := c.Get(ctx, key, &secret); err != nil {
 		return "", fmt.Errorf("unable to fetch Secret %s: %w", key, err)
 	}
-	tokenBytes, exists := secret.Data["token"]
+	// The API server defaults an empty type to Opaque; treat both the same.
+	if secret.Type != "" && secret.Type != corev1.SecretTypeOpaque {
+		return "", &secretInvalidError{
+			reason:  "WrongType",
+			message: fmt.Sprintf("Secret %s has type %q, expected %q", key, secret.Type, corev1.SecretTypeOpaque),
+		}
+	}
+	tokenBytes, exists := secret.Data[tokenSecretKey]
 	if !exists {
-		return "", fmt.Errorf("key \"token\" not found in Secret %s", key)
+		_, hasAppID := secret.Data[appIDSecretKey]
+		_, hasPrivateKey := secret.Data[appPrivateKeySecretKey]
+		if hasAppID && hasPrivateKey {
+			return readAppCredentials(&secret, key)
+		}
+		return "", &secretInvalidError{
+			reason: "MissingKey",
+			message: fmt.Sprintf("key %q, or keys %q and %q, not found in Secret %s",
+				tokenSecretKey, appIDSecretKey, appPrivateKeySecretKey, key),
+		}
+	}
+	if len(bytes.TrimSpace(tokenBytes)) == 0 {
+		return "", &secretInvalidError{
+			reason:  "EmptyValue",
+			message: fmt.Sprintf("key %q in Secret %s is empty", tokenSecretKey, key),
+		}
 	}
 	return string(tokenBytes), nil
 }
 
-// handleDeletion closes the remote issue (if it exists) and removes the finalizer
-// so Kubernetes can complete the deletion.
+// readAppCredentials reads the GitHub App credentials from secret, found at key.
+func readAppCredentials(secret *corev1.Secret, key types.NamespacedName) (string, error) {
+	var ids [2]int64
+	for i, idKey := range []string{appIDSecretKey, appInstallationIDSecretKey} {
+		value, exists := secret.Data[idKey]
+		if !exists {
+			return "", &secretInvalidError{
+				reason:  "MissingKey",
+				message: fmt.Sprintf("key %q not found in Secret %s", idKey, key),
+			}
+		}
+		id, err := strconv.ParseInt(string(bytes.TrimSpace(value)), 10, 64)
+		if err != nil || id <= 0 {
+			return "", &secretInvalidError{
+				reason:  "InvalidValue",
+				message: fmt.Sprintf("key %q in Secret %s is not a GitHub App ID", idKey, key),
+			}
+		}
+		ids[i] = id
+	}
+	privateKey := secret.Data[appPrivateKeySecretKey]
+	if len(bytes.TrimSpace(privateKey)) == 0 {
+		return "", &secretInvalidError{
+			reason:  "EmptyValue",
+			message: fmt.Sprintf("key %q in Secret %s is empty", appPrivateKeySecretKey, key),
+		}
+	}
+	return providers.AppCredentials{AppID: ids[0], InstallationID: ids[1], PrivateKey: privateKey}.Token(), nil
+}
+
+// updateStatus persists the status of issue. The cached copy it was read
+// from may be stale after a concurrent edit of the CR; on a conflict the
+// latest version is read and the same status written onto it. The
+// controller is the only writer of status, so nothing is lost by that.
+func (r *GitHubIssueReconciler) updateStatus(ctx context.Context, issue *issuesv1.GitHubIssue) error {
+	status := issue.Status.DeepCopy()
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		err := r.Status().Update(ctx, issue)
+		if !apierrors.IsConflict(err) {
+			return err
+		}
+		if err := r.Get(ctx, client.ObjectKeyFromObject(issue), issue); err != nil {
+			return err
+		}
+		status.DeepCopyInto(&issue.Status)
+		return err
+	})
+}
+
+// setCondition sets a status condition and persists it, skipping the write when nothing changed.
+func (r *GitHubIssueReconciler) setCondition(ctx context.Context, issue *issuesv1.GitHubIssue, condType string, status metav1.ConditionStatus, reason, message string) error {
+	changed := meta.SetStatusCondition(&issue.Status.Conditions, metav1.Condition{
+		Type:               condType,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: issue.Generation,
+	})
+	if !changed {
+		return nil
+	}
+	if err := r.updateStatus(ctx, issue); err != nil {
+		return fmt.Errorf("failed to set %s condition: %w", condType, err)
+	}
+	return nil
+}
+
+// clearCondition removes a status condition and persists the removal if it was present.
+func (r *GitHubIssueReconciler) clearCondition(ctx context.Context, issue *issuesv1.GitHubIssue, condType string) error {
+	if !meta.RemoveStatusCondition(&issue.Status.Conditions, condType) {
+		return nil
+	}
+	if err := r.updateStatus(ctx, issue); err != nil {
+		return fmt.Errorf("failed to clear %s condition: %w", condType, err)
+	}
+	return nil
+}
+
+// setNotReady sets the Ready condition to False with reason and message.
+func (r *GitHubIssueReconciler) setNotReady(ctx context.Context, issue *issuesv1.GitHubIssue, reason, message string) error {
+	return r.setCondition(ctx, issue, conditionReady, metav1.ConditionFalse, reason, message)
+}
+
+// setReady sets the Ready condition after a successful create or sync. An
+// issue left alone because another GitHubIssue manages it is not ready.
+func (r *GitHubIssueReconciler) setReady(ctx context.Context, issue *issuesv1.GitHubIssue) error {
+	if meta.IsStatusConditionTrue(issue.Status.Conditions, conditionOwnershipConflict) {
+		return r.setNotReady(ctx, issue, "OwnershipConflict",
+			meta.FindStatusCondition(issue.Status.Conditions, conditionOwnershipConflict).Message)
+	}
+	return r.setCondition(ctx, issue, conditionReady, metav1.ConditionTrue, "Synced",
+		fmt.Sprintf("issue %s#%d is in sync", issue.Spec.Repo, issue.Status.IssueNumber))
+}
+
+// isStuck reports whether the Stuck condition was set for the current spec.
+// A spec change bumps the generation, which lifts it.
+func isStuck(issue *issuesv1.GitHubIssue) bool {
+	cond := meta.FindStatusCondition(issue.Status.Conditions, conditionStuck)
+	return cond != nil && cond.Status == metav1.ConditionTrue && cond.ObservedGeneration == issue.Generation
+}
+
+// recordFailedAttempt counts a failed create or sync and records its error in
+// status. The retry is delayed by syncBackoff of the failure count, or, with
+// spec.maxRetries set, called off with the Stuck condition once the count
+// exceeds it.
+func (r *GitHubIssueReconciler) recordFailedAttempt(ctx context.Context, issue *issuesv1.GitHubIssue, syncErr error) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	// Attempts only count against the generation they were made for
+	if issue.Status.ObservedGeneration != issue.Generation {
+		issue.Status.ObservedGeneration = issue.Generation
+		issue.Status.FailedAttempts = 0
+	}
+	issue.Status.FailedAttempts++
+	issue.Status.LastError = syncErr.Error()
+
+	if issue.Spec.MaxRetries == nil || issue.Status.FailedAttempts <= *issue.Spec.MaxRetries {
+		if err := r.updateStatus(ctx, issue); err != nil {
+			// The sync error matters more; fall back to the workqueue's backoff
+			logger.Error(err, "failed to record failed attempt")
+			return ctrl.Result{}, syncErr
+		}
+		backoff := syncBackoff(issue.Status.FailedAttempts)
+		logger.Error(syncErr, "sync failed, retrying", "failedAttempts", issue.Status.FailedAttempts, "retryAfter", backoff)
+		return ctrl.Result{RequeueAfter: backoff}, nil
+	}
+
+	logger.Info("giving up after repeated sync failures", "failedAttempts", issue.Status.FailedAttempts, "error", syncErr.Error())
+	meta.SetStatusCondition(&issue.Status.Conditions, metav1.Condition{
+		Type:               conditionStuck,
+		Status:             metav1.ConditionTrue,
+		Reason:             "MaxRetriesExceeded",
+		Message:            fmt.Sprintf("sync failed %d times: %v", issue.Status.FailedAttempts, syncErr),
+		ObservedGeneration: issue.Generation,
+	})
+	if err := r.updateStatus(ctx, issue); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to set %s condition: %w", conditionStuck, err)
+	}
+	return ctrl.Result{}, nil
+}
+
+// syncBackoff returns how long to wait before retrying after the given number
+// of consecutive failures: minSyncBackoff, doubled per further failure, capped
+// at maxSyncBackoff.
+func syncBackoff(failedAttempts int32) time.Duration {
+	backoff := minSyncBackoff
+	for i := int32(1); i < failedAttempts && backoff < maxSyncBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, maxSyncBackoff)
+}
+
+// resetFailedAttempts clears the failure count, last error and Stuck condition
+// after a successful sync and records the generation that was synced, writing
+// status only when one of them changes.
+func (r *GitHubIssueReconciler) resetFailedAttempts(ctx context.Context, issue *issuesv1.GitHubIssue) error {
+	removed := meta.RemoveStatusCondition(&issue.Status.Conditions, conditionStuck)
+	if !removed && issue.Status.FailedAttempts == 0 && issue.Status.LastError == "" &&
+		issue.Status.ObservedGeneration == issue.Generation {
+		return nil
+	}
+	issue.Status.FailedAttempts = 0
+	issue.Status.LastError = ""
+	issue.Status.ObservedGeneration = issue.Generation
+	if err := r.updateStatus(ctx, issue); err != nil {
+		return fmt.Errorf("failed to reset failed attempts: %w", err)
+	}
+	return nil
+}
+
+// handleDeletion closes or deletes the remote issue (if it exists and
+// spec.deletionPolicy is not Orphan) and removes the finalizer so Kubernetes
+// can complete the deletion. Failures are retried until the cleanup timeout,
+// after which the remote issue is orphaned.
 func (r *GitHubIssueReconciler) handleDeletion(ctx context.Context, issue *issuesv1.GitHubIssue, token string) error {
 	logger := log.FromContext(ctx)
 
-	if !controllerutil.ContainsFinalizer(issue, githubIssueFinalizer) {
+	if !controllerutil.ContainsFinalizer(issue, r.finalizerName()) {
 		return nil
 	}
 
-	// Close the remote issue if it was created
-	if issue.Status.IssueNumber > 0 {
-		logger.Info("closing remote issue before deletion", "issueNumber", issue.Status.IssueNumber)
-		if err := r.IssueProvider.Close(ctx, token, issue.Spec.Repo, issue.Status.IssueNumber); err != nil {
-			return fmt.Errorf("failed to close remote issue: %w", err)
+	// Close or delete the remote issue if it was created, unless another CR
+	// manages it or the deletion policy leaves it open
+	if meta.IsStatusConditionTrue(issue.Status.Conditions, conditionOwnershipConflict) {
+		logger.Info("remote issue is managed by another GitHubIssue, leaving it open", "issueNumber", issue.Status.IssueNumber)
+	} else if issue.Spec.DeletionPolicy == issuesv1.DeletionPolicyOrphan {
+		logger.Info("deletion policy is Orphan, leaving remote issue open", "issueNumber", issue.Status.IssueNumber)
+	} else if issue.Status.IssueNumber > 0 {
+		if err := r.removeRemoteIssue(ctx, issue, token); err != nil {
+			// Retried with backoff until the cleanup timeout, as when the token is missing
+			if errors.Is(err, errAuditOnly) || r.cleanupRemaining(issue) > 0 {
+				return err
+			}
+			logger.Error(err, "remote cleanup still failing after cleanup timeout, orphaning remote issue",
+				"issueNumber", issue.Status.IssueNumber, "repo", issue.Spec.Repo)
 		}
 	}
 
 	// Remove finalizer to unblock deletion
-	controllerutil.RemoveFinalizer(issue, githubIssueFinalizer)
+	controllerutil.RemoveFinalizer(issue, r.finalizerName())
 	if err := r.Update(ctx, issue); err != nil {
 		return fmt.Errorf("failed to remove finalizer: %w", err)
 	}
@@ -145,14 +770,104 @@ func (r *GitHubIssueReconciler) handleDeletion(ctx context.Context, issue *issue
 	return nil
 }
 
+// removeRemoteIssue deletes the remote issue of a deleted CR whose
+// spec.deletionPolicy is Delete, where the provider can delete issues, and
+// closes it otherwise. An issue that is already gone counts as deleted.
+func (r *GitHubIssueReconciler) removeRemoteIssue(ctx context.Context, issue *issuesv1.GitHubIssue, token string) error {
+	logger := log.FromContext(ctx)
+
+	if issue.Spec.DeletionPolicy != issuesv1.DeletionPolicyDelete {
+		return r.closeRemoteIssue(ctx, issue, token)
+	}
+	deleter, ok := r.IssueProvider.(providers.Deleter)
+	if !ok || !providers.CapabilitiesOf(r.IssueProvider).Delete {
+		logger.Info("provider cannot delete issues, closing the remote issue instead", "issueNumber", issue.Status.IssueNumber)
+		return r.closeRemoteIssue(ctx, issue, token)
+	}
+	logger.Info("deleting remote issue", "issueNumber", issue.Status.IssueNumber)
+	callCtx, cancel := r.providerContext(ctx)
+	defer cancel()
+	if err := deleter.Delete(callCtx, token, issue.Spec.Repo, issue.Status.IssueNumber); err != nil && !errors.Is(err, providers.ErrIssueNotFound) {
+		return fmt.Errorf("failed to delete remote issue: %w", err)
+	}
+	return nil
+}
+
+// closeRemoteIssue closes the remote issue of a deleted CR and, with
+// spec.lockOnClose, locks it. Both calls are repeated on a retry; closing or
+// locking an issue twice is harmless.
+func (r *GitHubIssueReconciler) closeRemoteIssue(ctx context.Context, issue *issuesv1.GitHubIssue, token string) error {
+	logger := log.FromContext(ctx)
+
+	logger.Info("closing remote issue before deletion", "issueNumber", issue.Status.IssueNumber)
+	callCtx, cancel := r.providerContext(ctx)
+	defer cancel()
+	if err := r.IssueProvider.Close(callCtx, token, issue.Spec.Repo, issue.Status.IssueNumber); err != nil {
+		return fmt.Errorf("failed to close remote issue: %w", err)
+	}
+	if !issue.Spec.LockOnClose {
+		return nil
+	}
+	locker, ok := r.IssueProvider.(providers.Locker)
+	if !ok || !providers.CapabilitiesOf(r.IssueProvider).Lock {
+		logger.Info("provider cannot lock issues, leaving the remote issue unlocked", "issueNumber", issue.Status.IssueNumber)
+		return nil
+	}
+	logger.Info("locking remote issue before deletion", "issueNumber", issue.Status.IssueNumber)
+	lockCtx, cancelLock := r.providerContext(ctx)
+	defer cancelLock()
+	if err := locker.Lock(lockCtx, token, issue.Spec.Repo, issue.Status.IssueNumber); err != nil {
+		return fmt.Errorf("failed to lock remote issue: %w", err)
+	}
+	return nil
+}
+
+// cleanupRemaining returns how much of the cleanup timeout is left for a
+// deleted CR; it is zero or negative once the remote issue should be orphaned.
+func (r *GitHubIssueReconciler) cleanupRemaining(issue *issuesv1.GitHubIssue) time.Duration {
+	timeout := r.CleanupTimeout
+	if timeout == 0 {
+		timeout = defaultCleanupTimeout
+	}
+	return timeout - r.now().Sub(issue.DeletionTimestamp.Time)
+}
+
+// handleDeletionWithoutToken finalizes a CR whose token cannot be read. It keeps
+// retrying until the cleanup timeout, then removes the finalizer and orphans the
+// remote issue so that namespace deletion is not blocked forever.
+func (r *GitHubIssueReconciler) handleDeletionWithoutToken(ctx context.Context, issue *issuesv1.GitHubIssue, tokenErr error) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	if !controllerutil.ContainsFinalizer(issue, r.finalizerName()) {
+		return ctrl.Result{}, nil
+	}
+
+	// Without a remote issue to close there is nothing to wait for
+	if issue.Status.IssueNumber > 0 && issue.Spec.DeletionPolicy != issuesv1.DeletionPolicyOrphan {
+		if remaining := r.cleanupRemaining(issue); remaining > 0 {
+			logger.Info("token unavailable during deletion, retrying", "error", tokenErr.Error(), "giveUpIn", remaining)
+			return ctrl.Result{RequeueAfter: min(remaining, 30*time.Second)}, nil
+		}
+		logger.Error(tokenErr, "token unavailable after cleanup timeout, orphaning remote issue",
+			"issueNumber", issue.Status.IssueNumber, "repo", issue.Spec.Repo)
+	}
+
+	controllerutil.RemoveFinalizer(issue, r.finalizerName())
+	if err := r.Update(ctx, issue); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to remove finalizer: %w", err)
+	}
+	logger.Info("finalizer removed, CR can be deleted")
+	return ctrl.Result{}, nil
+}
+
 // ensureFinalizer adds the cleanup finalizer if it is not already present.
 // Returns (true, result, err) when the finalizer was just added (caller should return immediately
 // to requeue and re-fetch the updated object).
 func (r *GitHubIssueReconciler) ensureFinalizer(ctx context.Context, issue *issuesv1.GitHubIssue) (bool, ctrl.Result, error) {
-	if controllerutil.ContainsFinalizer(issue, githubIssueFinalizer) {
+	if controllerutil.ContainsFinalizer(issue, r.finalizerName()) {
 		return false, ctrl.Result{}, nil
 	}
-	controllerutil.AddFinalizer(issue, githubIssueFinalizer)
+	controllerutil.AddFinalizer(issue, r.finalizerName())
 	if err := r.Update(ctx, issue); err != nil {
 		return true, ctrl.Result{}, fmt.Errorf("failed to add finalizer: %w", err)
 	}
@@ -160,86 +875,801 @@ func (r *GitHubIssueReconciler) ensureFinalizer(ctx context.Context, issue *issu
 	return true, ctrl.Result{Requeue: true}, nil
 }
 
+// adoptOrCreateRemoteIssue adopts an existing issue with the desired title when
+// spec.adoptExisting is set, and creates a new one otherwise or if none is found.
+// An issue created by an earlier reconcile whose status write failed is picked
+// up from issueNumberAnnotation first.
+func (r *GitHubIssueReconciler) adoptOrCreateRemoteIssue(ctx context.Context, issue *issuesv1.GitHubIssue, desired *desiredIssue, token string) error {
+	if recovered, err := r.recoverCreatedIssue(ctx, issue, desired, token); err != nil || recovered {
+		return err
+	}
+	if issue.Spec.AdoptExisting {
+		adopted, err := r.adoptRemoteIssue(ctx, issue, desired, token)
+		if err != nil || adopted {
+			return err
+		}
+	}
+	return r.createRemoteIssue(ctx, issue, desired, token)
+}
+
+// adoptRemoteIssue searches spec.repo for an open issue titled exactly like
+// the desired one and not marked as owned by another CR. The lowest numbered
+// match is recorded in status and synced to the spec, which also adds our
+// owner marker. It reports whether an issue was adopted.
+func (r *GitHubIssueReconciler) adoptRemoteIssue(ctx context.Context, issue *issuesv1.GitHubIssue, desired *desiredIssue, token string) (bool, error) {
+	logger := log.FromContext(ctx)
+
+	callCtx, cancel := r.providerContext(ctx)
+	defer cancel()
+	found, err := r.IssueProvider.Search(callCtx, token, adoptionQuery(issue.Spec.Repo, desired.Title))
+	if err != nil {
+		return false, fmt.Errorf("failed to search for an existing remote issue: %w", err)
+	}
+	var match *providers.Issue
+	for _, candidate := range found {
+		if candidate.Title != desired.Title || foreignOwner(issue, candidate.Labels) != "" {
+			continue
+		}
+		if match == nil || candidate.Number < match.Number {
+			match = candidate
+		}
+	}
+	if match == nil {
+		return false, nil
+	}
+
+	logger.Info("adopting existing remote issue", "repo", issue.Spec.Repo, "issueNumber", match.Number)
+	issue.Status.Repo = issue.Spec.Repo
+	issue.Status.IssueNumber = match.Number
+	issue.Status.IssueURL = match.URL
+	issue.Status.State = match.State
+	issue.Status.StateReason = match.StateReason
+	issue.Status.Labels = providers.SortedLabels(match.Labels)
+	recordAuthor(issue, match)
+	if err := r.updateStatus(ctx, issue); err != nil {
+		return false, fmt.Errorf("failed to update status after adoption: %w", err)
+	}
+	return true, r.syncRemoteIssue(ctx, issue, desired, token)
+}
+
+// recoverCreatedIssue records in status the remote issue named by
+// issueNumberAnnotation and syncs it to the spec. It reports whether the
+// annotation was set and names an issue that still exists.
+func (r *GitHubIssueReconciler) recoverCreatedIssue(ctx context.Context, issue *issuesv1.GitHubIssue, desired *desiredIssue, token string) (bool, error) {
+	value, ok := issue.Annotations[issueNumberAnnotation]
+	if !ok {
+		return false, nil
+	}
+	number, err := strconv.Atoi(value)
+	if err != nil || number <= 0 {
+		return true, fmt.Errorf("annotation %s=%q is not an issue number", issueNumberAnnotation, value)
+	}
+
+	log.FromContext(ctx).Info("recovering remote issue created by an earlier reconcile", "repo", issue.Spec.Repo, "issueNumber", number)
+	callCtx, cancel := r.providerContext(ctx)
+	defer cancel()
+	current, err := r.IssueProvider.Get(callCtx, token, issue.Spec.Repo, number)
+	if errors.Is(err, providers.ErrIssueNotFound) {
+		// Deleted since; a new issue is created and replaces the annotation
+		return false, nil
+	}
+	if err != nil {
+		return true, fmt.Errorf("failed to get remote issue %d from annotation %s: %w", number, issueNumberAnnotation, err)
+	}
+	issue.Status.Repo = issue.Spec.Repo
+	issue.Status.IssueNumber = current.Number
+	issue.Status.IssueURL = current.URL
+	issue.Status.State = current.State
+	issue.Status.StateReason = current.StateReason
+	issue.Status.Labels = providers.SortedLabels(current.Labels)
+	// The issue was created before this reconcile, so the autoCloseAfter
+	// clock runs from when the provider says it was
+	createdAt := current.CreatedAt
+	if createdAt.IsZero() {
+		createdAt = r.now()
+	}
+	issue.Status.CreatedAt = ptr.To(metav1.NewTime(createdAt))
+	recordAuthor(issue, current)
+	if err := r.updateStatus(ctx, issue); err != nil {
+		return true, fmt.Errorf("failed to update status after recovering the remote issue: %w", err)
+	}
+	return true, r.syncRemoteIssue(ctx, issue, desired, token)
+}
+
+// recordAuthor records in status who opened remote and when, as far as the
+// provider reports it, without persisting it.
+func recordAuthor(issue *issuesv1.GitHubIssue, remote *providers.Issue) {
+	issue.Status.Author = remote.Author
+	issue.Status.IssueCreatedAt = nil
+	if !remote.CreatedAt.IsZero() {
+		issue.Status.IssueCreatedAt = ptr.To(metav1.NewTime(remote.CreatedAt))
+	}
+}
+
+// setIssueNumberAnnotation sets issueNumberAnnotation on issue without persisting it.
+func setIssueNumberAnnotation(issue *issuesv1.GitHubIssue, number int) {
+	if issue.Annotations == nil {
+		issue.Annotations = map[string]string{}
+	}
+	issue.Annotations[issueNumberAnnotation] = strconv.Itoa(number)
+}
+
+// adoptionQuery searches repo for open issues with title in their title.
+// Search syntax has no escape for double quotes, so they are dropped.
+func adoptionQuery(repo, title string) string {
+	phrase := strings.ReplaceAll(title, `"`, " ")
+	return fmt.Sprintf(`repo:%s is:issue is:open in:title "%s"`, repo, phrase)
+}
+
 // createRemoteIssue creates a new GitHub issue and records its details in status.
-func (r *GitHubIssueReconciler) createRemoteIssue(ctx context.Context, issue *issuesv1.GitHubIssue, token string) error {
+// The issue number is written to issueNumberAnnotation first: a regular update
+// does not race the status subresource, and if the status write then fails the
+// next reconcile recovers the issue rather than creating another one.
+func (r *GitHubIssueReconciler) createRemoteIssue(ctx context.Context, issue *issuesv1.GitHubIssue, desired *desiredIssue, token string) error {
 	logger := log.FromContext(ctx)
-	logger.Info("creating remote issue", "repo", issue.Spec.Repo, "title", issue.Spec.Title)
+	logger.Info("creating remote issue", "repo", issue.Spec.Repo, "title", desired.Title)
 
-	created, err := r.IssueProvider.Create(ctx, token, providers.CreateIssueInput{
-		Repo:   issue.Spec.Repo,
-		Title:  issue.Spec.Title,
-		Body:   issue.Spec.Body,
-		Labels: issue.Spec.Labels,
+	if err := r.ensureLabels(ctx, issue, token); err != nil {
+		return err
+	}
+	callCtx, cancel := r.providerContext(ctx)
+	defer cancel()
+	created, err := r.IssueProvider.Create(callCtx, token, providers.CreateIssueInput{
+		Repo:      issue.Spec.Repo,
+		Title:     desired.Title,
+		Body:      desired.Body,
+		Labels:    desired.Labels,
+		Assignees: desired.Assignees,
+		Milestone: desired.Milestone,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to create remote issue: %w", err)
 	}
 
+	// Update replaces the in-memory status with the stored one, so it has to
+	// come before status is filled in
+	setIssueNumberAnnotation(issue, created.Number)
+	if err := r.Update(ctx, issue); err != nil {
+		// Status is still written below and is enough on its own
+		logger.Error(err, "failed to record the remote issue number in an annotation", "issueNumber", created.Number)
+	}
+
+	issue.Status.Repo = issue.Spec.Repo
 	issue.Status.IssueNumber = created.Number
 	issue.Status.IssueURL = created.URL
 	issue.Status.State = created.State
-	if err := r.Status().Update(ctx, issue); err != nil {
+	issue.Status.Labels = providers.SortedLabels(created.Labels)
+	issue.Status.CreatedAt = ptr.To(metav1.NewTime(r.now()))
+	recordAuthor(issue, created)
+	// The provider returned the issue as created from the spec. LastVerifiedAt
+	// is left for the first sync, which still has state and pins to apply.
+	markVerified(issue, specHash(issue, desired, r.autoCloseDue(issue)))
+	if err := r.updateStatus(ctx, issue); err != nil {
 		return fmt.Errorf("failed to update status after creation: %w", err)
 	}
 	logger.Info("remote issue created", "issueNumber", created.Number)
+	if err := r.syncPinned(ctx, issue, created.Pinned, token); err != nil {
+		return err
+	}
+	return r.syncComments(ctx, issue, token)
+}
+
+// transferRemoteIssue moves the remote issue to spec.transferTo, records where
+// it ended up in status, then points spec.repo at the new repository and
+// clears spec.transferTo. Status is written first so that a failed spec update
+// is finished on the next reconcile instead of transferring again.
+func (r *GitHubIssueReconciler) transferRemoteIssue(ctx context.Context, issue *issuesv1.GitHubIssue, token string) error {
+	target := issue.Spec.TransferTo
+	if target == "" || issue.Status.IssueNumber == 0 {
+		return nil
+	}
+	logger := log.FromContext(ctx)
+
+	if target != issue.Status.Repo && target != issue.Spec.Repo {
+		transferer, ok := r.IssueProvider.(providers.Transferer)
+		if !ok || !providers.CapabilitiesOf(r.IssueProvider).Transfer {
+			logger.Info("issue provider cannot transfer issues, ignoring spec.transferTo")
+			return nil
+		}
+
+		logger.Info("transferring remote issue", "issueNumber", issue.Status.IssueNumber, "from", issue.Spec.Repo, "to", target)
+		callCtx, cancel := r.providerContext(ctx)
+		defer cancel()
+		moved, err := transferer.Transfer(callCtx, token, issue.Spec.Repo, issue.Status.IssueNumber, target)
+		if err != nil {
+			return fmt.Errorf("failed to transfer remote issue: %w", err)
+		}
+		issue.Status.Repo = target
+		issue.Status.IssueNumber = moved.Number
+		issue.Status.IssueURL = moved.URL
+		if err := r.updateStatus(ctx, issue); err != nil {
+			return fmt.Errorf("failed to update status after transfer: %w", err)
+		}
+		logger.Info("remote issue transferred", "repo", target, "issueNumber", moved.Number)
+		if _, ok := issue.Annotations[issueNumberAnnotation]; ok {
+			// Persisted with the spec update below
+			setIssueNumberAnnotation(issue, moved.Number)
+		}
+	}
+
+	issue.Spec.Repo = target
+	issue.Spec.TransferTo = ""
+	if err := r.Update(ctx, issue); err != nil {
+		return fmt.Errorf("failed to point spec.repo at the transferred issue: %w", err)
+	}
 	return nil
 }
 
+// syncIfChanged syncs the remote issue unless its spec hash is unchanged since
+// a sync verified within SpecHashWindow, and reports which of the two happened
+// on the InSync condition.
+func (r *GitHubIssueReconciler) syncIfChanged(ctx context.Context, issue *issuesv1.GitHubIssue, desired *desiredIssue, token string) error {
+	hash := specHash(issue, desired, r.autoCloseDue(issue))
+	if r.specHashFresh(issue, hash) {
+		log.FromContext(ctx).Info("spec unchanged since the last verified sync, skipping remote issue", "specHash", hash)
+		return r.setCondition(ctx, issue, conditionInSync, metav1.ConditionTrue, "SpecHashMatched",
+			fmt.Sprintf("spec hash %s is unchanged since the remote issue was verified at %s; the issue provider was not queried",
+				hash, issue.Status.LastVerifiedAt.UTC().Format(time.RFC3339)))
+	}
+
+	if err := r.syncRemoteIssue(ctx, issue, desired, token); err != nil {
+		return err
+	}
+	if meta.IsStatusConditionTrue(issue.Status.Conditions, conditionOwnershipConflict) {
+		// Nothing was synced, so there is nothing to vouch for
+		removed := meta.RemoveStatusCondition(&issue.Status.Conditions, conditionInSync)
+		if !removed && issue.Status.ObservedSpecHash == "" && issue.Status.LastVerifiedAt == nil {
+			return nil
+		}
+		issue.Status.ObservedSpecHash = ""
+		issue.Status.LastVerifiedAt = nil
+		if err := r.updateStatus(ctx, issue); err != nil {
+			return fmt.Errorf("failed to clear %s condition: %w", conditionInSync, err)
+		}
+		return nil
+	}
+
+	changed := markVerified(issue, hash)
+	// Without a window the timestamp is never consulted; leaving it out avoids
+	// a status write, and with it another reconcile, after every sync
+	if r.SpecHashWindow > 0 {
+		issue.Status.LastVerifiedAt = ptr.To(metav1.NewTime(r.now()))
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+	if err := r.updateStatus(ctx, issue); err != nil {
+		return fmt.Errorf("failed to record verified sync: %w", err)
+	}
+	return nil
+}
+
+// markVerified records in status that the remote issue was found to match
+// hash, and reports whether that changed anything.
+func markVerified(issue *issuesv1.GitHubIssue, hash string) bool {
+	changed := meta.SetStatusCondition(&issue.Status.Conditions, metav1.Condition{
+		Type:               conditionInSync,
+		Status:             metav1.ConditionTrue,
+		Reason:             "RemoteVerified",
+		Message:            fmt.Sprintf("issue %s#%d was read from the issue provider and matches spec hash %s", issue.Spec.Repo, issue.Status.IssueNumber, hash),
+		ObservedGeneration: issue.Generation,
+	})
+	if issue.Status.ObservedSpecHash != hash {
+		issue.Status.ObservedSpecHash = hash
+		changed = true
+	}
+	return changed
+}
+
+// specHashFresh reports whether hash matches a sync verified within SpecHashWindow.
+func (r *GitHubIssueReconciler) specHashFresh(issue *issuesv1.GitHubIssue, hash string) bool {
+	if r.SpecHashWindow <= 0 || issue.Status.LastVerifiedAt == nil || issue.Status.ObservedSpecHash != hash {
+		return false
+	}
+	return r.now().Sub(issue.Status.LastVerifiedAt.Time) < r.SpecHashWindow
+}
+
+// specHash hashes everything syncRemoteIssue pushes to the remote issue, so
+// that an unchanged hash means there is nothing new to send.
+func specHash(issue *issuesv1.GitHubIssue, desired *desiredIssue, autoClose bool) string {
+	data, _ := json.Marshal(struct {
+		Repo        string               `json:"repo"`
+		Title       string               `json:"title"`
+		Body        string               `json:"body"`
+		Labels      []string             `json:"labels"`
+		Assignees   []string             `json:"assignees"`
+		Milestone   string               `json:"milestone"`
+		State       string               `json:"state"`
+		CloseReason string               `json:"closeReason"`
+		AutoClose   bool                 `json:"autoClose"`
+		Pinned      bool                 `json:"pinned"`
+		KeepClosed  bool                 `json:"keepClosed"`
+		Comments    []string             `json:"comments"`
+		LabelSpecs  []issuesv1.LabelSpec `json:"labelSpecs"`
+	}{
+		Repo:        issue.Spec.Repo,
+		Title:       desired.Title,
+		Body:        desired.Body,
+		Labels:      desired.Labels,
+		Assignees:   desired.Assignees,
+		Milestone:   desired.Milestone,
+		State:       issue.Spec.State,
+		CloseReason: issue.Spec.CloseReason,
+		AutoClose:   autoClose,
+		Pinned:      issue.Spec.Pinned,
+		KeepClosed:  issue.Spec.TTLSecondsAfterClosed != nil,
+		Comments:    issue.Spec.Comments,
+		LabelSpecs:  issue.Spec.LabelSpecs,
+	})
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8])
+}
+
 // syncRemoteIssue enforces the desired state (spec) onto the existing GitHub issue.
-// It reopens the issue if closed externally and pushes any title/body/labels drift.
-func (r *GitHubIssueReconciler) syncRemoteIssue(ctx context.Context, issue *issuesv1.GitHubIssue, token string) error {
+// It reopens the issue if closed externally and pushes any title, body,
+// labels, assignees or milestone drift, batched into a single Update call. Issues marked
+// as owned by another CR are left untouched.
+func (r *GitHubIssueReconciler) syncRemoteIssue(ctx context.Context, issue *issuesv1.GitHubIssue, desired *desiredIssue, token string) error {
 	logger := log.FromContext(ctx)
 	logger.Info("syncing remote issue", "issueNumber", issue.Status.IssueNumber)
 
-	current, err := r.IssueProvider.Get(ctx, token, issue.Spec.Repo, issue.Status.IssueNumber)
+	callCtx, cancel := r.providerContext(ctx)
+	defer cancel()
+	current, err := r.IssueProvider.Get(callCtx, token, issue.Spec.Repo, issue.Status.IssueNumber)
+	if errors.Is(err, providers.ErrIssueNotFound) {
+		logger.Info("remote issue was deleted, creating it again", "issueNumber", issue.Status.IssueNumber)
+		if err := r.forgetRemoteIssue(ctx, issue); err != nil {
+			return err
+		}
+		return r.createRemoteIssue(ctx, issue, desired, token)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to get remote issue: %w", err)
 	}
 
-	// Reopen if someone closed it on GitHub
-	if current.State == "closed" {
-		logger.Info("reopening externally-closed issue", "issueNumber", issue.Status.IssueNumber)
-		if err := r.IssueProvider.Reopen(ctx, token, issue.Spec.Repo, issue.Status.IssueNumber); err != nil {
-			return fmt.Errorf("failed to reopen remote issue: %w", err)
-		}
-		current.State = "open"
+	// Stop here rather than fight another CR over the same issue
+	if owner := foreignOwner(issue, current.Labels); owner != "" {
+		logger.Info("remote issue is managed by another GitHubIssue", "issueNumber", issue.Status.IssueNumber, "marker", owner)
+		return r.setCondition(ctx, issue, conditionOwnershipConflict, metav1.ConditionTrue, "ManagedElsewhere",
+			fmt.Sprintf("issue %s#%d carries owner label %q of another GitHubIssue", issue.Spec.Repo, issue.Status.IssueNumber, owner))
+	}
+	if err := r.ensureLabels(ctx, issue, token); err != nil {
+		return err
+	}
+	if err := r.clearCondition(ctx, issue, conditionOwnershipConflict); err != nil {
+		return err
 	}
 
-	// Push spec to GitHub if title/body/labels have drifted
-	if r.specDrifted(issue, current) {
-		logger.Info("updating remote issue to match spec", "issueNumber", issue.Status.IssueNumber)
-		if _, err := r.IssueProvider.Update(ctx, token, issue.Spec.Repo, issue.Status.IssueNumber, providers.UpdateIssueInput{
-			Title:  issue.Spec.Title,
-			Body:   issue.Spec.Body,
-			Labels: issue.Spec.Labels,
-		}); err != nil {
+	// Close or reopen to match spec.state and push any content drift, all in
+	// one Update so the change is applied atomically
+	input, err := r.stateChange(ctx, issue, current)
+	if err != nil {
+		return err
+	}
+	// A difference is only drift when the spec is the one last verified
+	// remotely; after a spec change it is the change being pushed
+	specChanged := issue.Status.ObservedSpecHash != "" &&
+		issue.Status.ObservedSpecHash != specHash(issue, desired, r.autoCloseDue(issue))
+	if issue.Spec.TaskMode == issuesv1.TaskModeAdditive {
+		desired = desired.withCheckedTasks(current.Body)
+	}
+	drifted := driftedFields(desired, current)
+	contentDrifted := len(drifted) > 0
+	if contentDrifted && !specChanged {
+		if err := r.setCondition(ctx, issue, conditionDrift, metav1.ConditionTrue, "RemoteDiffers",
+			fmt.Sprintf("%s of issue %s#%d differed from spec",
+				strings.Join(drifted, ", "), issue.Spec.Repo, issue.Status.IssueNumber)); err != nil {
+			return err
+		}
+	}
+	if contentDrifted {
+		input.Title = desired.Title
+		input.Body = desired.Body
+		input.Labels = desired.Labels
+		if len(desired.Assignees) > 0 {
+			input.Assignees = desired.Assignees
+		}
+		input.Milestone = desired.Milestone
+	}
+	remoteLabels := providers.SortedLabels(current.Labels)
+	if contentDrifted || input.State != "" {
+		logger.Info("updating remote issue to match spec", "issueNumber", issue.Status.IssueNumber,
+			"contentDrifted", contentDrifted, "state", input.State)
+		updateCtx, cancelUpdate := r.providerContext(ctx)
+		defer cancelUpdate()
+		updated, err := r.IssueProvider.Update(updateCtx, token, issue.Spec.Repo, issue.Status.IssueNumber, input)
+		if err != nil {
 			return fmt.Errorf("failed to update remote issue: %w", err)
 		}
 		logger.Info("remote issue updated")
+		if contentDrifted {
+			if err := r.commentOnUpdate(ctx, issue, current, desired, token); err != nil {
+				return err
+			}
+			remoteLabels = desired.Labels
+		}
+		if input.State != "" {
+			current.State = updated.State
+			current.StateReason = updated.StateReason
+		}
+	}
+	if !contentDrifted || specChanged {
+		if err := r.clearCondition(ctx, issue, conditionDrift); err != nil {
+			return err
+		}
+	}
+
+	// Pin or unpin to match spec, where the provider supports it
+	if err := r.syncPinned(ctx, issue, current.Pinned, token); err != nil {
+		return err
+	}
+	if err := r.syncComments(ctx, issue, token); err != nil {
+		return err
 	}
 
 	// Sync status back
-	if issue.Status.State != current.State {
-		issue.Status.State = current.State
-		if err := r.Status().Update(ctx, issue); err != nil {
+	changed := issue.Status.State != current.State || issue.Status.StateReason != current.StateReason ||
+		!slices.Equal(issue.Status.Labels, remoteLabels)
+	issue.Status.State = current.State
+	issue.Status.StateReason = current.StateReason
+	issue.Status.Labels = remoteLabels
+	switch {
+	case current.State == "closed" && issue.Status.ClosedSince == nil:
+		now := metav1.NewTime(r.now())
+		issue.Status.ClosedSince = &now
+		changed = true
+	case current.State != "closed" && issue.Status.ClosedSince != nil:
+		issue.Status.ClosedSince = nil
+		changed = true
+	}
+	// Backfill issues recorded before status carried the author
+	if issue.Status.Author == "" && current.Author != "" {
+		recordAuthor(issue, current)
+		changed = true
+	}
+	if changed {
+		if err := r.updateStatus(ctx, issue); err != nil {
 			return fmt.Errorf("failed to update status after sync: %w", err)
 		}
 	}
 	return nil
 }
 
-// specDrifted reports whether the remote issue differs from the desired spec.
-func (r *GitHubIssueReconciler) specDrifted(issue *issuesv1.GitHubIssue, remote *providers.Issue) bool {
-	return remote.Title != issue.Spec.Title ||
-		remote.Body != issue.Spec.Body ||
-		!labelsMatch(remote.Labels, issue.Spec.Labels)
+// forgetRemoteIssue clears what status records about a remote issue that no
+// longer exists, so that a new one is created in its place. Status is written
+// first: if creating the new issue fails, the next reconcile creates it
+// rather than looking for the deleted one again.
+func (r *GitHubIssueReconciler) forgetRemoteIssue(ctx context.Context, issue *issuesv1.GitHubIssue) error {
+	issue.Status.Repo = ""
+	issue.Status.IssueNumber = 0
+	issue.Status.IssueURL = ""
+	issue.Status.CreatedAt = nil
+	issue.Status.Author = ""
+	issue.Status.IssueCreatedAt = nil
+	issue.Status.Labels = nil
+	issue.Status.State = ""
+	issue.Status.StateReason = ""
+	issue.Status.ClosedSince = nil
+	issue.Status.ObservedSpecHash = ""
+	issue.Status.LastVerifiedAt = nil
+	issue.Status.Comments = nil
+	meta.RemoveStatusCondition(&issue.Status.Conditions, conditionInSync)
+	if err := r.updateStatus(ctx, issue); err != nil {
+		return fmt.Errorf("failed to clear status of the deleted remote issue: %w", err)
+	}
+	return nil
 }
 
-// labelsMatch checks if two label slices contain the same elements (order-independent)
+// stateChange returns the part of an Update that moves the remote issue to
+// spec.state, or closes it once spec.autoCloseAfter has elapsed; it is empty
+// when the state is already right. An open issue closed on GitHub is reopened
+// unless a TTL lets closed issues expire; a closed issue is also corrected
+// when its close reason differs from spec.closeReason.
+func (r *GitHubIssueReconciler) stateChange(ctx context.Context, issue *issuesv1.GitHubIssue, current *providers.Issue) (providers.UpdateIssueInput, error) {
+	logger := log.FromContext(ctx)
+
+	autoClose := issue.Spec.State != "closed" && r.autoCloseDue(issue)
+	if autoClose {
+		if err := r.setCondition(ctx, issue, conditionAutoClosed, metav1.ConditionTrue, "AutoCloseAfterElapsed",
+			fmt.Sprintf("spec.autoCloseAfter (%s) has elapsed since the issue was created", issue.Spec.AutoCloseAfter.Duration)); err != nil {
+			return providers.UpdateIssueInput{}, err
+		}
+	} else if err := r.clearCondition(ctx, issue, conditionAutoClosed); err != nil {
+		return providers.UpdateIssueInput{}, err
+	}
+
+	// Reopening on drift only applies while the issue is meant to be open; an
+	// issue desired closed is never reopened, however it was closed
+	if issue.Spec.State != "closed" && !autoClose {
+		if current.State != "closed" || issue.Spec.TTLSecondsAfterClosed != nil {
+			return providers.UpdateIssueInput{}, nil
+		}
+		logger.Info("reopening externally-closed issue", "issueNumber", issue.Status.IssueNumber)
+		return providers.UpdateIssueInput{State: "open"}, nil
+	}
+
+	reasonDrifted := issue.Spec.CloseReason != "" && current.StateReason != issue.Spec.CloseReason
+	if current.State == "closed" && !reasonDrifted {
+		return providers.UpdateIssueInput{}, nil
+	}
+	logger.Info("closing remote issue to match spec", "issueNumber", issue.Status.IssueNumber,
+		"reason", issue.Spec.CloseReason, "remoteState", current.State, "remoteReason", current.StateReason)
+	return providers.UpdateIssueInput{State: "closed", StateReason: issue.Spec.CloseReason}, nil
+}
+
+// autoCloseDue reports whether spec.autoCloseAfter has elapsed. Issues
+// created before status.createdAt existed count from the CR's creation.
+func (r *GitHubIssueReconciler) autoCloseDue(issue *issuesv1.GitHubIssue) bool {
+	return issue.Spec.AutoCloseAfter != nil && r.untilAutoClose(issue) == 0
+}
+
+// untilAutoClose returns how long until spec.autoCloseAfter elapses, or zero
+// if it is unset or has already elapsed.
+func (r *GitHubIssueReconciler) untilAutoClose(issue *issuesv1.GitHubIssue) time.Duration {
+	if issue.Spec.AutoCloseAfter == nil {
+		return 0
+	}
+	created := issue.CreationTimestamp
+	if issue.Status.CreatedAt != nil {
+		created = *issue.Status.CreatedAt
+	}
+	if d := created.Add(issue.Spec.AutoCloseAfter.Duration).Sub(r.now()); d > 0 {
+		return d
+	}
+	return 0
+}
+
+// expireClosed deletes the CR once its remote issue has stayed closed for
+// spec.ttlSecondsAfterClosed, which runs the normal finalizer cleanup.
+// Returns (true, result, err) while a TTL is counting down or has just expired.
+func (r *GitHubIssueReconciler) expireClosed(ctx context.Context, issue *issuesv1.GitHubIssue) (bool, ctrl.Result, error) {
+	if issue.Spec.TTLSecondsAfterClosed == nil || issue.Status.ClosedSince == nil {
+		return false, ctrl.Result{}, nil
+	}
+	ttl := time.Duration(*issue.Spec.TTLSecondsAfterClosed) * time.Second
+	if remaining := issue.Status.ClosedSince.Add(ttl).Sub(r.now()); remaining > 0 {
+		return true, ctrl.Result{RequeueAfter: remaining}, nil
+	}
+
+	log.FromContext(ctx).Info("remote issue closed longer than TTL, deleting GitHubIssue", "ttl", ttl)
+	if err := r.Delete(ctx, issue); client.IgnoreNotFound(err) != nil {
+		return true, ctrl.Result{}, fmt.Errorf("failed to delete expired GitHubIssue: %w", err)
+	}
+	return true, ctrl.Result{}, nil
+}
+
+// ensureLabels creates or restyles the repository labels of spec.labelSpecs.
+// Providers that cannot manage labels leave their appearance to the provider.
+func (r *GitHubIssueReconciler) ensureLabels(ctx context.Context, issue *issuesv1.GitHubIssue, token string) error {
+	if len(issue.Spec.LabelSpecs) == 0 {
+		return nil
+	}
+	manager, ok := r.IssueProvider.(providers.LabelManager)
+	if !ok || !providers.CapabilitiesOf(r.IssueProvider).LabelAppearance {
+		log.FromContext(ctx).Info("provider cannot manage labels, ignoring spec.labelSpecs")
+		return nil
+	}
+	labels := make([]providers.LabelSpec, 0, len(issue.Spec.LabelSpecs))
+	for _, label := range issue.Spec.LabelSpecs {
+		labels = append(labels, providers.LabelSpec{Name: label.Name, Color: label.Color, Description: label.Description})
+	}
+	callCtx, cancel := r.providerContext(ctx)
+	defer cancel()
+	if err := manager.EnsureLabels(callCtx, token, issue.Spec.Repo, labels); err != nil {
+		return fmt.Errorf("failed to ensure repository labels: %w", err)
+	}
+	return nil
+}
+
+// syncPinned pins or unpins the remote issue to match spec.pinned. Providers
+// that cannot pin get the FeatureUnsupported condition instead of a call.
+func (r *GitHubIssueReconciler) syncPinned(ctx context.Context, issue *issuesv1.GitHubIssue, remotePinned bool, token string) error {
+	pinner, ok := r.IssueProvider.(providers.Pinner)
+	if !ok || !providers.CapabilitiesOf(r.IssueProvider).Pin {
+		if !issue.Spec.Pinned {
+			return r.clearCondition(ctx, issue, conditionFeatureUnsupported)
+		}
+		return r.setCondition(ctx, issue, conditionFeatureUnsupported, metav1.ConditionTrue, "PinNotSupported",
+			"the issue provider does not support pinning; spec.pinned is ignored")
+	}
+	if err := r.clearCondition(ctx, issue, conditionFeatureUnsupported); err != nil {
+		return err
+	}
+	if issue.Spec.Pinned == remotePinned {
+		return nil
+	}
+
+	log.FromContext(ctx).Info("updating remote pin state", "issueNumber", issue.Status.IssueNumber, "pinned", issue.Spec.Pinned)
+	callCtx, cancel := r.providerContext(ctx)
+	defer cancel()
+	if err := pinner.SetPinned(callCtx, token, issue.Spec.Repo, issue.Status.IssueNumber, issue.Spec.Pinned); err != nil {
+		return fmt.Errorf("failed to update remote pin state: %w", err)
+	}
+	return nil
+}
+
+// syncComments makes sure every entry of spec.comments is on the remote issue
+// exactly once and records the comments in status. A comment recorded in
+// status that still exists counts as posted. So does an unclaimed remote
+// comment with the same text, which covers a post whose status write failed.
+// Only comments missing after that are posted. Nothing is ever deleted.
+func (r *GitHubIssueReconciler) syncComments(ctx context.Context, issue *issuesv1.GitHubIssue, token string) error {
+	if len(issue.Spec.Comments) == 0 {
+		if issue.Status.Comments == nil {
+			return nil
+		}
+		issue.Status.Comments = nil
+		if err := r.updateStatus(ctx, issue); err != nil {
+			return fmt.Errorf("failed to update status after syncing comments: %w", err)
+		}
+		return nil
+	}
+	logger := log.FromContext(ctx)
+	commenter, ok := r.IssueProvider.(providers.Commenter)
+	if !ok || !providers.CapabilitiesOf(r.IssueProvider).Comments {
+		logger.Info("issue provider cannot post comments, ignoring spec.comments")
+		return nil
+	}
+
+	callCtx, cancel := r.providerContext(ctx)
+	defer cancel()
+	remote, err := commenter.ListComments(callCtx, token, issue.Spec.Repo, issue.Status.IssueNumber)
+	if err != nil {
+		return fmt.Errorf("failed to list remote comments: %w", err)
+	}
+	remoteBodies := make(map[int64]string, len(remote))
+	for _, comment := range remote {
+		remoteBodies[comment.ID] = comment.Body
+	}
+	recorded := make(map[string][]int64, len(issue.Status.Comments))
+	for _, posted := range issue.Status.Comments {
+		recorded[posted.Hash] = append(recorded[posted.Hash], posted.ID)
+	}
+
+	claimed := make(map[int64]bool, len(issue.Spec.Comments))
+	posted := make([]issuesv1.PostedComment, 0, len(issue.Spec.Comments))
+	for _, body := range issue.Spec.Comments {
+		hash := commentHash(body)
+		var id int64
+		for _, candidate := range recorded[hash] {
+			if remoteBody, ok := remoteBodies[candidate]; ok && remoteBody == body && !claimed[candidate] {
+				id = candidate
+				break
+			}
+		}
+		if id == 0 {
+			for _, comment := range remote {
+				if comment.Body == body && !claimed[comment.ID] {
+					id = comment.ID
+					break
+				}
+			}
+		}
+		if id == 0 {
+			logger.Info("posting comment", "issueNumber", issue.Status.IssueNumber, "hash", hash)
+			postCtx, cancelPost := r.providerContext(ctx)
+			created, err := commenter.AddComment(postCtx, token, issue.Spec.Repo, issue.Status.IssueNumber, body)
+			cancelPost()
+			if err != nil {
+				return fmt.Errorf("failed to comment on remote issue: %w", err)
+			}
+			id = created.ID
+		}
+		claimed[id] = true
+		posted = append(posted, issuesv1.PostedComment{ID: id, Hash: hash})
+	}
+
+	if slices.Equal(issue.Status.Comments, posted) {
+		return nil
+	}
+	issue.Status.Comments = posted
+	if err := r.updateStatus(ctx, issue); err != nil {
+		return fmt.Errorf("failed to update status after syncing comments: %w", err)
+	}
+	return nil
+}
+
+// commentHash identifies the text of a comment in status.
+func commentHash(body string) string {
+	sum := sha256.Sum256([]byte(body))
+	return hex.EncodeToString(sum[:8])
+}
+
+// commentOnUpdate posts a summary of an update pushed to the remote issue
+// when spec.commentOnUpdate is set. previous is the issue before the update.
+func (r *GitHubIssueReconciler) commentOnUpdate(ctx context.Context, issue *issuesv1.GitHubIssue, previous *providers.Issue, desired *desiredIssue, token string) error {
+	if !issue.Spec.CommentOnUpdate {
+		return nil
+	}
+	commenter, ok := r.IssueProvider.(providers.Commenter)
+	if !ok || !providers.CapabilitiesOf(r.IssueProvider).Comments {
+		log.FromContext(ctx).Info("issue provider cannot post comments, skipping update comment")
+		return nil
+	}
+	callCtx, cancel := r.providerContext(ctx)
+	defer cancel()
+	if _, err := commenter.AddComment(callCtx, token, issue.Spec.Repo, issue.Status.IssueNumber, describeUpdate(issue, previous, desired)); err != nil {
+		return fmt.Errorf("failed to comment on remote issue: %w", err)
+	}
+	return nil
+}
+
+// describeUpdate summarizes how desired differs from previous.
+func describeUpdate(issue *issuesv1.GitHubIssue, previous *providers.Issue, desired *desiredIssue) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Updated to match GitHubIssue %s/%s:\n", issue.Namespace, issue.Name)
+	if previous.Title != desired.Title {
+		fmt.Fprintf(&sb, "- title: %q → %q\n", previous.Title, desired.Title)
+	}
+	if previous.Body != desired.Body {
+		sb.WriteString("- body changed\n")
+	}
+	var added, removed []string
+	for _, l := range desired.Labels {
+		if !slices.Contains(previous.Labels, l) {
+			added = append(added, l)
+		}
+	}
+	for _, l := range previous.Labels {
+		if !slices.Contains(desired.Labels, l) {
+			removed = append(removed, l)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+	if len(added) > 0 {
+		fmt.Fprintf(&sb, "- labels added: %s\n", strings.Join(added, ", "))
+	}
+	if len(removed) > 0 {
+		fmt.Fprintf(&sb, "- labels removed: %s\n", strings.Join(removed, ", "))
+	}
+	if len(desired.Assignees) > 0 && !labelsMatch(previous.Assignees, desired.Assignees) {
+		fmt.Fprintf(&sb, "- assignees: %s\n", strings.Join(desired.Assignees, ", "))
+	}
+	if desired.Milestone != "" && previous.Milestone != desired.Milestone {
+		fmt.Fprintf(&sb, "- milestone: %q → %q\n", previous.Milestone, desired.Milestone)
+	}
+	return sb.String()
+}
+
+// driftedFields lists the fields of the remote issue that differ from the
+// desired spec, in a fixed order; it is empty when there is no drift.
+func driftedFields(desired *desiredIssue, remote *providers.Issue) []string {
+	var fields []string
+	if remote.Title != desired.Title {
+		fields = append(fields, "title")
+	}
+	if remote.Body != desired.Body {
+		// Checkboxes ticked on GitHub are reported apart from edits to the text
+		if len(desired.tasks) > 0 && uncheckTasks(remote.Body) == uncheckTasks(desired.Body) {
+			fields = append(fields, "tasks")
+		} else {
+			fields = append(fields, "body")
+		}
+	}
+	if !labelsMatch(remote.Labels, desired.Labels) {
+		fields = append(fields, "labels")
+	}
+	if len(desired.Assignees) > 0 && !labelsMatch(remote.Assignees, desired.Assignees) {
+		fields = append(fields, "assignees")
+	}
+	if desired.Milestone != "" && remote.Milestone != desired.Milestone {
+		fields = append(fields, "milestone")
+	}
+	return fields
+}
+
+// labelsMatch checks if two label slices contain the same elements (order-independent).
+// Labels from the provider and renderDesired are both sorted, so the common
+// case is settled by a plain comparison without allocating.
 func labelsMatch(a, b []string) bool {
 	if len(a) != len(b) {
 		return false
 	}
+	if slices.Equal(a, b) {
+		return true
+	}
 	aCopy := slices.Clone(a)
 	bCopy := slices.Clone(b)
 	sort.Strings(aCopy)
@@ -247,9 +1677,87 @@ func labelsMatch(a, b []string) bool {
 	return slices.Equal(aCopy, bCopy)
 }
 
+// finalizerName returns the configured cleanup finalizer.
+func (r *GitHubIssueReconciler) finalizerName() string {
+	if r.FinalizerName == "" {
+		return DefaultFinalizerName
+	}
+	return r.FinalizerName
+}
+
+// providerTimeout returns the configured bound on a single provider call.
+func (r *GitHubIssueReconciler) providerTimeout() time.Duration {
+	if r.ProviderTimeout == 0 {
+		return defaultProviderTimeout
+	}
+	return r.ProviderTimeout
+}
+
+// providerContext derives the context for a single provider call from ctx,
+// bounded by providerTimeout.
+func (r *GitHubIssueReconciler) providerContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, r.providerTimeout())
+}
+
+// now returns the current time from the configured clock.
+func (r *GitHubIssueReconciler) now() time.Time {
+	if r.Clock == nil {
+		return time.Now()
+	}
+	return r.Clock.Now()
+}
+
+// event records an event about issue when a Recorder is set.
+func (r *GitHubIssueReconciler) event(issue *issuesv1.GitHubIssue, eventType, reason, message string) {
+	if r.Recorder != nil {
+		r.Recorder.Event(issue, eventType, reason, message)
+	}
+}
+
+// tokenSecretIndex indexes GitHubIssues by the "namespace/name" of each
+// token Secret they reference.
+const tokenSecretIndex = ".spec.tokenSecrets"
+
+// tokenSecretKeys returns the tokenSecretIndex keys of a GitHubIssue.
+func tokenSecretKeys(obj client.Object) []string {
+	issue, ok := obj.(*issuesv1.GitHubIssue)
+	if !ok {
+		return nil
+	}
+	namespace := tokenSecretNamespace(issue)
+	var keys []string
+	for _, ref := range tokenSecretRefs(issue) {
+		keys = append(keys, types.NamespacedName{Namespace: namespace, Name: ref}.String())
+	}
+	return keys
+}
+
+// issuesForSecret maps a Secret to the GitHubIssues that take their token
+// from it, so a rotated token is picked up without waiting for the resync.
+func (r *GitHubIssueReconciler) issuesForSecret(ctx context.Context, secret client.Object) []ctrl.Request {
+	key := client.ObjectKeyFromObject(secret)
+	var issues issuesv1.GitHubIssueList
+	if err := r.List(ctx, &issues, client.MatchingFields{tokenSecretIndex: key.String()}); err != nil {
+		log.FromContext(ctx).Error(err, "failed to list GitHubIssues using token Secret", "secret", key)
+		return nil
+	}
+	requests := make([]ctrl.Request, 0, len(issues.Items))
+	for i := range issues.Items {
+		requests = append(requests, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&issues.Items[i])})
+	}
+	return requests
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *GitHubIssueReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &issuesv1.GitHubIssue{}, tokenSecretIndex, tokenSecretKeys); err != nil {
+		return err
+	}
+	b := ctrl.NewControllerManagedBy(mgr).
 		For(&issuesv1.GitHubIssue{}).
-		Complete(r)
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.issuesForSecret))
+	if r.SyncNow != nil {
+		b = b.WatchesRawSource(source.Channel(r.SyncNow, &handler.EnqueueRequestForObject{}))
+	}
+	return b.Complete(skipAuditedWrites(r))
 }
@@ -0,0 +1,120 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"testing"
+)
+
+func TestLabelsMatch(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []string
+		want bool
+	}{
+		{"both nil", nil, nil, true},
+		{"nil and empty", nil, []string{}, true},
+		{"nil and one", nil, []string{"bug"}, false},
+		{"same order", []string{"bug", "ui"}, []string{"bug", "ui"}, true},
+		{"different order", []string{"ui", "bug"}, []string{"bug", "ui"}, true},
+		{"different label", []string{"bug", "ui"}, []string{"bug", "api"}, false},
+		{"different length", []string{"bug"}, []string{"bug", "ui"}, false},
+		{"duplicates counted", []string{"bug", "bug", "ui"}, []string{"bug", "ui", "ui"}, false},
+		{"same duplicates", []string{"bug", "ui", "bug"}, []string{"bug", "bug", "ui"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := labelsMatch(tt.a, tt.b); got != tt.want {
+				t.Errorf("labelsMatch(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+			if got := labelsMatch(tt.b, tt.a); got != tt.want {
+				t.Errorf("labelsMatch(%v, %v) = %v, want %v", tt.b, tt.a, got, tt.want)
+			}
+		})
+	}
+}
+
+// labelsMatchSorted is the implementation without the sorted fast path, kept
+// to benchmark against.
+func labelsMatchSorted(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	aCopy := slices.Clone(a)
+	bCopy := slices.Clone(b)
+	sort.Strings(aCopy)
+	sort.Strings(bCopy)
+	return slices.Equal(aCopy, bCopy)
+}
+
+func BenchmarkLabelsMatch(b *testing.B) {
+	labels := make([]string, 100)
+	for i := range labels {
+		labels[i] = fmt.Sprintf("label-%03d", i)
+	}
+	reversed := slices.Clone(labels)
+	slices.Reverse(reversed)
+
+	cases := []struct {
+		name string
+		a, b []string
+	}{
+		{"sorted", labels, slices.Clone(labels)},
+		{"unsorted", labels, reversed},
+	}
+	// labelsMatchCounted compares by counting with a map; measured slower than
+	// sorting for GitHub-sized label sets, so it is not used.
+	labelsMatchCounted := func(a, b []string) bool {
+		if len(a) != len(b) {
+			return false
+		}
+		counts := make(map[string]int, len(a))
+		for _, l := range a {
+			counts[l]++
+		}
+		for _, l := range b {
+			if counts[l] == 0 {
+				return false
+			}
+			counts[l]--
+		}
+		return true
+	}
+	impls := []struct {
+		name  string
+		match func(a, b []string) bool
+	}{
+		{"sort", labelsMatchSorted},
+		{"map", labelsMatchCounted},
+		{"current", labelsMatch},
+	}
+	for _, c := range cases {
+		for _, impl := range impls {
+			b.Run(c.name+"/"+impl.name, func(b *testing.B) {
+				b.ReportAllocs()
+				for b.Loop() {
+					if !impl.match(c.a, c.b) {
+						b.Fatal("expected labels to match")
+					}
+				}
+			})
+		}
+	}
+}
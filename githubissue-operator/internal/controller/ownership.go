@@ -0,0 +1,54 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	issuesv1 "github.com/zhangbiao2009/controller_exercise/githubissue-operator/api/v1"
+)
+
+// ownerLabelPrefix starts the marker label that records which GitHubIssue
+// manages a remote issue. The rest of the label is a hash of the CR's
+// namespace/name, which keeps it within GitHub's 50-character label limit and
+// stable across backup/restore (unlike the UID).
+const ownerLabelPrefix = "k8s-owner-"
+
+// ownerMarker returns the marker label for the given CR.
+func ownerMarker(issue *issuesv1.GitHubIssue) string {
+	return markerFor(issue.Namespace + "/" + issue.Name)
+}
+
+// markerFor returns the marker label for an owner key.
+func markerFor(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return ownerLabelPrefix + hex.EncodeToString(sum[:8])
+}
+
+// foreignOwner returns the first marker label on the remote issue that belongs
+// to a different CR, or "" if the issue is unmarked or marked as ours.
+func foreignOwner(issue *issuesv1.GitHubIssue, remoteLabels []string) string {
+	ours := ownerMarker(issue)
+	for _, label := range remoteLabels {
+		if strings.HasPrefix(label, ownerLabelPrefix) && label != ours {
+			return label
+		}
+	}
+	return ""
+}
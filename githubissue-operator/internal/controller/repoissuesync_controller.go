@@ -0,0 +1,204 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/clock"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	issuesv1 "github.com/zhangbiao2009/controller_exercise/githubissue-operator/api/v1"
+	"github.com/zhangbiao2009/controller_exercise/githubissue-operator/pkg/providers"
+)
+
+// RepoIssueSyncReconciler reconciles a RepoIssueSync object
+type RepoIssueSyncReconciler struct {
+	client.Client
+	Scheme        *runtime.Scheme
+	IssueProvider providers.IssueProvider
+
+	// Clock is used for time-based decisions. Nil means the real clock.
+	Clock clock.PassiveClock
+
+	// Tracer starts a span for every reconcile. Nil records nothing.
+	Tracer trace.Tracer
+
+	// ProviderTimeout bounds each call to the issue provider, so that a hung
+	// connection fails the sync instead of blocking a worker. Zero means
+	// defaultProviderTimeout.
+	ProviderTimeout time.Duration
+}
+
+//+kubebuilder:rbac:groups=issues.github.example.com,resources=repoissuesyncs,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=issues.github.example.com,resources=repoissuesyncs/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=issues.github.example.com,resources=repoissuesyncs/finalizers,verbs=update
+
+// Reconcile ensures every issue listed in the RepoIssueSync exists and is open.
+// Issues it creates carry an owner marker label, which is how they are found
+// again on later reconciles.
+func (r *RepoIssueSyncReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	return tracedReconcile(ctx, r.Tracer, "RepoIssueSync.Reconcile", req, r.reconcile)
+}
+
+// reconcile does the work of Reconcile within its span.
+func (r *RepoIssueSyncReconciler) reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	// 1. Fetch the RepoIssueSync instance
+	var sync issuesv1.RepoIssueSync
+	if err := r.Get(ctx, req.NamespacedName, &sync); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Info("RepoIssueSync resource not found. Ignoring since object must be deleted.")
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	if !sync.DeletionTimestamp.IsZero() {
+		// Issues are left as they are
+		return ctrl.Result{}, nil
+	}
+
+	// 2. Get GitHub token
+	token, err := readToken(ctx, r.Client, types.NamespacedName{Name: sync.Spec.TokenSecretRef, Namespace: sync.Namespace})
+	if err != nil {
+		return ctrl.Result{RequeueAfter: 30 * time.Second}, err
+	}
+
+	// 3. Find the issues this RepoIssueSync created earlier
+	marker := repoIssueSyncMarker(&sync)
+	listCtx, cancelList := r.providerContext(ctx)
+	remote, err := r.IssueProvider.List(listCtx, token, sync.Spec.Repo, providers.ListIssuesOptions{Labels: []string{marker}})
+	cancelList()
+	if err != nil {
+		return r.providerError(ctx, fmt.Errorf("failed to list remote issues: %w", err))
+	}
+	byTitle := make(map[string]*providers.Issue, len(remote))
+	for _, issue := range remote {
+		if _, seen := byTitle[issue.Title]; !seen {
+			byTitle[issue.Title] = issue
+		}
+	}
+
+	// 4. Create missing issues and reopen closed ones
+	synced := make([]issuesv1.SyncedIssue, 0, len(sync.Spec.Issues))
+	for _, tmpl := range sync.Spec.Issues {
+		issue, err := r.ensureIssue(ctx, &sync, byTitle[tmpl.Title], tmpl, marker, token)
+		if err != nil {
+			return r.providerError(ctx, err)
+		}
+		synced = append(synced, issuesv1.SyncedIssue{
+			Title:       tmpl.Title,
+			IssueNumber: issue.Number,
+			IssueURL:    issue.URL,
+			State:       issue.State,
+		})
+	}
+
+	// 5. Record the managed issues in status
+	if !equality.Semantic.DeepEqual(sync.Status.Issues, synced) {
+		sync.Status.Issues = synced
+		if err := r.Status().Update(ctx, &sync); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to update status: %w", err)
+		}
+	}
+
+	// 6. Periodic resync to recreate or reopen issues changed on GitHub
+	return ctrl.Result{RequeueAfter: 5 * time.Minute}, nil
+}
+
+// providerError returns err for a retry with backoff, unless the provider's
+// rate limit is exhausted: then the request waits for the limit to reset.
+func (r *RepoIssueSyncReconciler) providerError(ctx context.Context, err error) (ctrl.Result, error) {
+	if wait, ok := providers.RetryAfter(err, r.now()); ok {
+		log.FromContext(ctx).Info("provider rate limit exhausted, waiting for it to reset", "retryAfter", wait)
+		return ctrl.Result{RequeueAfter: wait}, nil
+	}
+	return ctrl.Result{}, err
+}
+
+// providerContext derives the context for a single provider call from ctx,
+// bounded by ProviderTimeout.
+func (r *RepoIssueSyncReconciler) providerContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if r.ProviderTimeout == 0 {
+		return context.WithTimeout(ctx, defaultProviderTimeout)
+	}
+	return context.WithTimeout(ctx, r.ProviderTimeout)
+}
+
+// now returns the current time from the configured clock.
+func (r *RepoIssueSyncReconciler) now() time.Time {
+	if r.Clock == nil {
+		return time.Now()
+	}
+	return r.Clock.Now()
+}
+
+// ensureIssue makes sure the issue for tmpl exists and is open. existing is the
+// matching remote issue, or nil if there is none yet.
+func (r *RepoIssueSyncReconciler) ensureIssue(ctx context.Context, sync *issuesv1.RepoIssueSync, existing *providers.Issue, tmpl issuesv1.IssueTemplate, marker, token string) (*providers.Issue, error) {
+	logger := log.FromContext(ctx)
+
+	if existing == nil {
+		logger.Info("creating remote issue", "repo", sync.Spec.Repo, "title", tmpl.Title)
+		callCtx, cancel := r.providerContext(ctx)
+		defer cancel()
+		created, err := r.IssueProvider.Create(callCtx, token, providers.CreateIssueInput{
+			Repo:   sync.Spec.Repo,
+			Title:  tmpl.Title,
+			Body:   tmpl.Body,
+			Labels: append(append([]string(nil), tmpl.Labels...), marker),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create remote issue %q: %w", tmpl.Title, err)
+		}
+		return created, nil
+	}
+
+	if existing.State == "closed" {
+		logger.Info("reopening closed issue", "issueNumber", existing.Number)
+		callCtx, cancel := r.providerContext(ctx)
+		defer cancel()
+		if err := r.IssueProvider.Reopen(callCtx, token, sync.Spec.Repo, existing.Number); err != nil {
+			return nil, fmt.Errorf("failed to reopen remote issue %q: %w", tmpl.Title, err)
+		}
+		existing.State = "open"
+	}
+	return existing, nil
+}
+
+// repoIssueSyncMarker returns the owner marker label for issues created by sync.
+// The kind prefix keeps it distinct from a GitHubIssue with the same name.
+func repoIssueSyncMarker(sync *issuesv1.RepoIssueSync) string {
+	return markerFor("RepoIssueSync/" + sync.Namespace + "/" + sync.Name)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *RepoIssueSyncReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&issuesv1.RepoIssueSync{}).
+		Complete(skipAuditedWrites(r))
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clocktesting "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	issuesv1 "github.com/zhangbiao2009/controller_exercise/githubissue-operator/api/v1"
+	"github.com/zhangbiao2009/controller_exercise/githubissue-operator/pkg/providers"
+)
+
+var _ = Describe("RepoIssueSync Controller", func() {
+	const (
+		resourceName = "bulk-sync"
+		namespace    = "default"
+		secretName   = "github-token"
+		repo         = "owner/repo"
+		token        = "fake-token"
+	)
+
+	ctx := context.Background()
+	namespacedName := types.NamespacedName{Name: resourceName, Namespace: namespace}
+
+	var c client.Client
+	var mockProvider *providers.MockProvider
+	var reconciler *RepoIssueSyncReconciler
+
+	BeforeEach(func() {
+		mockProvider = providers.NewMockProvider()
+		c = fake.NewClientBuilder().
+			WithScheme(testScheme).
+			WithStatusSubresource(&issuesv1.RepoIssueSync{}).
+			Build()
+		reconciler = &RepoIssueSyncReconciler{Client: c, Scheme: testScheme, IssueProvider: mockProvider}
+
+		Expect(c.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: secretName, Namespace: namespace},
+			Data:       map[string][]byte{"token": []byte(token)},
+		})).To(Succeed())
+		Expect(c.Create(ctx, &issuesv1.RepoIssueSync{
+			ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: namespace},
+			Spec: issuesv1.RepoIssueSyncSpec{
+				Repo: repo,
+				Issues: []issuesv1.IssueTemplate{
+					{Title: "First", Body: "one", Labels: []string{"bug"}},
+					{Title: "Second", Body: "two"},
+					{Title: "Third"},
+				},
+				TokenSecretRef: secretName,
+			},
+		})).To(Succeed())
+	})
+
+	reconcileSync := func() reconcile.Result {
+		result, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: namespacedName})
+		Expect(err).NotTo(HaveOccurred())
+		return result
+	}
+
+	It("should create every listed issue and record them in status", func() {
+		result := reconcileSync()
+		Expect(result.RequeueAfter).To(Equal(5 * time.Minute))
+		Expect(mockProvider.CreateCount()).To(Equal(3))
+
+		var sync issuesv1.RepoIssueSync
+		Expect(c.Get(ctx, namespacedName, &sync)).To(Succeed())
+		Expect(sync.Status.Issues).To(HaveLen(3))
+		for i, title := range []string{"First", "Second", "Third"} {
+			Expect(sync.Status.Issues[i].Title).To(Equal(title))
+			Expect(sync.Status.Issues[i].State).To(Equal("open"))
+			remote := mockProvider.GetIssue(repo, sync.Status.Issues[i].IssueNumber)
+			Expect(remote).NotTo(BeNil())
+			Expect(remote.Title).To(Equal(title))
+			Expect(remote.Labels).To(ContainElement(repoIssueSyncMarker(&sync)))
+		}
+		Expect(mockProvider.GetIssue(repo, sync.Status.Issues[0].IssueNumber).Labels).To(ContainElement("bug"))
+	})
+
+	It("should not create duplicates on later reconciles", func() {
+		reconcileSync()
+		reconcileSync()
+		Expect(mockProvider.CreateCount()).To(Equal(3))
+	})
+
+	It("should ignore unmarked issues with the same title", func() {
+		_, err := mockProvider.Create(ctx, token, providers.CreateIssueInput{Repo: repo, Title: "First"})
+		Expect(err).NotTo(HaveOccurred())
+
+		reconcileSync()
+		Expect(mockProvider.CreateCount()).To(Equal(4))
+	})
+
+	It("should reopen closed issues and create newly listed ones", func() {
+		reconcileSync()
+		Expect(mockProvider.Close(ctx, token, repo, 2)).To(Succeed())
+
+		var sync issuesv1.RepoIssueSync
+		Expect(c.Get(ctx, namespacedName, &sync)).To(Succeed())
+		sync.Spec.Issues = append(sync.Spec.Issues, issuesv1.IssueTemplate{Title: "Fourth"})
+		Expect(c.Update(ctx, &sync)).To(Succeed())
+
+		reconcileSync()
+		Expect(mockProvider.GetIssue(repo, 2).State).To(Equal("open"))
+		Expect(mockProvider.CreateCount()).To(Equal(4))
+
+		Expect(c.Get(ctx, namespacedName, &sync)).To(Succeed())
+		Expect(sync.Status.Issues).To(HaveLen(4))
+		Expect(sync.Status.Issues[3].Title).To(Equal("Fourth"))
+	})
+
+	It("should requeue with an error while the Secret is missing", func() {
+		Expect(c.Delete(ctx, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: secretName, Namespace: namespace}})).To(Succeed())
+
+		result, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: namespacedName})
+		Expect(err).To(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(30 * time.Second))
+		Expect(mockProvider.CreateCount()).To(BeZero())
+	})
+
+	It("should wait until the rate limit resets by the configured clock", func() {
+		clk := clocktesting.NewFakePassiveClock(time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC))
+		reconciler.Clock = clk
+		mockProvider.CreateFunc = func(ctx context.Context, token string, input providers.CreateIssueInput) (*providers.Issue, error) {
+			return nil, &providers.RateLimitError{Reset: clk.Now().Add(42 * time.Second)}
+		}
+
+		Expect(reconcileSync().RequeueAfter).To(Equal(42 * time.Second))
+
+		// A reset already in the past still waits a moment
+		clk.SetTime(clk.Now().Add(time.Minute))
+		mockProvider.CreateFunc = func(ctx context.Context, token string, input providers.CreateIssueInput) (*providers.Issue, error) {
+			return nil, &providers.RateLimitError{Reset: clk.Now().Add(-time.Minute)}
+		}
+		Expect(reconcileSync().RequeueAfter).To(Equal(time.Second))
+	})
+
+	It("should cancel a hung call at the provider timeout", func() {
+		reconciler.ProviderTimeout = 50 * time.Millisecond
+		var callErr error
+		mockProvider.CreateFunc = func(ctx context.Context, token string, input providers.CreateIssueInput) (*providers.Issue, error) {
+			<-ctx.Done()
+			callErr = ctx.Err()
+			return nil, callErr
+		}
+
+		_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: namespacedName})
+		Expect(err).To(MatchError(context.DeadlineExceeded))
+		Expect(callErr).To(MatchError(context.DeadlineExceeded))
+	})
+})
@@ -0,0 +1,90 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	issuesv1 "github.com/zhangbiao2009/controller_exercise/githubissue-operator/api/v1"
+)
+
+// SyncNowHandler serves an admin endpoint that forces an immediate reconcile of
+// a single GitHubIssue instead of waiting for the periodic resync.
+//
+//	POST /sync?namespace=<ns>&name=<name>   (Authorization: Bearer <token>)
+//
+// Accepted requests are turned into generic events on Events, which the
+// reconciler consumes through a channel source (see GitHubIssueReconciler.SyncNow).
+type SyncNowHandler struct {
+	// Token is the bearer token callers must present. An empty token rejects every request.
+	Token string
+	// Events receives one event per accepted request.
+	Events chan<- event.GenericEvent
+}
+
+// ServeHTTP validates the caller and enqueues the requested GitHubIssue.
+func (h *SyncNowHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !h.authorized(r) {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	namespace := r.URL.Query().Get("namespace")
+	name := r.URL.Query().Get("name")
+	if namespace == "" || name == "" {
+		http.Error(w, "namespace and name query parameters are required", http.StatusBadRequest)
+		return
+	}
+
+	ev := event.GenericEvent{
+		Object: &issuesv1.GitHubIssue{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		},
+	}
+	select {
+	case h.Events <- ev:
+	case <-r.Context().Done():
+		http.Error(w, "request canceled before the sync was enqueued", http.StatusServiceUnavailable)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+	fmt.Fprintf(w, "sync enqueued for %s/%s\n", namespace, name)
+}
+
+// authorized reports whether the request carries the configured bearer token.
+func (h *SyncNowHandler) authorized(r *http.Request) bool {
+	if h.Token == "" {
+		return false
+	}
+	presented, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(presented), []byte(h.Token)) == 1
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var _ = Describe("SyncNowHandler", func() {
+	const adminToken = "s3cret"
+
+	var events chan event.GenericEvent
+	var h *SyncNowHandler
+
+	BeforeEach(func() {
+		events = make(chan event.GenericEvent, 1)
+		h = &SyncNowHandler{Token: adminToken, Events: events}
+	})
+
+	doRequest := func(method, target, auth string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, nil)
+		if auth != "" {
+			req.Header.Set("Authorization", auth)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	It("should enqueue a reconcile request for the named GitHubIssue", func() {
+		rec := doRequest(http.MethodPost, "/sync?namespace=team-a&name=my-issue", "Bearer "+adminToken)
+		Expect(rec.Code).To(Equal(http.StatusAccepted))
+
+		var ev event.GenericEvent
+		Eventually(events).Should(Receive(&ev))
+
+		// Feed the event through the same handler the controller uses
+		queue := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[reconcile.Request]())
+		defer queue.ShutDown()
+		(&handler.EnqueueRequestForObject{}).Generic(context.Background(), ev, queue)
+
+		Expect(queue.Len()).To(Equal(1))
+		req, _ := queue.Get()
+		Expect(req.NamespacedName).To(Equal(types.NamespacedName{Namespace: "team-a", Name: "my-issue"}))
+	})
+
+	It("should reject requests without the bearer token", func() {
+		rec := doRequest(http.MethodPost, "/sync?namespace=team-a&name=my-issue", "")
+		Expect(rec.Code).To(Equal(http.StatusUnauthorized))
+
+		rec = doRequest(http.MethodPost, "/sync?namespace=team-a&name=my-issue", "Bearer wrong")
+		Expect(rec.Code).To(Equal(http.StatusUnauthorized))
+		Consistently(events).ShouldNot(Receive())
+	})
+
+	It("should reject everything when no token is configured", func() {
+		h.Token = ""
+		rec := doRequest(http.MethodPost, "/sync?namespace=team-a&name=my-issue", "Bearer ")
+		Expect(rec.Code).To(Equal(http.StatusUnauthorized))
+	})
+
+	It("should require namespace and name", func() {
+		rec := doRequest(http.MethodPost, "/sync?namespace=team-a", "Bearer "+adminToken)
+		Expect(rec.Code).To(Equal(http.StatusBadRequest))
+		Consistently(events).ShouldNot(Receive())
+	})
+
+	It("should only accept POST", func() {
+		rec := doRequest(http.MethodGet, "/sync?namespace=team-a&name=my-issue", "Bearer "+adminToken)
+		Expect(rec.Code).To(Equal(http.StatusMethodNotAllowed))
+	})
+})
@@ -0,0 +1,92 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+
+	issuesv1 "github.com/zhangbiao2009/controller_exercise/githubissue-operator/api/v1"
+)
+
+// taskItem matches a GitHub task list item, capturing the checkbox mark and
+// the task text.
+var taskItem = regexp.MustCompile(`^([-*] \[)([ xX])(\] )(.+)$`)
+
+// renderTasks appends tasks to body as a GitHub task list, separated from the
+// body by a blank line.
+func renderTasks(body string, tasks []issuesv1.IssueTask) string {
+	if len(tasks) == 0 {
+		return body
+	}
+	var sb strings.Builder
+	if body != "" {
+		sb.WriteString(body)
+		sb.WriteString("\n\n")
+	}
+	for i, task := range tasks {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		if task.Done {
+			sb.WriteString("- [x] ")
+		} else {
+			sb.WriteString("- [ ] ")
+		}
+		sb.WriteString(task.Text)
+	}
+	return sb.String()
+}
+
+// checkedTasks returns the texts of the checked task list items in body.
+// GitHub stores bodies edited in the browser with CRLF line endings, which
+// are accepted too.
+func checkedTasks(body string) map[string]bool {
+	checked := map[string]bool{}
+	for _, line := range strings.Split(body, "\n") {
+		m := taskItem.FindStringSubmatch(strings.TrimSuffix(line, "\r"))
+		if m != nil && m[2] != " " {
+			checked[m[4]] = true
+		}
+	}
+	return checked
+}
+
+// uncheckTasks clears every task list checkbox in body, so two bodies that
+// only differ in which tasks are done compare equal.
+func uncheckTasks(body string) string {
+	lines := strings.Split(body, "\n")
+	for i, line := range lines {
+		lines[i] = taskItem.ReplaceAllString(line, "$1 $3$4")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// withCheckedTasks returns d with the tasks checked in remoteBody checked as
+// well, for spec.taskMode "additive".
+func (d *desiredIssue) withCheckedTasks(remoteBody string) *desiredIssue {
+	checked := checkedTasks(remoteBody)
+	tasks := slices.Clone(d.tasks)
+	for i := range tasks {
+		tasks[i].Done = tasks[i].Done || checked[tasks[i].Text]
+	}
+	merged := *d
+	merged.tasks = tasks
+	merged.Body = renderTasks(d.bodyWithoutTasks, tasks)
+	return &merged
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"slices"
+	"testing"
+
+	issuesv1 "github.com/zhangbiao2009/controller_exercise/githubissue-operator/api/v1"
+	"github.com/zhangbiao2009/controller_exercise/githubissue-operator/pkg/providers"
+)
+
+var testTasks = []issuesv1.IssueTask{
+	{Text: "Write the migration"},
+	{Text: "Review the rollout plan", Done: true},
+}
+
+func TestRenderTasks(t *testing.T) {
+	tests := []struct {
+		name  string
+		body  string
+		tasks []issuesv1.IssueTask
+		want  string
+	}{
+		{"no tasks", "Body", nil, "Body"},
+		{"after the body", "Body", testTasks, "Body\n\n- [ ] Write the migration\n- [x] Review the rollout plan"},
+		{"without a body", "", testTasks, "- [ ] Write the migration\n- [x] Review the rollout plan"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := renderTasks(tt.body, tt.tasks); got != tt.want {
+				t.Errorf("renderTasks() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckedTasks(t *testing.T) {
+	body := "Body\r\n\r\n- [X] Write the migration\r\n- [ ] Review the rollout plan\r\n* [x] Added on GitHub\r\n-[x] not a task"
+	got := checkedTasks(body)
+	want := map[string]bool{"Write the migration": true, "Added on GitHub": true}
+	if len(got) != len(want) {
+		t.Fatalf("checkedTasks() = %v, want %v", got, want)
+	}
+	for text := range want {
+		if !got[text] {
+			t.Errorf("checkedTasks() = %v, want %q checked", got, text)
+		}
+	}
+}
+
+func TestDriftedFields_Tasks(t *testing.T) {
+	desired := &desiredIssue{
+		Title:            "Title",
+		Body:             renderTasks("Body", testTasks),
+		bodyWithoutTasks: "Body",
+		tasks:            testTasks,
+	}
+	remote := func(body string) *providers.Issue {
+		return &providers.Issue{Title: "Title", Body: body}
+	}
+
+	tests := []struct {
+		name    string
+		desired *desiredIssue
+		body    string
+		want    []string
+	}{
+		{"in sync", desired, desired.Body, nil},
+		{"box ticked on GitHub", desired, "Body\n\n- [x] Write the migration\n- [x] Review the rollout plan", []string{"tasks"}},
+		{"box cleared on GitHub", desired, "Body\n\n- [ ] Write the migration\n- [ ] Review the rollout plan", []string{"tasks"}},
+		{"text edited on GitHub", desired, "Body\n\n- [ ] Write the tests\n- [x] Review the rollout plan", []string{"body"}},
+		{"additive keeps a box ticked on GitHub",
+			desired.withCheckedTasks("Body\n\n- [x] Write the migration\n- [x] Review the rollout plan"),
+			"Body\n\n- [x] Write the migration\n- [x] Review the rollout plan", nil},
+		{"additive still checks boxes done in spec",
+			desired.withCheckedTasks("Body\n\n- [ ] Write the migration\n- [ ] Review the rollout plan"),
+			"Body\n\n- [ ] Write the migration\n- [ ] Review the rollout plan", []string{"tasks"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := driftedFields(tt.desired, remote(tt.body)); !slices.Equal(got, tt.want) {
+				t.Errorf("driftedFields() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+	"unicode/utf8"
+
+	issuesv1 "github.com/zhangbiao2009/controller_exercise/githubissue-operator/api/v1"
+	"github.com/zhangbiao2009/controller_exercise/githubissue-operator/pkg/providers"
+)
+
+// desiredIssue is the remote issue content a GitHubIssue asks for, with all
+// templated spec fields rendered and the owner marker label added to the
+// sorted labels. Creation and drift detection both use it so the remote issue
+// is always compared against what would actually be sent.
+type desiredIssue struct {
+	Title  string
+	Body   string
+	Labels []string
+	// Assignees is empty when the controller leaves assignees alone
+	Assignees []string
+	// Milestone is empty when the controller leaves the milestone alone
+	Milestone string
+
+	// bodyWithoutTasks and tasks are what Body was rendered from
+	bodyWithoutTasks string
+	tasks            []issuesv1.IssueTask
+}
+
+// templateData is the data exposed to templates in GitHubIssue spec fields,
+// e.g. "env-{{ .Namespace }}". It only holds plain strings, so templates cannot
+// reach into the CR or the reconciler.
+type templateData struct {
+	Name      string
+	Namespace string
+	// Cluster is the name the operator was given for its cluster, if any
+	Cluster string
+}
+
+// renderDesired renders the templated spec fields of the CR, with cluster
+// exposed as .Cluster.
+func renderDesired(issue *issuesv1.GitHubIssue, cluster string) (*desiredIssue, error) {
+	data := templateData{
+		Name:      issue.Name,
+		Namespace: issue.Namespace,
+		Cluster:   cluster,
+	}
+
+	labels, err := renderLabels(issue.Spec.Labels, data)
+	if err != nil {
+		return nil, err
+	}
+	body := issue.Spec.Body
+	if strings.Contains(body, "{{") {
+		if body, err = renderTemplate("body", body, data); err != nil {
+			return nil, err
+		}
+	}
+	if issue.Spec.TruncateBody {
+		// The task list is appended after truncation, so leave room for it
+		tasksLength := utf8.RuneCountInString(renderTasks(body, issue.Spec.Tasks)) - utf8.RuneCountInString(body)
+		body = issuesv1.TruncateBody(body, issuesv1.MaxBodyLength-tasksLength)
+	}
+	return &desiredIssue{
+		Title:            issue.Spec.Title,
+		Body:             renderTasks(body, issue.Spec.Tasks),
+		Labels:           providers.SortedLabels(append(labels, ownerMarker(issue))),
+		Assignees:        providers.SortedLabels(issue.Spec.Assignees),
+		Milestone:        issue.Spec.Milestone,
+		bodyWithoutTasks: body,
+		tasks:            issue.Spec.Tasks,
+	}, nil
+}
+
+// renderLabels renders each label as a template. Labels without template
+// actions are returned unchanged.
+func renderLabels(labels []string, data templateData) ([]string, error) {
+	if labels == nil {
+		return nil, nil
+	}
+	rendered := make([]string, 0, len(labels))
+	for i, label := range labels {
+		if !strings.Contains(label, "{{") {
+			rendered = append(rendered, label)
+			continue
+		}
+		out, err := renderTemplate(fmt.Sprintf("labels[%d]", i), label, data)
+		if err != nil {
+			return nil, err
+		}
+		if strings.TrimSpace(out) == "" {
+			return nil, fmt.Errorf("labels[%d]: template %q renders to an empty label", i, label)
+		}
+		rendered = append(rendered, out)
+	}
+	return rendered, nil
+}
+
+// renderTemplate parses and executes a single template, failing on unknown keys.
+func renderTemplate(name, text string, data templateData) (string, error) {
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", name, err)
+	}
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, data); err != nil {
+		return "", fmt.Errorf("%s: %w", name, err)
+	}
+	return sb.String(), nil
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// tokenPool hands out the token with the most quota left among several, so
+// reconciles that may use more than one token spread their API calls, and
+// thus the per-token rate limit, across all of them. A token whose limit is
+// exhausted is skipped until it resets, and of tokens with equal headroom the
+// least recently used one goes first. Usage and quota are tracked by the
+// namespace/name of the Secret holding a token, so CRs sharing a Secret also
+// share its turn and token values are not kept around. The zero value is
+// ready to use.
+type tokenPool struct {
+	mu      sync.Mutex
+	seq     uint64
+	secrets map[string]*pooledSecret
+}
+
+// pooledSecret is what tokenPool knows about the token of one Secret.
+type pooledSecret struct {
+	lastUsed uint64
+	// remaining is the quota the provider last reported, until reset
+	remaining int
+	reset     time.Time
+}
+
+// headroom returns the quota s has left at now. Before the provider has
+// reported any, or once the reported quota has reset, it is unlimited.
+func (s *pooledSecret) headroom(now time.Time) int {
+	if !now.Before(s.reset) {
+		return math.MaxInt
+	}
+	return s.remaining
+}
+
+// secret returns the state of secret, adding it if it is new. p.mu must be held.
+func (p *tokenPool) secret(secret string) *pooledSecret {
+	if p.secrets == nil {
+		p.secrets = make(map[string]*pooledSecret)
+	}
+	s, ok := p.secrets[secret]
+	if !ok {
+		s = &pooledSecret{}
+		p.secrets[secret] = s
+	}
+	return s
+}
+
+// pick returns the index of the Secret in secrets with the most quota left at
+// now, the least recently used one among equals, and records it as used. Ties
+// go to the earlier entry. When every Secret is exhausted the one that resets
+// first is picked, so the caller is told how long to wait.
+func (p *tokenPool) pick(secrets []string, now time.Time) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	best := 0
+	for i := 1; i < len(secrets); i++ {
+		s, b := p.secret(secrets[i]), p.secret(secrets[best])
+		sHeadroom, bHeadroom := s.headroom(now), b.headroom(now)
+		switch {
+		case sHeadroom == 0 && bHeadroom == 0:
+			if s.reset.Before(b.reset) {
+				best = i
+			}
+		case sHeadroom != bHeadroom:
+			if sHeadroom > bHeadroom {
+				best = i
+			}
+		case s.lastUsed < b.lastUsed:
+			best = i
+		}
+	}
+	p.seq++
+	p.secret(secrets[best]).lastUsed = p.seq
+	return best
+}
+
+// observe records that the token of secret has remaining calls left until
+// reset, as reported by the provider.
+func (p *tokenPool) observe(secret string, remaining int, reset time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	s := p.secret(secret)
+	s.remaining, s.reset = remaining, reset
+}
+
+// exhausted records that the rate limit of the token of secret is exhausted
+// until reset, so pick skips it until then.
+func (p *tokenPool) exhausted(secret string, reset time.Time) {
+	p.observe(secret, 0, reset)
+}
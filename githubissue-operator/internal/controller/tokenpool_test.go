@@ -0,0 +1,67 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestTokenPoolPick(t *testing.T) {
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	secrets := []string{"ns/a", "ns/b", "ns/c"}
+
+	t.Run("least recently used among equals", func(t *testing.T) {
+		var p tokenPool
+		var got []int
+		for range 4 {
+			got = append(got, p.pick(secrets, now))
+		}
+		if want := []int{0, 1, 2, 0}; !slices.Equal(got, want) {
+			t.Errorf("picks = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("most headroom first", func(t *testing.T) {
+		var p tokenPool
+		p.observe("ns/a", 10, now.Add(time.Hour))
+		p.observe("ns/b", 500, now.Add(time.Hour))
+		p.observe("ns/c", 20, now.Add(time.Hour))
+		if got := p.pick(secrets, now); got != 1 {
+			t.Errorf("pick() = %d, want 1", got)
+		}
+	})
+
+	t.Run("exhausted skipped until reset", func(t *testing.T) {
+		var p tokenPool
+		p.exhausted("ns/a", now.Add(time.Hour))
+		p.exhausted("ns/b", now.Add(time.Minute))
+		for range 3 {
+			if got := p.pick(secrets, now); got != 2 {
+				t.Fatalf("pick() = %d, want 2", got)
+			}
+		}
+		p.exhausted("ns/c", now.Add(2*time.Hour))
+		if got := p.pick(secrets, now); got != 1 {
+			t.Errorf("pick() with all exhausted = %d, want 1, which resets first", got)
+		}
+		if got := p.pick(secrets, now.Add(time.Hour)); got != 0 {
+			t.Errorf("pick() after a reset = %d, want 0", got)
+		}
+	})
+}
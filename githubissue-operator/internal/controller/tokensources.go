@@ -0,0 +1,94 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// TokenSources limits where GitHubIssues may take their token from. Without
+// it, a GitHubIssue author could name any file or environment variable of the
+// operator's pod, such as its service account token, or another tenant's
+// token Secret, and have the operator send it to the issue provider.
+type TokenSources struct {
+	// SecretNamespaces are the namespaces, besides its own, a GitHubIssue may
+	// read token Secrets from with spec.tokenSecretNamespace, as set with
+	// --token-secret-namespaces. Empty allows only its own namespace.
+	SecretNamespaces []string
+
+	// FileDir is the directory spec.tokenFile must be in, as set with
+	// --token-file-dir. Empty rejects every spec.tokenFile.
+	FileDir string
+
+	// EnvPrefix is the prefix spec.tokenEnv must start with, as set with
+	// --token-env-prefix. Empty rejects every spec.tokenEnv.
+	EnvPrefix string
+}
+
+// checkFile returns the cleaned form of path, a spec.tokenFile, or a
+// *secretInvalidError when it is not inside FileDir.
+func (s TokenSources) checkFile(path string) (string, error) {
+	if s.FileDir == "" {
+		return "", &secretInvalidError{
+			reason:  "TokenFileNotAllowed",
+			message: "spec.tokenFile is not allowed: the operator has no --token-file-dir",
+		}
+	}
+	cleaned := filepath.Clean(path)
+	rel, err := filepath.Rel(filepath.Clean(s.FileDir), cleaned)
+	if err != nil || !filepath.IsAbs(cleaned) || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", &secretInvalidError{
+			reason:  "TokenFileNotAllowed",
+			message: fmt.Sprintf("spec.tokenFile %q is not allowed: it must be a file in %s", path, s.FileDir),
+		}
+	}
+	return cleaned, nil
+}
+
+// checkEnv returns a *secretInvalidError when name, a spec.tokenEnv, does not
+// start with EnvPrefix.
+func (s TokenSources) checkEnv(name string) error {
+	if s.EnvPrefix == "" {
+		return &secretInvalidError{
+			reason:  "TokenEnvNotAllowed",
+			message: "spec.tokenEnv is not allowed: the operator has no --token-env-prefix",
+		}
+	}
+	if !strings.HasPrefix(name, s.EnvPrefix) || name == s.EnvPrefix {
+		return &secretInvalidError{
+			reason:  "TokenEnvNotAllowed",
+			message: fmt.Sprintf("spec.tokenEnv %q is not allowed: it must start with %s", name, s.EnvPrefix),
+		}
+	}
+	return nil
+}
+
+// checkSecretNamespace returns a *secretInvalidError when a GitHubIssue in
+// namespace may not read token Secrets from secretNamespace.
+func (s TokenSources) checkSecretNamespace(namespace, secretNamespace string) error {
+	if secretNamespace == namespace || slices.Contains(s.SecretNamespaces, secretNamespace) {
+		return nil
+	}
+	return &secretInvalidError{
+		reason: "NamespaceNotAllowed",
+		message: fmt.Sprintf("token Secrets in namespace %q cannot be used from namespace %q: "+
+			"the operator's --token-secret-namespaces does not list it", secretNamespace, namespace),
+	}
+}
@@ -0,0 +1,170 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	issuesv1 "github.com/zhangbiao2009/controller_exercise/githubissue-operator/api/v1"
+)
+
+const (
+	// GitHubIssueValidatorPath is the path GitHubIssueValidator is served on.
+	GitHubIssueValidatorPath = "/validate-issues-github-example-com-v1-githubissue"
+	// GitHubIssueDefaulterPath is the path GitHubIssueDefaulter is served on.
+	GitHubIssueDefaulterPath = "/mutate-issues-github-example-com-v1-githubissue"
+)
+
+// DefaultIssueLabel is the label GitHubIssueDefaulter adds by default.
+const DefaultIssueLabel = "managed-by-operator"
+
+// repoPattern matches a repository in format "owner/repo".
+var repoPattern = regexp.MustCompile(`^[^/]+/[^/]+$`)
+
+//+kubebuilder:webhook:path=/validate-issues-github-example-com-v1-githubissue,mutating=false,failurePolicy=fail,sideEffects=None,groups=issues.github.example.com,resources=githubissues,verbs=create;update,versions=v1,name=vgithubissue.kb.io,admissionReviewVersions=v1
+
+// GitHubIssueValidator is a validating admission webhook that rejects
+// GitHubIssues the reconciler could never sync, so the mistake surfaces when
+// the GitHubIssue is applied rather than as a failed API call later.
+type GitHubIssueValidator struct {
+	// TokenSources limits the namespaces spec.tokenSecretNamespace and the
+	// files and environment variables spec.tokenFile and spec.tokenEnv may
+	// name, as it does for the reconciler.
+	TokenSources TokenSources
+}
+
+// Handle validates the GitHubIssue in a create or update request. Updates
+// that leave the spec alone, or that reach a GitHubIssue being deleted, are
+// always allowed, so one admitted before a rule was added can still have its
+// metadata changed and its finalizer removed.
+func (v *GitHubIssueValidator) Handle(_ context.Context, req admission.Request) admission.Response {
+	issue := &issuesv1.GitHubIssue{}
+	if err := json.Unmarshal(req.Object.Raw, issue); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	if req.Operation == admissionv1.Update {
+		old := &issuesv1.GitHubIssue{}
+		if err := json.Unmarshal(req.OldObject.Raw, old); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		if issue.DeletionTimestamp != nil || equality.Semantic.DeepEqual(old.Spec, issue.Spec) {
+			return admission.Allowed("")
+		}
+	}
+	if err := validateGitHubIssue(issue, v.TokenSources); err != nil {
+		return admission.Denied(err.Error())
+	}
+	return admission.Allowed("")
+}
+
+// SetupWebhookWithManager serves the validator from the manager's webhook server.
+func (v *GitHubIssueValidator) SetupWebhookWithManager(mgr ctrl.Manager) {
+	mgr.GetWebhookServer().Register(GitHubIssueValidatorPath, &webhook.Admission{Handler: v})
+}
+
+// validateGitHubIssue returns why issue cannot be synced with its token taken
+// from sources, or nil.
+func validateGitHubIssue(issue *issuesv1.GitHubIssue, sources TokenSources) error {
+	if !repoPattern.MatchString(issue.Spec.Repo) {
+		return fmt.Errorf("spec.repo %q is not in format \"owner/repo\"", issue.Spec.Repo)
+	}
+	if issue.Spec.TransferTo != "" && !repoPattern.MatchString(issue.Spec.TransferTo) {
+		return fmt.Errorf("spec.transferTo %q is not in format \"owner/repo\"", issue.Spec.TransferTo)
+	}
+	if issue.Spec.Title == "" {
+		return fmt.Errorf("spec.title must not be empty")
+	}
+	if err := issuesv1.CheckTitleLength(issue.Spec.Title); err != nil {
+		return fmt.Errorf("spec.title: %w", err)
+	}
+	// A templated body is only known once rendered, and a truncated one always fits
+	if !issue.Spec.TruncateBody && !strings.Contains(issue.Spec.Body, "{{") {
+		if err := issuesv1.CheckBodyLength(issue.Spec.Body); err != nil {
+			return fmt.Errorf("spec.body: %w", err)
+		}
+	}
+	if len(tokenSecretRefs(issue)) == 0 && issue.Spec.TokenFile == "" && issue.Spec.TokenEnv == "" {
+		return fmt.Errorf("one of spec.tokenSecretRef, spec.tokenSecretRefs, spec.tokenFile or spec.tokenEnv must be set")
+	}
+	if len(tokenSecretRefs(issue)) > 0 {
+		if err := sources.checkSecretNamespace(issue.Namespace, tokenSecretNamespace(issue)); err != nil {
+			return err
+		}
+	}
+	if issue.Spec.TokenFile != "" {
+		if _, err := sources.checkFile(issue.Spec.TokenFile); err != nil {
+			return err
+		}
+	}
+	if issue.Spec.TokenEnv != "" {
+		if err := sources.checkEnv(issue.Spec.TokenEnv); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//+kubebuilder:webhook:path=/mutate-issues-github-example-com-v1-githubissue,mutating=true,failurePolicy=fail,sideEffects=None,groups=issues.github.example.com,resources=githubissues,verbs=create;update,versions=v1,name=mgithubissue.kb.io,admissionReviewVersions=v1
+
+// GitHubIssueDefaulter is a mutating admission webhook that standardizes
+// GitHubIssues: it adds Label to spec.labels and sets spec.state to "open"
+// when they are missing. Applying its result again changes nothing.
+type GitHubIssueDefaulter struct {
+	// Label is added to every GitHubIssue that lacks it. Empty adds no label.
+	Label string
+}
+
+// Handle defaults the GitHubIssue in a create or update request.
+func (d *GitHubIssueDefaulter) Handle(_ context.Context, req admission.Request) admission.Response {
+	issue := &issuesv1.GitHubIssue{}
+	if err := json.Unmarshal(req.Object.Raw, issue); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	d.Default(issue)
+	defaulted, err := json.Marshal(issue)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	return admission.PatchResponseFromRaw(req.Object.Raw, defaulted)
+}
+
+// Default sets the missing defaults of issue.
+func (d *GitHubIssueDefaulter) Default(issue *issuesv1.GitHubIssue) {
+	if d.Label != "" && !slices.Contains(issue.Spec.Labels, d.Label) {
+		issue.Spec.Labels = append(issue.Spec.Labels, d.Label)
+	}
+	if issue.Spec.State == "" {
+		issue.Spec.State = "open"
+	}
+}
+
+// SetupWebhookWithManager serves the defaulter from the manager's webhook server.
+func (d *GitHubIssueDefaulter) SetupWebhookWithManager(mgr ctrl.Manager) {
+	mgr.GetWebhookServer().Register(GitHubIssueDefaulterPath, &webhook.Admission{Handler: d})
+}
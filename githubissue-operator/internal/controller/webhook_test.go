@@ -0,0 +1,250 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	issuesv1 "github.com/zhangbiao2009/controller_exercise/githubissue-operator/api/v1"
+)
+
+var _ = Describe("GitHubIssue validating webhook", func() {
+	validate := func(spec issuesv1.GitHubIssueSpec) admission.Response {
+		raw, err := json.Marshal(&issuesv1.GitHubIssue{
+			ObjectMeta: metav1.ObjectMeta{Name: "issue", Namespace: "default"},
+			Spec:       spec,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		return (&GitHubIssueValidator{}).Handle(context.Background(), admission.Request{
+			AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: admissionv1.Create,
+				Object:    runtime.RawExtension{Raw: raw},
+			},
+		})
+	}
+
+	It("should allow a valid GitHubIssue", func() {
+		resp := validate(issuesv1.GitHubIssueSpec{Repo: "owner/repo", Title: "Title", TokenSecretRef: "token"})
+		Expect(resp.Allowed).To(BeTrue())
+	})
+
+	It("should reject a repo without an owner", func() {
+		resp := validate(issuesv1.GitHubIssueSpec{Repo: "repo", Title: "Title", TokenSecretRef: "token"})
+		Expect(resp.Allowed).To(BeFalse())
+		Expect(resp.Result.Message).To(ContainSubstring("spec.repo"))
+	})
+
+	It("should reject a title over GitHub's limit", func() {
+		resp := validate(issuesv1.GitHubIssueSpec{
+			Repo:           "owner/repo",
+			Title:          strings.Repeat("t", issuesv1.MaxTitleLength+1),
+			TokenSecretRef: "token",
+		})
+		Expect(resp.Allowed).To(BeFalse())
+		Expect(resp.Result.Message).To(ContainSubstring("issue title too long"))
+	})
+
+	It("should reject token Secrets in a namespace the operator does not allow", func() {
+		resp := validate(issuesv1.GitHubIssueSpec{
+			Repo: "owner/repo", Title: "Title", TokenSecretRef: "token", TokenSecretNamespace: "other-tenant",
+		})
+		Expect(resp.Allowed).To(BeFalse())
+		Expect(resp.Result.Message).To(ContainSubstring("--token-secret-namespaces"))
+	})
+
+	Context("When the token comes from a file or the environment", func() {
+		sources := TokenSources{FileDir: "/var/run/tokens", EnvPrefix: "GITHUB_TOKEN_"}
+
+		validateSources := func(file, env string) admission.Response {
+			raw, err := json.Marshal(&issuesv1.GitHubIssue{
+				ObjectMeta: metav1.ObjectMeta{Name: "issue", Namespace: "default"},
+				Spec:       issuesv1.GitHubIssueSpec{Repo: "owner/repo", Title: "Title", TokenFile: file, TokenEnv: env},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			return (&GitHubIssueValidator{TokenSources: sources}).Handle(context.Background(), admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: admissionv1.Create,
+					Object:    runtime.RawExtension{Raw: raw},
+				},
+			})
+		}
+
+		It("should allow a file in --token-file-dir and a variable with --token-env-prefix", func() {
+			Expect(validateSources("/var/run/tokens/team-a", "").Allowed).To(BeTrue())
+			Expect(validateSources("/var/run/tokens/./team-a/../team-b", "").Allowed).To(BeTrue())
+			Expect(validateSources("", "GITHUB_TOKEN_TEAM_A").Allowed).To(BeTrue())
+		})
+
+		It("should reject files outside --token-file-dir", func() {
+			for _, file := range []string{
+				"/var/run/secrets/kubernetes.io/serviceaccount/token",
+				"/var/run/tokens/../../secrets/kubernetes.io/serviceaccount/token",
+				"/var/run/tokens/..",
+				"/var/run/tokens",
+				"/var/run/tokens-other/token",
+				"tokens/team-a",
+				"../var/run/tokens/team-a",
+			} {
+				resp := validateSources(file, "")
+				Expect(resp.Allowed).To(BeFalse(), file)
+				Expect(resp.Result.Message).To(ContainSubstring("spec.tokenFile"), file)
+			}
+		})
+
+		It("should reject variables without --token-env-prefix", func() {
+			for _, env := range []string{"OPERATOR_CREDENTIAL", "GITHUB_TOKEN_", "github_token_team_a", "XGITHUB_TOKEN_A"} {
+				resp := validateSources("", env)
+				Expect(resp.Allowed).To(BeFalse(), env)
+				Expect(resp.Result.Message).To(ContainSubstring("spec.tokenEnv"), env)
+			}
+		})
+
+		It("should reject both when the operator allows neither", func() {
+			sources = TokenSources{}
+			DeferCleanup(func() { sources = TokenSources{FileDir: "/var/run/tokens", EnvPrefix: "GITHUB_TOKEN_"} })
+			Expect(validateSources("/var/run/tokens/team-a", "").Allowed).To(BeFalse())
+			Expect(validateSources("", "GITHUB_TOKEN_TEAM_A").Allowed).To(BeFalse())
+		})
+	})
+
+	It("should reject a body over GitHub's limit", func() {
+		resp := validate(issuesv1.GitHubIssueSpec{
+			Repo:           "owner/repo",
+			Title:          "Title",
+			Body:           strings.Repeat("b", issuesv1.MaxBodyLength+1),
+			TokenSecretRef: "token",
+		})
+		Expect(resp.Allowed).To(BeFalse())
+		Expect(resp.Result.Message).To(ContainSubstring("issue body too long"))
+	})
+
+	It("should admit long bodies that are truncated or only known once rendered", func() {
+		long := strings.Repeat("b", issuesv1.MaxBodyLength+1)
+		resp := validate(issuesv1.GitHubIssueSpec{
+			Repo: "owner/repo", Title: "Title", Body: long, TruncateBody: true, TokenSecretRef: "token",
+		})
+		Expect(resp.Allowed).To(BeTrue())
+		resp = validate(issuesv1.GitHubIssueSpec{
+			Repo: "owner/repo", Title: "Title", Body: "{{ .Name }}" + long, TokenSecretRef: "token",
+		})
+		Expect(resp.Allowed).To(BeTrue(), "the reconciler checks the rendered body")
+	})
+
+	It("should reject an empty title", func() {
+		resp := validate(issuesv1.GitHubIssueSpec{Repo: "owner/repo", TokenSecretRef: "token"})
+		Expect(resp.Allowed).To(BeFalse())
+		Expect(resp.Result.Message).To(ContainSubstring("spec.title"))
+	})
+
+	Context("When an invalid GitHubIssue was admitted earlier", func() {
+		invalid := issuesv1.GitHubIssueSpec{Repo: "repo", Title: "Title", TokenSecretRef: "token"}
+
+		update := func(oldSpec issuesv1.GitHubIssueSpec, issue *issuesv1.GitHubIssue) admission.Response {
+			oldRaw, err := json.Marshal(&issuesv1.GitHubIssue{
+				ObjectMeta: metav1.ObjectMeta{Name: "issue", Namespace: "default"},
+				Spec:       oldSpec,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			raw, err := json.Marshal(issue)
+			Expect(err).NotTo(HaveOccurred())
+			return (&GitHubIssueValidator{}).Handle(context.Background(), admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: admissionv1.Update,
+					Object:    runtime.RawExtension{Raw: raw},
+					OldObject: runtime.RawExtension{Raw: oldRaw},
+				},
+			})
+		}
+
+		It("should allow updates that leave the spec alone", func() {
+			resp := update(invalid, &issuesv1.GitHubIssue{
+				ObjectMeta: metav1.ObjectMeta{Name: "issue", Namespace: "default", Labels: map[string]string{"team": "a"}},
+				Spec:       invalid,
+			})
+			Expect(resp.Allowed).To(BeTrue())
+		})
+
+		It("should allow any update once it is being deleted", func() {
+			now := metav1.Now()
+			changed := invalid
+			changed.Title = "New title"
+			resp := update(invalid, &issuesv1.GitHubIssue{
+				ObjectMeta: metav1.ObjectMeta{Name: "issue", Namespace: "default", DeletionTimestamp: &now},
+				Spec:       changed,
+			})
+			Expect(resp.Allowed).To(BeTrue())
+		})
+
+		It("should still reject a spec change that leaves it invalid", func() {
+			changed := invalid
+			changed.Title = "New title"
+			resp := update(invalid, &issuesv1.GitHubIssue{
+				ObjectMeta: metav1.ObjectMeta{Name: "issue", Namespace: "default"},
+				Spec:       changed,
+			})
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Message).To(ContainSubstring("spec.repo"))
+		})
+	})
+})
+
+var _ = Describe("GitHubIssue defaulting webhook", func() {
+	defaulter := &GitHubIssueDefaulter{Label: DefaultIssueLabel}
+
+	It("should add the label and state when they are missing", func() {
+		issue := &issuesv1.GitHubIssue{Spec: issuesv1.GitHubIssueSpec{Labels: []string{"bug"}}}
+		defaulter.Default(issue)
+		Expect(issue.Spec.Labels).To(Equal([]string{"bug", DefaultIssueLabel}))
+		Expect(issue.Spec.State).To(Equal("open"))
+	})
+
+	It("should keep a label and state that are already set", func() {
+		issue := &issuesv1.GitHubIssue{Spec: issuesv1.GitHubIssueSpec{
+			Labels: []string{DefaultIssueLabel, "bug"},
+			State:  "closed",
+		}}
+		defaulter.Default(issue)
+		Expect(issue.Spec.Labels).To(Equal([]string{DefaultIssueLabel, "bug"}))
+		Expect(issue.Spec.State).To(Equal("closed"))
+	})
+
+	It("should patch only the missing defaults", func() {
+		raw, err := json.Marshal(&issuesv1.GitHubIssue{
+			ObjectMeta: metav1.ObjectMeta{Name: "issue", Namespace: "default"},
+			Spec:       issuesv1.GitHubIssueSpec{Repo: "owner/repo", Title: "Title", State: "closed"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		resp := defaulter.Handle(context.Background(), admission.Request{
+			AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: admissionv1.Create,
+				Object:    runtime.RawExtension{Raw: raw},
+			},
+		})
+		Expect(resp.Allowed).To(BeTrue())
+		Expect(resp.Patches).To(HaveLen(1))
+		Expect(resp.Patches[0].Path).To(Equal("/spec/labels"))
+	})
+})
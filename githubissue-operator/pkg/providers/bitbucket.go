@@ -0,0 +1,405 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package providers
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// DefaultBitbucketURL is the Bitbucket Cloud API used when none is configured
+const DefaultBitbucketURL = "https://api.bitbucket.org/2.0"
+
+// bitbucketPageSize is the page size requested when listing issues, the
+// most Bitbucket allows for issues
+const bitbucketPageSize = 50
+
+// bitbucketLabelsPrefix starts the line of an issue's content that records
+// its labels. Bitbucket issues have no labels, so the provider keeps them in
+// a Markdown comment, which Bitbucket does not render, at the end of the
+// content and strips it again when reading the issue back.
+const bitbucketLabelsPrefix = "[//]: # (labels: "
+
+// BitbucketProvider implements IssueProvider for the issue tracker of
+// Bitbucket Cloud through its REST API. The repo of an issue is
+// "workspace/repo_slug". A token of the form "username:app-password" is sent
+// with basic auth, any other token as an OAuth bearer token.
+//
+// Bitbucket's states map onto open ("new", "open" and "on hold") and closed
+// (the others). Closing resolves an issue, or marks it "wontfix" when
+// StateReason is "not_planned". Bitbucket has no issue search across
+// repositories, so Search needs a repo: qualifier.
+type BitbucketProvider struct {
+	// BaseURL is the API root, e.g. "https://api.bitbucket.org/2.0"
+	BaseURL string
+	// HTTPClient sends the requests; nil means http.DefaultClient
+	HTTPClient *http.Client
+}
+
+// NewBitbucketProvider creates a BitbucketProvider for the API at baseURL,
+// or Bitbucket Cloud if it is empty
+func NewBitbucketProvider(baseURL string) *BitbucketProvider {
+	if baseURL == "" {
+		baseURL = DefaultBitbucketURL
+	}
+	return &BitbucketProvider{BaseURL: strings.TrimSuffix(baseURL, "/")}
+}
+
+// bitbucketIssue is the part of Bitbucket's issue representation the provider uses
+type bitbucketIssue struct {
+	ID      int               `json:"id"`
+	Title   string            `json:"title"`
+	State   string            `json:"state"`
+	Content *bitbucketContent `json:"content,omitempty"`
+	Links   struct {
+		HTML struct {
+			Href string `json:"href"`
+		} `json:"html"`
+	} `json:"links"`
+}
+
+// bitbucketContent is the text of an issue
+type bitbucketContent struct {
+	Raw string `json:"raw"`
+}
+
+// bitbucketIssueRequest is the body of a create or update request
+type bitbucketIssueRequest struct {
+	Title   string            `json:"title,omitempty"`
+	Content *bitbucketContent `json:"content,omitempty"`
+	State   string            `json:"state,omitempty"`
+}
+
+// bitbucketIssuePage is one page of a list of issues
+type bitbucketIssuePage struct {
+	Values []bitbucketIssue `json:"values"`
+	// Next is the URL of the next page, empty on the last one
+	Next string `json:"next"`
+}
+
+// authHeader returns the Authorization header for token
+func (p *BitbucketProvider) authHeader(token string) http.Header {
+	if strings.Contains(token, ":") {
+		return http.Header{"Authorization": {"Basic " + base64.StdEncoding.EncodeToString([]byte(token))}}
+	}
+	return http.Header{"Authorization": {"Bearer " + token}}
+}
+
+// do sends a request to the Bitbucket API and decodes the JSON response into
+// out, if it is not nil
+func (p *BitbucketProvider) do(ctx context.Context, token, method, path string, query url.Values, body, out any) error {
+	_, err := sendJSON(ctx, p.HTTPClient, method, p.BaseURL, path, query, p.authHeader(token), body, out)
+	return err
+}
+
+// bitbucketRepoPath returns the API path of the repository repo names
+func bitbucketRepoPath(repo string) (string, error) {
+	workspace, slug, err := parseRepo(repo)
+	if err != nil {
+		return "", err
+	}
+	return "/repositories/" + url.PathEscape(workspace) + "/" + url.PathEscape(slug), nil
+}
+
+// Create creates a new Bitbucket issue
+func (p *BitbucketProvider) Create(ctx context.Context, token string, input CreateIssueInput) (*Issue, error) {
+	path, err := bitbucketRepoPath(input.Repo)
+	if err != nil {
+		return nil, err
+	}
+
+	req := bitbucketIssueRequest{
+		Title:   input.Title,
+		Content: &bitbucketContent{Raw: bitbucketRaw(input.Body, input.Labels)},
+	}
+	var bbIssue bitbucketIssue
+	if err := p.do(ctx, token, http.MethodPost, path+"/issues", nil, req, &bbIssue); err != nil {
+		return nil, fmt.Errorf("failed to create Bitbucket issue: %w", err)
+	}
+	return fromBitbucketIssue(&bbIssue), nil
+}
+
+// Get retrieves an existing Bitbucket issue
+func (p *BitbucketProvider) Get(ctx context.Context, token string, repo string, issueNumber int) (*Issue, error) {
+	path, err := bitbucketRepoPath(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	var bbIssue bitbucketIssue
+	if err := p.do(ctx, token, http.MethodGet, bitbucketIssuePath(path, issueNumber), nil, nil, &bbIssue); err != nil {
+		if errors.Is(err, errNotFound) {
+			err = fmt.Errorf("%w: %w", ErrIssueNotFound, err)
+		}
+		return nil, fmt.Errorf("failed to get Bitbucket issue: %w", err)
+	}
+	return fromBitbucketIssue(&bbIssue), nil
+}
+
+// List returns the Bitbucket issues in a repository matching opts, following
+// pagination. The state is filtered by Bitbucket and the labels locally.
+func (p *BitbucketProvider) List(ctx context.Context, token string, repo string, opts ListIssuesOptions) ([]*Issue, error) {
+	path, err := bitbucketRepoPath(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	issues, err := p.listPages(ctx, token, path+"/issues", bitbucketStateQuery(opts.State))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Bitbucket issues: %w", err)
+	}
+	return withLabels(issues, opts.Labels), nil
+}
+
+// Search returns the Bitbucket issues matching a query in GitHub's issue
+// search syntax. The repo: qualifier is required. The is:, state: and
+// in:title qualifiers and the remaining words are turned into a Bitbucket
+// query that matches each word in the title or, without in:title, the
+// content; label: qualifiers are filtered locally.
+func (p *BitbucketProvider) Search(ctx context.Context, token string, query string) ([]*Issue, error) {
+	q := parseSearchQuery(query)
+	if q.repo == "" {
+		return nil, fmt.Errorf("searching Bitbucket issues needs a repo: qualifier")
+	}
+	path, err := bitbucketRepoPath(q.repo)
+	if err != nil {
+		return nil, err
+	}
+
+	var clauses []string
+	if state := bitbucketStateQuery(q.state); state != "" {
+		clauses = append(clauses, state)
+	}
+	for _, term := range q.terms {
+		clause := "title ~ " + strconv.Quote(term)
+		if !q.inTitle {
+			clause = "(" + clause + " OR content.raw ~ " + strconv.Quote(term) + ")"
+		}
+		clauses = append(clauses, clause)
+	}
+	issues, err := p.listPages(ctx, token, path+"/issues", strings.Join(clauses, " AND "))
+	if err != nil {
+		return nil, fmt.Errorf("failed to search Bitbucket issues: %w", err)
+	}
+	return withLabels(issues, q.labels), nil
+}
+
+// listPages collects the issues of every page of a list request filtered by
+// the Bitbucket query bbql, following each page's link to the next
+func (p *BitbucketProvider) listPages(ctx context.Context, token, path, bbql string) ([]*Issue, error) {
+	query := url.Values{"pagelen": {strconv.Itoa(bitbucketPageSize)}}
+	if bbql != "" {
+		query.Set("q", bbql)
+	}
+	header := p.authHeader(token)
+	baseURL := p.BaseURL
+	var result []*Issue
+	for {
+		var page bitbucketIssuePage
+		if _, err := sendJSON(ctx, p.HTTPClient, http.MethodGet, baseURL, path, query, header, nil, &page); err != nil {
+			return nil, err
+		}
+		for i := range page.Values {
+			result = append(result, fromBitbucketIssue(&page.Values[i]))
+		}
+		if page.Next == "" {
+			return result, nil
+		}
+		// The next link is absolute and carries the query
+		baseURL, path, query = page.Next, "", nil
+	}
+}
+
+// Update updates an existing Bitbucket issue. Bitbucket keeps the body and
+// labels in the same field, so when only one of them changes the other is
+// read from the issue first.
+func (p *BitbucketProvider) Update(ctx context.Context, token string, repo string, issueNumber int, input UpdateIssueInput) (*Issue, error) {
+	path, err := bitbucketRepoPath(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	req := bitbucketIssueRequest{Title: input.Title, State: bitbucketState(input.State, input.StateReason)}
+	if input.Body != "" || input.Labels != nil {
+		body, labels := input.Body, input.Labels
+		if body == "" || labels == nil {
+			current, err := p.Get(ctx, token, repo, issueNumber)
+			if err != nil {
+				return nil, fmt.Errorf("failed to update Bitbucket issue: %w", err)
+			}
+			if body == "" {
+				body = current.Body
+			}
+			if labels == nil {
+				labels = current.Labels
+			}
+		}
+		req.Content = &bitbucketContent{Raw: bitbucketRaw(body, labels)}
+	}
+
+	var bbIssue bitbucketIssue
+	if err := p.do(ctx, token, http.MethodPut, bitbucketIssuePath(path, issueNumber), nil, req, &bbIssue); err != nil {
+		return nil, fmt.Errorf("failed to update Bitbucket issue: %w", err)
+	}
+	return fromBitbucketIssue(&bbIssue), nil
+}
+
+// Close resolves a Bitbucket issue
+func (p *BitbucketProvider) Close(ctx context.Context, token string, repo string, issueNumber int) error {
+	if _, err := p.Update(ctx, token, repo, issueNumber, UpdateIssueInput{State: "closed"}); err != nil {
+		return fmt.Errorf("failed to close Bitbucket issue: %w", err)
+	}
+	return nil
+}
+
+// Reopen reopens a closed Bitbucket issue
+func (p *BitbucketProvider) Reopen(ctx context.Context, token string, repo string, issueNumber int) error {
+	if _, err := p.Update(ctx, token, repo, issueNumber, UpdateIssueInput{State: "open"}); err != nil {
+		return fmt.Errorf("failed to reopen Bitbucket issue: %w", err)
+	}
+	return nil
+}
+
+// Capabilities reports no optional operations; pinning, comments, transfers,
+// assignees, locking, milestones and projects are not implemented for Bitbucket yet.
+func (p *BitbucketProvider) Capabilities() ProviderCapabilities {
+	return ProviderCapabilities{}
+}
+
+// bitbucketIssuePath returns the API path of an issue in the repository at repoPath
+func bitbucketIssuePath(repoPath string, id int) string {
+	return repoPath + "/issues/" + strconv.Itoa(id)
+}
+
+// bitbucketOpenStates are the Bitbucket states that count as open; all
+// others count as closed
+var bitbucketOpenStates = []string{"new", "open", "on hold"}
+
+// bitbucketStateQuery translates a provider-neutral state filter to a
+// Bitbucket query, empty for "all"
+func bitbucketStateQuery(state string) string {
+	var clauses []string
+	switch state {
+	case "open":
+		for _, s := range bitbucketOpenStates {
+			clauses = append(clauses, "state = "+strconv.Quote(s))
+		}
+		return "(" + strings.Join(clauses, " OR ") + ")"
+	case "closed":
+		for _, s := range bitbucketOpenStates {
+			clauses = append(clauses, "state != "+strconv.Quote(s))
+		}
+		return "(" + strings.Join(clauses, " AND ") + ")"
+	default:
+		return ""
+	}
+}
+
+// bitbucketState translates a provider-neutral state and reason to the
+// Bitbucket state to move an issue to, empty for no change
+func bitbucketState(state, reason string) string {
+	switch {
+	case state == "open":
+		return "open"
+	case state == "closed" && reason == "not_planned":
+		return "wontfix"
+	case state == "closed":
+		return "resolved"
+	default:
+		return ""
+	}
+}
+
+// bitbucketRaw returns the content of an issue with body and labels
+func bitbucketRaw(body string, labels []string) string {
+	if len(labels) == 0 {
+		return body
+	}
+	escaped := make([]string, 0, len(labels))
+	for _, label := range labels {
+		escaped = append(escaped, url.QueryEscape(label))
+	}
+	return body + "\n\n" + bitbucketLabelsPrefix + strings.Join(escaped, ",") + ")"
+}
+
+// parseBitbucketRaw splits the content of an issue into its body and the
+// labels recorded by bitbucketRaw
+func parseBitbucketRaw(raw string) (body string, labels []string) {
+	i := strings.LastIndex(raw, "\n\n"+bitbucketLabelsPrefix)
+	if i < 0 || !strings.HasSuffix(raw, ")") {
+		return raw, nil
+	}
+	encoded := strings.TrimSuffix(raw[i+len("\n\n"+bitbucketLabelsPrefix):], ")")
+	if strings.Contains(encoded, "\n") {
+		return raw, nil
+	}
+	for _, label := range strings.Split(encoded, ",") {
+		decoded, err := url.QueryUnescape(label)
+		if err != nil {
+			return raw, nil
+		}
+		labels = append(labels, decoded)
+	}
+	return raw[:i], labels
+}
+
+// withLabels returns the issues carrying all of labels
+func withLabels(issues []*Issue, labels []string) []*Issue {
+	if len(labels) == 0 {
+		return issues
+	}
+	var result []*Issue
+	for _, issue := range issues {
+		if !slices.ContainsFunc(labels, func(l string) bool { return !slices.Contains(issue.Labels, l) }) {
+			result = append(result, issue)
+		}
+	}
+	return result
+}
+
+// fromBitbucketIssue converts a Bitbucket issue to the provider-neutral
+// Issue, mapping its state onto open and closed
+func fromBitbucketIssue(bbIssue *bitbucketIssue) *Issue {
+	var body string
+	var labels []string
+	if bbIssue.Content != nil {
+		body, labels = parseBitbucketRaw(bbIssue.Content.Raw)
+	}
+	issue := &Issue{
+		Number: bbIssue.ID,
+		URL:    bbIssue.Links.HTML.Href,
+		State:  "open",
+		Title:  bbIssue.Title,
+		Body:   body,
+		Labels: SortedLabels(labels),
+	}
+	switch {
+	case slices.Contains(bitbucketOpenStates, bbIssue.State):
+	case bbIssue.State == "invalid" || bbIssue.State == "duplicate" || bbIssue.State == "wontfix":
+		issue.State, issue.StateReason = "closed", "not_planned"
+	default:
+		issue.State, issue.StateReason = "closed", "completed"
+	}
+	return issue
+}
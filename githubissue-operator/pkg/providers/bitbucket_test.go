@@ -0,0 +1,269 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package providers
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+// fakeBitbucket serves the issues of workspace/repo the way the Bitbucket
+// API does, one issue per page of a list. Requests must carry the bearer
+// token "secret" or the app password "user:secret". It records the q
+// parameter of the last list request in *lastQuery.
+func fakeBitbucket(t *testing.T, lastQuery *string) *httptest.Server {
+	t.Helper()
+	var issues []*bitbucketIssue
+	mux := http.NewServeMux()
+	var srv *httptest.Server
+	mux.HandleFunc("POST /repositories/workspace/repo/issues", func(w http.ResponseWriter, r *http.Request) {
+		var req bitbucketIssueRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Error(err)
+		}
+		issue := &bitbucketIssue{ID: len(issues) + 1, Title: req.Title, State: "new", Content: req.Content}
+		issue.Links.HTML.Href = fmt.Sprintf("https://bitbucket.org/workspace/repo/issues/%d", issue.ID)
+		issues = append(issues, issue)
+		_ = json.NewEncoder(w).Encode(issue)
+	})
+	mux.HandleFunc("GET /repositories/workspace/repo/issues", func(w http.ResponseWriter, r *http.Request) {
+		*lastQuery = r.URL.Query().Get("q")
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		resp := bitbucketIssuePage{}
+		if page < len(issues) {
+			resp.Values = []bitbucketIssue{*issues[page]}
+		}
+		if page+1 < len(issues) {
+			next := r.URL.Query()
+			next.Set("page", strconv.Itoa(page+1))
+			resp.Next = srv.URL + r.URL.Path + "?" + next.Encode()
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	})
+	issueHandler := func(update bool) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			id, _ := strconv.Atoi(r.PathValue("id"))
+			if id < 1 || id > len(issues) {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			issue := issues[id-1]
+			if update {
+				var req bitbucketIssueRequest
+				if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+					t.Error(err)
+				}
+				if req.Title != "" {
+					issue.Title = req.Title
+				}
+				if req.Content != nil {
+					issue.Content = req.Content
+				}
+				if req.State != "" {
+					issue.State = req.State
+				}
+			}
+			_ = json.NewEncoder(w).Encode(issue)
+		}
+	}
+	mux.HandleFunc("GET /repositories/workspace/repo/issues/{id}", issueHandler(false))
+	mux.HandleFunc("PUT /repositories/workspace/repo/issues/{id}", issueHandler(true))
+
+	basic := "Basic " + base64.StdEncoding.EncodeToString([]byte("user:secret"))
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); auth != "Bearer secret" && auth != basic {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestBitbucketProvider_CreateGetUpdate(t *testing.T) {
+	var q string
+	p := NewBitbucketProvider(fakeBitbucket(t, &q).URL + "/")
+	ctx := context.Background()
+
+	created, err := p.Create(ctx, "secret", CreateIssueInput{Repo: "workspace/repo", Title: "Bug", Body: "broken", Labels: []string{"ui", "needs triage"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &Issue{Number: 1, URL: "https://bitbucket.org/workspace/repo/issues/1", State: "open", Title: "Bug", Body: "broken", Labels: []string{"needs triage", "ui"}}
+	if !reflect.DeepEqual(created, want) {
+		t.Errorf("created = %+v, want %+v", created, want)
+	}
+
+	got, err := p.Get(ctx, "user:secret", "workspace/repo", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got = %+v, want %+v", got, want)
+	}
+
+	updated, err := p.Update(ctx, "secret", "workspace/repo", 1, UpdateIssueInput{Title: "Bug!", Labels: []string{"bug"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Title != "Bug!" || updated.Body != "broken" || !reflect.DeepEqual(updated.Labels, []string{"bug"}) {
+		t.Errorf("updated = %+v, want the new title and labels and the old body", updated)
+	}
+
+	updated, err = p.Update(ctx, "secret", "workspace/repo", 1, UpdateIssueInput{Body: "fixed?", Labels: []string{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Body != "fixed?" || len(updated.Labels) != 0 {
+		t.Errorf("updated = %+v, want the new body and no labels", updated)
+	}
+}
+
+func TestBitbucketProvider_CloseAndReopen(t *testing.T) {
+	var q string
+	p := NewBitbucketProvider(fakeBitbucket(t, &q).URL)
+	ctx := context.Background()
+
+	if _, err := p.Create(ctx, "secret", CreateIssueInput{Repo: "workspace/repo", Title: "Bug"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Close(ctx, "secret", "workspace/repo", 1); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := p.Get(ctx, "secret", "workspace/repo", 1); got.State != "closed" || got.StateReason != "completed" {
+		t.Errorf("after Close = %q/%q, want closed/completed", got.State, got.StateReason)
+	}
+	if err := p.Reopen(ctx, "secret", "workspace/repo", 1); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := p.Get(ctx, "secret", "workspace/repo", 1); got.State != "open" {
+		t.Errorf("state after Reopen = %q, want %q", got.State, "open")
+	}
+
+	closed, err := p.Update(ctx, "secret", "workspace/repo", 1, UpdateIssueInput{State: "closed", StateReason: "not_planned"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if closed.State != "closed" || closed.StateReason != "not_planned" {
+		t.Errorf("after closing as not planned = %q/%q, want closed/not_planned", closed.State, closed.StateReason)
+	}
+}
+
+func TestBitbucketProvider_MapsStates(t *testing.T) {
+	for state, want := range map[string]string{
+		"new": "open", "open": "open", "on hold": "open",
+		"resolved": "closed", "closed": "closed", "wontfix": "closed", "duplicate": "closed", "invalid": "closed",
+	} {
+		if got := fromBitbucketIssue(&bitbucketIssue{State: state}).State; got != want {
+			t.Errorf("state %q maps to %q, want %q", state, got, want)
+		}
+	}
+}
+
+func TestBitbucketProvider_List(t *testing.T) {
+	var q string
+	p := NewBitbucketProvider(fakeBitbucket(t, &q).URL)
+	ctx := context.Background()
+
+	for _, labels := range [][]string{{"bug"}, {"docs"}, {"bug", "ui"}} {
+		if _, err := p.Create(ctx, "secret", CreateIssueInput{Repo: "workspace/repo", Title: "Issue", Labels: labels}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	all, err := p.List(ctx, "secret", "workspace/repo", ListIssuesOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 3 {
+		t.Errorf("listed %d issues across pages, want 3", len(all))
+	}
+
+	bugs, err := p.List(ctx, "secret", "workspace/repo", ListIssuesOptions{State: "open", Labels: []string{"bug"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(bugs) != 2 || bugs[0].Number != 1 || bugs[1].Number != 3 {
+		t.Errorf("listed %+v, want issues 1 and 3", bugs)
+	}
+	if want := `(state = "new" OR state = "open" OR state = "on hold")`; q != want {
+		t.Errorf("q = %s, want %s", q, want)
+	}
+}
+
+func TestBitbucketProvider_Search(t *testing.T) {
+	var q string
+	p := NewBitbucketProvider(fakeBitbucket(t, &q).URL)
+	ctx := context.Background()
+
+	if _, err := p.Create(ctx, "secret", CreateIssueInput{Repo: "workspace/repo", Title: "Flaky test", Labels: []string{"ci"}}); err != nil {
+		t.Fatal(err)
+	}
+	found, err := p.Search(ctx, "secret", `repo:workspace/repo is:closed in:title label:ci "flaky test"`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(found) != 1 {
+		t.Errorf("found %d issues, want 1", len(found))
+	}
+	want := `(state != "new" AND state != "open" AND state != "on hold") AND title ~ "flaky test"`
+	if q != want {
+		t.Errorf("q = %s, want %s", q, want)
+	}
+
+	if _, err := p.Search(ctx, "secret", "flaky"); err == nil {
+		t.Error("Search without a repo: qualifier succeeded, want an error")
+	}
+}
+
+func TestBitbucketProvider_RejectsBadToken(t *testing.T) {
+	var q string
+	p := NewBitbucketProvider(fakeBitbucket(t, &q).URL)
+	if _, err := p.Get(context.Background(), "wrong", "workspace/repo", 1); err == nil {
+		t.Error("Get with a bad token succeeded, want an error")
+	}
+}
+
+func TestBitbucketProvider_GetMissingIssueIsNotFound(t *testing.T) {
+	var q string
+	p := NewBitbucketProvider(fakeBitbucket(t, &q).URL)
+	if _, err := p.Get(context.Background(), "secret", "workspace/repo", 1); !errors.Is(err, ErrIssueNotFound) {
+		t.Errorf("err = %v, want ErrIssueNotFound", err)
+	}
+	if _, err := p.Get(context.Background(), "wrong", "workspace/repo", 1); errors.Is(err, ErrIssueNotFound) {
+		t.Errorf("err = %v, want an error other than ErrIssueNotFound", err)
+	}
+}
+
+func TestParseBitbucketRaw_LeavesOrdinaryContentAlone(t *testing.T) {
+	raw := "see\n\n[//]: # (labels: not\nreally)"
+	if body, labels := parseBitbucketRaw(raw); body != raw || labels != nil {
+		t.Errorf("parseBitbucketRaw(%q) = %q, %v, want the content unchanged and no labels", raw, body, labels)
+	}
+	if body, labels := parseBitbucketRaw(bitbucketRaw("body", []string{"a,b", "c)"})); body != "body" || !reflect.DeepEqual(labels, []string{"a,b", "c)"}) {
+		t.Errorf("round trip = %q, %v, want body and [a,b c)]", body, labels)
+	}
+}
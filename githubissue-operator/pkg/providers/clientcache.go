@@ -0,0 +1,80 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package providers
+
+import (
+	"crypto/sha256"
+	"sync"
+
+	"github.com/google/go-github/v57/github"
+)
+
+// maxCachedClients bounds clientCache, so tokens that were rotated out do not
+// keep their clients around forever.
+const maxCachedClients = 64
+
+// clientCache keeps one GitHub client per token, so calls with the same token
+// share its connections and the rate limits it has seen. Entries are keyed
+// by the token's SHA-256 rather than the token itself. Past maxCachedClients
+// the least recently used client is dropped. The zero value is ready to use.
+type clientCache struct {
+	mu      sync.Mutex
+	seq     uint64
+	clients map[[sha256.Size]byte]*cachedClient
+}
+
+type cachedClient struct {
+	client   *github.Client
+	lastUsed uint64
+}
+
+// get returns the client cached for token, calling build to create it on a miss.
+func (c *clientCache) get(token string, build func() (*github.Client, error)) (*github.Client, error) {
+	key := sha256.Sum256([]byte(token))
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.clients == nil {
+		c.clients = make(map[[sha256.Size]byte]*cachedClient)
+	}
+	c.seq++
+	if cached, ok := c.clients[key]; ok {
+		cached.lastUsed = c.seq
+		return cached.client, nil
+	}
+
+	client, err := build()
+	if err != nil {
+		return nil, err
+	}
+	if len(c.clients) >= maxCachedClients {
+		c.evictOldest()
+	}
+	c.clients[key] = &cachedClient{client: client, lastUsed: c.seq}
+	return client, nil
+}
+
+// evictOldest drops the least recently used client. c.mu must be held.
+func (c *clientCache) evictOldest() {
+	var oldest [sha256.Size]byte
+	var oldestUsed uint64
+	for key, cached := range c.clients {
+		if oldestUsed == 0 || cached.lastUsed < oldestUsed {
+			oldest, oldestUsed = key, cached.lastUsed
+		}
+	}
+	delete(c.clients, oldest)
+}
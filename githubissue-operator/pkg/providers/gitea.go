@@ -0,0 +1,323 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package providers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"code.gitea.io/sdk/gitea"
+)
+
+// giteaPageSize is the page size requested when listing issues. Gitea caps
+// it at its MAX_RESPONSE_ITEMS setting, 50 by default.
+const giteaPageSize = 50
+
+// GiteaProvider implements IssueProvider for a Gitea instance through its
+// REST API. Labels are set by name and must already exist in the repository.
+// Gitea does not record why an issue was closed, so StateReason is always empty.
+type GiteaProvider struct {
+	// BaseURL is the Gitea instance, e.g. "https://gitea.example.com"
+	BaseURL string
+	// HTTPClient sends the requests; nil means the Gitea SDK's default client
+	HTTPClient *http.Client
+}
+
+// NewGiteaProvider creates a GiteaProvider for the instance at baseURL
+func NewGiteaProvider(baseURL string) *GiteaProvider {
+	return &GiteaProvider{BaseURL: strings.TrimSuffix(baseURL, "/")}
+}
+
+// newClient creates a Gitea client authenticating with token whose requests
+// use ctx. The SDK's server version probe is skipped; it would cost a request
+// per call and the provider only uses endpoints every supported Gitea has.
+func (p *GiteaProvider) newClient(ctx context.Context, token string) (*gitea.Client, error) {
+	opts := []gitea.ClientOption{gitea.SetToken(token), gitea.SetContext(ctx), gitea.SetGiteaVersion("")}
+	if p.HTTPClient != nil {
+		opts = append(opts, gitea.SetHTTPClient(p.HTTPClient))
+	}
+	client, err := gitea.NewClient(p.BaseURL, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Gitea client: %w", err)
+	}
+	return client, nil
+}
+
+// Create creates a new Gitea issue
+func (p *GiteaProvider) Create(ctx context.Context, token string, input CreateIssueInput) (*Issue, error) {
+	owner, repoName, err := parseRepo(input.Repo)
+	if err != nil {
+		return nil, err
+	}
+	client, err := p.newClient(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := gitea.CreateIssueOption{Title: input.Title, Body: input.Body}
+	if len(input.Labels) > 0 {
+		if opts.Labels, err = giteaLabelIDs(client, owner, repoName, input.Labels); err != nil {
+			return nil, fmt.Errorf("failed to create Gitea issue: %w", err)
+		}
+	}
+	gtIssue, resp, err := client.CreateIssue(owner, repoName, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Gitea issue: %w", giteaError(resp, err))
+	}
+	return fromGiteaIssue(gtIssue), nil
+}
+
+// Get retrieves an existing Gitea issue
+func (p *GiteaProvider) Get(ctx context.Context, token string, repo string, issueNumber int) (*Issue, error) {
+	owner, repoName, err := parseRepo(repo)
+	if err != nil {
+		return nil, err
+	}
+	client, err := p.newClient(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	gtIssue, resp, err := client.GetIssue(owner, repoName, int64(issueNumber))
+	if err != nil {
+		err = giteaError(resp, err)
+		if errors.Is(err, errNotFound) {
+			err = fmt.Errorf("%w: %w", ErrIssueNotFound, err)
+		}
+		return nil, fmt.Errorf("failed to get Gitea issue: %w", err)
+	}
+	return fromGiteaIssue(gtIssue), nil
+}
+
+// List returns the Gitea issues in a repository matching opts, following
+// pagination. Pull requests are skipped.
+func (p *GiteaProvider) List(ctx context.Context, token string, repo string, opts ListIssuesOptions) ([]*Issue, error) {
+	owner, repoName, err := parseRepo(repo)
+	if err != nil {
+		return nil, err
+	}
+	client, err := p.newClient(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	listOpts := gitea.ListIssueOption{State: giteaState(opts.State), Labels: opts.Labels}
+	issues, err := giteaListPages(&listOpts, func() ([]*gitea.Issue, *gitea.Response, error) {
+		return client.ListRepoIssues(owner, repoName, listOpts)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Gitea issues: %w", err)
+	}
+	return issues, nil
+}
+
+// Search returns the Gitea issues matching a query in GitHub's issue search
+// syntax. The repo:, is:, state: and label: qualifiers are turned into issue
+// list filters and the remaining words into Gitea's keyword search, which
+// always looks at titles and bodies; without repo: every repository the
+// token can see is searched.
+func (p *GiteaProvider) Search(ctx context.Context, token string, query string) ([]*Issue, error) {
+	q := parseSearchQuery(query)
+	var owner, repoName string
+	if q.repo != "" {
+		var err error
+		if owner, repoName, err = parseRepo(q.repo); err != nil {
+			return nil, err
+		}
+	}
+	client, err := p.newClient(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	listOpts := gitea.ListIssueOption{State: giteaState(q.state), Labels: q.labels, KeyWord: strings.Join(q.terms, " ")}
+	issues, err := giteaListPages(&listOpts, func() ([]*gitea.Issue, *gitea.Response, error) {
+		if q.repo != "" {
+			return client.ListRepoIssues(owner, repoName, listOpts)
+		}
+		return client.ListIssues(listOpts)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search Gitea issues: %w", err)
+	}
+	return issues, nil
+}
+
+// giteaListPages collects the issues of every page of a list request,
+// advancing opts to the Link header's next page between calls to list
+func giteaListPages(opts *gitea.ListIssueOption, list func() ([]*gitea.Issue, *gitea.Response, error)) ([]*Issue, error) {
+	opts.Type = gitea.IssueTypeIssue
+	opts.PageSize = giteaPageSize
+	opts.Page = 1
+	var result []*Issue
+	for {
+		gtIssues, resp, err := list()
+		if err != nil {
+			return nil, giteaError(resp, err)
+		}
+		for _, gtIssue := range gtIssues {
+			result = append(result, fromGiteaIssue(gtIssue))
+		}
+		if resp.NextPage == 0 {
+			return result, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// Update updates an existing Gitea issue. StateReason is ignored.
+func (p *GiteaProvider) Update(ctx context.Context, token string, repo string, issueNumber int, input UpdateIssueInput) (*Issue, error) {
+	owner, repoName, err := parseRepo(repo)
+	if err != nil {
+		return nil, err
+	}
+	client, err := p.newClient(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	// Gitea leaves the title alone when it is empty
+	opts := gitea.EditIssueOption{Title: input.Title}
+	if input.Body != "" {
+		opts.Body = &input.Body
+	}
+	if input.State != "" {
+		state := gitea.StateType(input.State)
+		opts.State = &state
+	}
+
+	gtIssue, resp, err := client.EditIssue(owner, repoName, int64(issueNumber), opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update Gitea issue: %w", giteaError(resp, err))
+	}
+	if input.Labels != nil {
+		if gtIssue.Labels, err = setGiteaLabels(client, owner, repoName, gtIssue.Index, input.Labels); err != nil {
+			return nil, fmt.Errorf("failed to update Gitea issue labels: %w", err)
+		}
+	}
+	return fromGiteaIssue(gtIssue), nil
+}
+
+// setGiteaLabels replaces the labels of an issue with those named and
+// returns the issue's new labels
+func setGiteaLabels(client *gitea.Client, owner, repo string, index int64, names []string) ([]*gitea.Label, error) {
+	ids, err := giteaLabelIDs(client, owner, repo, names)
+	if err != nil {
+		return nil, err
+	}
+	labels, resp, err := client.ReplaceIssueLabels(owner, repo, index, gitea.IssueLabelsOption{Labels: ids})
+	if err != nil {
+		return nil, giteaError(resp, err)
+	}
+	return labels, nil
+}
+
+// giteaLabelIDs looks up the IDs of the labels named in a repository, which
+// is how the Gitea SDK refers to labels. It fails if one does not exist.
+func giteaLabelIDs(client *gitea.Client, owner, repo string, names []string) ([]int64, error) {
+	byName := map[string]int64{}
+	opts := gitea.ListLabelsOptions{ListOptions: gitea.ListOptions{Page: 1, PageSize: giteaPageSize}}
+	for {
+		labels, resp, err := client.ListRepoLabels(owner, repo, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list labels: %w", giteaError(resp, err))
+		}
+		for _, label := range labels {
+			byName[label.Name] = label.ID
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	ids := make([]int64, 0, len(names))
+	for _, name := range names {
+		id, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("label %q does not exist in %s/%s", name, owner, repo)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// Close closes a Gitea issue
+func (p *GiteaProvider) Close(ctx context.Context, token string, repo string, issueNumber int) error {
+	if _, err := p.Update(ctx, token, repo, issueNumber, UpdateIssueInput{State: "closed"}); err != nil {
+		return fmt.Errorf("failed to close Gitea issue: %w", err)
+	}
+	return nil
+}
+
+// Reopen reopens a closed Gitea issue
+func (p *GiteaProvider) Reopen(ctx context.Context, token string, repo string, issueNumber int) error {
+	if _, err := p.Update(ctx, token, repo, issueNumber, UpdateIssueInput{State: "open"}); err != nil {
+		return fmt.Errorf("failed to reopen Gitea issue: %w", err)
+	}
+	return nil
+}
+
+// Capabilities reports no optional operations; pinning, comments, transfers,
+// assignees, locking, milestones and projects are not implemented for Gitea yet.
+func (p *GiteaProvider) Capabilities() ProviderCapabilities {
+	return ProviderCapabilities{}
+}
+
+// giteaError turns a failed Gitea SDK call into the provider errors the
+// controller understands. The SDK reports only the server's message, so the
+// status code is taken from resp: a 404 wraps errNotFound and a 429 becomes
+// a RateLimitError. Other errors are returned unchanged.
+func giteaError(resp *gitea.Response, err error) error {
+	if resp == nil || resp.Response == nil {
+		return err
+	}
+	switch resp.StatusCode {
+	case http.StatusNotFound:
+		return fmt.Errorf("%w: %w", errNotFound, err)
+	case http.StatusTooManyRequests:
+		return &RateLimitError{Reset: rateLimitReset(resp.Header)}
+	}
+	return err
+}
+
+// giteaState translates a provider-neutral state filter to Gitea's
+func giteaState(state string) gitea.StateType {
+	if state == "open" || state == "closed" {
+		return gitea.StateType(state)
+	}
+	return gitea.StateAll
+}
+
+// fromGiteaIssue converts a Gitea issue to the provider-neutral Issue
+func fromGiteaIssue(gtIssue *gitea.Issue) *Issue {
+	labels := make([]string, 0, len(gtIssue.Labels))
+	for _, label := range gtIssue.Labels {
+		labels = append(labels, label.Name)
+	}
+	return &Issue{
+		Number: int(gtIssue.Index),
+		URL:    gtIssue.HTMLURL,
+		State:  string(gtIssue.State),
+		Title:  gtIssue.Title,
+		Body:   gtIssue.Body,
+		Labels: SortedLabels(labels),
+	}
+}
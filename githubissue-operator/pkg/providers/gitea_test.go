@@ -0,0 +1,171 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"code.gitea.io/sdk/gitea"
+)
+
+// fakeGitea serves a single issue, #1 in owner/repo, the way the Gitea API
+// does. The repository has the labels "bug" and "ui".
+func fakeGitea(t *testing.T) *httptest.Server {
+	t.Helper()
+	repoLabels := []*gitea.Label{{ID: 1, Name: "bug"}, {ID: 2, Name: "ui"}}
+	labelsByID := func(ids []int64) []*gitea.Label {
+		var labels []*gitea.Label
+		for _, id := range ids {
+			labels = append(labels, repoLabels[id-1])
+		}
+		return labels
+	}
+
+	var issue *gitea.Issue
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/repos/owner/repo/labels", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(repoLabels)
+	})
+	mux.HandleFunc("POST /api/v1/repos/owner/repo/issues", func(w http.ResponseWriter, r *http.Request) {
+		var req gitea.CreateIssueOption
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Error(err)
+		}
+		issue = &gitea.Issue{Index: 1, HTMLURL: "https://gitea.example.com/owner/repo/issues/1", State: gitea.StateOpen,
+			Title: req.Title, Body: req.Body, Labels: labelsByID(req.Labels)}
+		_ = json.NewEncoder(w).Encode(issue)
+	})
+	mux.HandleFunc("GET /api/v1/repos/owner/repo/issues/1", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(issue)
+	})
+	mux.HandleFunc("PATCH /api/v1/repos/owner/repo/issues/1", func(w http.ResponseWriter, r *http.Request) {
+		var req gitea.EditIssueOption
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Error(err)
+		}
+		if req.Title != "" {
+			issue.Title = req.Title
+		}
+		if req.Body != nil {
+			issue.Body = *req.Body
+		}
+		if req.State != nil {
+			issue.State = *req.State
+		}
+		_ = json.NewEncoder(w).Encode(issue)
+	})
+	mux.HandleFunc("PUT /api/v1/repos/owner/repo/issues/1/labels", func(w http.ResponseWriter, r *http.Request) {
+		var req gitea.IssueLabelsOption
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Error(err)
+		}
+		issue.Labels = labelsByID(req.Labels)
+		_ = json.NewEncoder(w).Encode(issue.Labels)
+	})
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "token secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestGiteaProvider_CreateGetUpdate(t *testing.T) {
+	p := NewGiteaProvider(fakeGitea(t).URL + "/")
+	ctx := context.Background()
+
+	created, err := p.Create(ctx, "secret", CreateIssueInput{Repo: "owner/repo", Title: "Bug", Body: "broken", Labels: []string{"ui", "bug"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &Issue{Number: 1, URL: "https://gitea.example.com/owner/repo/issues/1", State: "open", Title: "Bug", Body: "broken", Labels: []string{"bug", "ui"}}
+	if !reflect.DeepEqual(created, want) {
+		t.Errorf("created = %+v, want %+v", created, want)
+	}
+
+	got, err := p.Get(ctx, "secret", "owner/repo", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got = %+v, want %+v", got, want)
+	}
+
+	updated, err := p.Update(ctx, "secret", "owner/repo", 1, UpdateIssueInput{Title: "Bug!", Labels: []string{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Title != "Bug!" || updated.Body != "broken" || len(updated.Labels) != 0 {
+		t.Errorf("updated = %+v, want the new title, the old body and no labels", updated)
+	}
+}
+
+func TestGiteaProvider_CloseAndReopen(t *testing.T) {
+	p := NewGiteaProvider(fakeGitea(t).URL)
+	ctx := context.Background()
+
+	if _, err := p.Create(ctx, "secret", CreateIssueInput{Repo: "owner/repo", Title: "Bug"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Close(ctx, "secret", "owner/repo", 1); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := p.Get(ctx, "secret", "owner/repo", 1); got.State != "closed" {
+		t.Errorf("state after Close = %q, want %q", got.State, "closed")
+	}
+	if err := p.Reopen(ctx, "secret", "owner/repo", 1); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := p.Get(ctx, "secret", "owner/repo", 1); got.State != "open" {
+		t.Errorf("state after Reopen = %q, want %q", got.State, "open")
+	}
+}
+
+func TestGiteaProvider_RejectsBadToken(t *testing.T) {
+	p := NewGiteaProvider(fakeGitea(t).URL)
+	if _, err := p.Get(context.Background(), "wrong", "owner/repo", 1); err == nil {
+		t.Error("Get with a bad token succeeded, want an error")
+	}
+}
+
+func TestGiteaProvider_GetMissingIssueIsNotFound(t *testing.T) {
+	p := NewGiteaProvider(fakeGitea(t).URL)
+	if _, err := p.Get(context.Background(), "secret", "owner/repo", 2); !errors.Is(err, ErrIssueNotFound) {
+		t.Errorf("err = %v, want ErrIssueNotFound", err)
+	}
+	if _, err := p.Get(context.Background(), "wrong", "owner/repo", 1); errors.Is(err, ErrIssueNotFound) {
+		t.Errorf("err = %v, want an error other than ErrIssueNotFound", err)
+	}
+}
+
+func TestGiteaProvider_CreateWithUnknownLabelFails(t *testing.T) {
+	p := NewGiteaProvider(fakeGitea(t).URL)
+	_, err := p.Create(context.Background(), "secret", CreateIssueInput{Repo: "owner/repo", Title: "Bug", Labels: []string{"nope"}})
+	if err == nil {
+		t.Error("Create with a label the repository lacks succeeded, want an error")
+	}
+}
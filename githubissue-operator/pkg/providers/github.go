@@ -18,26 +18,90 @@ package providers
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"strings"
+	"time"
 
+	"github.com/bradleyfalzon/ghinstallation/v2"
 	"github.com/google/go-github/v57/github"
 	"golang.org/x/oauth2"
 )
 
 // GitHubProvider implements IssueProvider for GitHub
-type GitHubProvider struct{}
+type GitHubProvider struct {
+	// EnterpriseBaseURL is the API host of a GitHub Enterprise Server, e.g.
+	// "https://github.example.com"; empty means github.com
+	EnterpriseBaseURL string
+	// EnterpriseUploadURL is the upload host of the GitHub Enterprise Server;
+	// empty means EnterpriseBaseURL
+	EnterpriseUploadURL string
+
+	// clients reuses the client built for a token across calls; with App
+	// credentials that also reuses the installation token until it expires
+	clients clientCache
+}
 
 // NewGitHubProvider creates a new GitHubProvider
 func NewGitHubProvider() *GitHubProvider {
 	return &GitHubProvider{}
 }
 
-// newClient creates an authenticated GitHub client
-func (p *GitHubProvider) newClient(ctx context.Context, token string) *github.Client {
-	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token})
-	tc := oauth2.NewClient(ctx, ts)
-	return github.NewClient(tc)
+// NewGitHubEnterpriseProvider creates a GitHubProvider for the GitHub
+// Enterprise Server at baseURL, uploading to uploadURL or, if it is empty,
+// baseURL. The /api/v3/ and /api/uploads/ paths are added when missing.
+func NewGitHubEnterpriseProvider(baseURL, uploadURL string) *GitHubProvider {
+	return &GitHubProvider{EnterpriseBaseURL: baseURL, EnterpriseUploadURL: uploadURL}
+}
+
+// newClient returns the authenticated GitHub client for token, building it on
+// first use. The client outlives ctx, so ctx only supplies the underlying
+// HTTP client, if it carries one (see oauth2.HTTPClient).
+func (p *GitHubProvider) newClient(ctx context.Context, token string) (*github.Client, error) {
+	return p.clients.get(token, func() (*github.Client, error) {
+		return p.buildClient(ctx, token)
+	})
+}
+
+// buildClient creates a GitHub client authenticated with token, which is
+// either a personal access token or AppCredentials encoded by their Token
+// method.
+func (p *GitHubProvider) buildClient(ctx context.Context, token string) (*github.Client, error) {
+	app, err := parseAppCredentials(token)
+	if err != nil {
+		return nil, err
+	}
+	var httpClient *http.Client
+	var appTransport *ghinstallation.Transport
+	if app != nil {
+		appTransport, err = ghinstallation.New(http.DefaultTransport, app.AppID, app.InstallationID, app.PrivateKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid GitHub App credentials: %w", err)
+		}
+		httpClient = &http.Client{Transport: appTransport}
+	} else {
+		httpClient = oauth2.NewClient(ctx, oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token}))
+	}
+	// The client outlives ctx, so the quota goes to the observer of each request
+	httpClient = &http.Client{Transport: rateTransport{base: httpClient.Transport}, Timeout: httpClient.Timeout}
+
+	if p.EnterpriseBaseURL == "" {
+		return github.NewClient(httpClient), nil
+	}
+	uploadURL := p.EnterpriseUploadURL
+	if uploadURL == "" {
+		uploadURL = p.EnterpriseBaseURL
+	}
+	client, err := github.NewEnterpriseClient(p.EnterpriseBaseURL, uploadURL, httpClient)
+	if err != nil {
+		return nil, fmt.Errorf("invalid GitHub Enterprise URL: %w", err)
+	}
+	if appTransport != nil {
+		// Installation tokens are minted by the Enterprise server as well
+		appTransport.BaseURL = strings.TrimSuffix(client.BaseURL.String(), "/")
+	}
+	return client, nil
 }
 
 // parseRepo splits "owner/repo" into owner and repo parts
@@ -56,7 +120,10 @@ func (p *GitHubProvider) Create(ctx context.Context, token string, input CreateI
 		return nil, err
 	}
 
-	client := p.newClient(ctx, token)
+	client, err := p.newClient(ctx, token)
+	if err != nil {
+		return nil, err
+	}
 
 	issueRequest := &github.IssueRequest{
 		Title: github.String(input.Title),
@@ -65,20 +132,23 @@ func (p *GitHubProvider) Create(ctx context.Context, token string, input CreateI
 	if len(input.Labels) > 0 {
 		issueRequest.Labels = &input.Labels
 	}
+	if len(input.Assignees) > 0 {
+		issueRequest.Assignees = &input.Assignees
+	}
+	if input.Milestone != "" {
+		number, err := milestoneNumber(ctx, client, owner, repo, input.Milestone)
+		if err != nil {
+			return nil, err
+		}
+		issueRequest.Milestone = &number
+	}
 
 	ghIssue, _, err := client.Issues.Create(ctx, owner, repo, issueRequest)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create GitHub issue: %w", err)
+		return nil, githubError(err, "create GitHub issue")
 	}
 
-	return &Issue{
-		Number: ghIssue.GetNumber(),
-		URL:    ghIssue.GetHTMLURL(),
-		State:  ghIssue.GetState(),
-		Title:  ghIssue.GetTitle(),
-		Body:   ghIssue.GetBody(),
-		Labels: extractLabels(ghIssue.Labels),
-	}, nil
+	return toIssue(ghIssue), nil
 }
 
 // Get retrieves an existing GitHub issue
@@ -88,21 +158,96 @@ func (p *GitHubProvider) Get(ctx context.Context, token string, repoStr string,
 		return nil, err
 	}
 
-	client := p.newClient(ctx, token)
+	client, err := p.newClient(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	ghIssue, resp, err := client.Issues.Get(ctx, owner, repo, issueNumber)
+	if err != nil {
+		// GitHub answers 410 Gone for deleted issues, and 404 when there never was one
+		if resp != nil && (resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone) {
+			err = fmt.Errorf("%w: %w", ErrIssueNotFound, err)
+		}
+		return nil, githubError(err, "get GitHub issue")
+	}
 
-	ghIssue, _, err := client.Issues.Get(ctx, owner, repo, issueNumber)
+	return toIssue(ghIssue), nil
+}
+
+// List returns the GitHub issues in a repo matching opts, following pagination.
+// The issues API also returns pull requests; those are skipped.
+func (p *GitHubProvider) List(ctx context.Context, token string, repoStr string, opts ListIssuesOptions) ([]*Issue, error) {
+	owner, repo, err := parseRepo(repoStr)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get GitHub issue: %w", err)
+		return nil, err
 	}
 
-	return &Issue{
-		Number: ghIssue.GetNumber(),
-		URL:    ghIssue.GetHTMLURL(),
-		State:  ghIssue.GetState(),
-		Title:  ghIssue.GetTitle(),
-		Body:   ghIssue.GetBody(),
-		Labels: extractLabels(ghIssue.Labels),
-	}, nil
+	client, err := p.newClient(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	state := opts.State
+	if state == "" {
+		state = "all"
+	}
+	listOpts := &github.IssueListByRepoOptions{
+		State:       state,
+		Labels:      opts.Labels,
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+
+	var result []*Issue
+	for {
+		ghIssues, resp, err := client.Issues.ListByRepo(ctx, owner, repo, listOpts)
+		if err != nil {
+			return nil, githubError(err, "list GitHub issues")
+		}
+		for _, ghIssue := range ghIssues {
+			if ghIssue.IsPullRequest() {
+				continue
+			}
+			result = append(result, toIssue(ghIssue))
+		}
+		if resp.NextPage == 0 {
+			return result, nil
+		}
+		listOpts.Page = resp.NextPage
+	}
+}
+
+// Search returns the GitHub issues matching query, following pagination.
+// Pull requests are skipped. The search API has its own rate limit, far lower
+// than the one for other calls; once it is exhausted, including between
+// pages, a *RateLimitError tells the caller when to try again.
+func (p *GitHubProvider) Search(ctx context.Context, token string, query string) ([]*Issue, error) {
+	client, err := p.newClient(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := &github.SearchOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	var result []*Issue
+	for {
+		found, resp, err := client.Search.Issues(ctx, query, opts)
+		if err != nil {
+			return nil, githubError(err, "search GitHub issues")
+		}
+		for _, ghIssue := range found.Issues {
+			if ghIssue.IsPullRequest() {
+				continue
+			}
+			result = append(result, toIssue(ghIssue))
+		}
+		if resp.NextPage == 0 {
+			return result, nil
+		}
+		if resp.Rate.Remaining == 0 {
+			return nil, &RateLimitError{Reset: resp.Rate.Reset.Time}
+		}
+		opts.Page = resp.NextPage
+	}
 }
 
 // Update updates an existing GitHub issue
@@ -112,7 +257,10 @@ func (p *GitHubProvider) Update(ctx context.Context, token string, repoStr strin
 		return nil, err
 	}
 
-	client := p.newClient(ctx, token)
+	client, err := p.newClient(ctx, token)
+	if err != nil {
+		return nil, err
+	}
 
 	issueRequest := &github.IssueRequest{}
 	if input.Title != "" {
@@ -124,20 +272,57 @@ func (p *GitHubProvider) Update(ctx context.Context, token string, repoStr strin
 	if input.Labels != nil {
 		issueRequest.Labels = &input.Labels
 	}
+	if input.Assignees != nil {
+		issueRequest.Assignees = &input.Assignees
+	}
+	if input.Milestone != "" {
+		number, err := milestoneNumber(ctx, client, owner, repo, input.Milestone)
+		if err != nil {
+			return nil, err
+		}
+		issueRequest.Milestone = &number
+	}
+	if input.State != "" {
+		issueRequest.State = github.String(input.State)
+	}
+	if input.StateReason != "" {
+		issueRequest.StateReason = github.String(input.StateReason)
+	}
 
 	ghIssue, _, err := client.Issues.Edit(ctx, owner, repo, issueNumber, issueRequest)
 	if err != nil {
-		return nil, fmt.Errorf("failed to update GitHub issue: %w", err)
+		return nil, githubError(err, "update GitHub issue")
 	}
 
-	return &Issue{
-		Number: ghIssue.GetNumber(),
-		URL:    ghIssue.GetHTMLURL(),
-		State:  ghIssue.GetState(),
-		Title:  ghIssue.GetTitle(),
-		Body:   ghIssue.GetBody(),
-		Labels: extractLabels(ghIssue.Labels),
-	}, nil
+	return toIssue(ghIssue), nil
+}
+
+// milestoneNumber returns the number of the milestone titled title, open or
+// closed, creating it when the repository has none by that title. Issues
+// refer to milestones by number only.
+func milestoneNumber(ctx context.Context, client *github.Client, owner, repo, title string) (int, error) {
+	opts := &github.MilestoneListOptions{State: "all", ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		milestones, resp, err := client.Issues.ListMilestones(ctx, owner, repo, opts)
+		if err != nil {
+			return 0, githubError(err, "list GitHub milestones")
+		}
+		for _, milestone := range milestones {
+			if milestone.GetTitle() == title {
+				return milestone.GetNumber(), nil
+			}
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	created, _, err := client.Issues.CreateMilestone(ctx, owner, repo, &github.Milestone{Title: &title})
+	if err != nil {
+		return 0, githubError(err, fmt.Sprintf("create GitHub milestone %q", title))
+	}
+	return created.GetNumber(), nil
 }
 
 // Close closes a GitHub issue
@@ -147,14 +332,17 @@ func (p *GitHubProvider) Close(ctx context.Context, token string, repoStr string
 		return err
 	}
 
-	client := p.newClient(ctx, token)
+	client, err := p.newClient(ctx, token)
+	if err != nil {
+		return err
+	}
 
 	state := "closed"
 	_, _, err = client.Issues.Edit(ctx, owner, repo, issueNumber, &github.IssueRequest{
 		State: &state,
 	})
 	if err != nil {
-		return fmt.Errorf("failed to close GitHub issue: %w", err)
+		return githubError(err, "close GitHub issue")
 	}
 
 	return nil
@@ -167,19 +355,277 @@ func (p *GitHubProvider) Reopen(ctx context.Context, token string, repoStr strin
 		return err
 	}
 
-	client := p.newClient(ctx, token)
+	client, err := p.newClient(ctx, token)
+	if err != nil {
+		return err
+	}
 
 	state := "open"
 	_, _, err = client.Issues.Edit(ctx, owner, repo, issueNumber, &github.IssueRequest{
 		State: &state,
 	})
 	if err != nil {
-		return fmt.Errorf("failed to reopen GitHub issue: %w", err)
+		return githubError(err, "reopen GitHub issue")
 	}
 
 	return nil
 }
 
+// AddComment posts a comment on a GitHub issue
+func (p *GitHubProvider) AddComment(ctx context.Context, token string, repoStr string, issueNumber int, body string) (*Comment, error) {
+	owner, repo, err := parseRepo(repoStr)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := p.newClient(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	ghComment, _, err := client.Issues.CreateComment(ctx, owner, repo, issueNumber, &github.IssueComment{
+		Body: &body,
+	})
+	if err != nil {
+		return nil, githubError(err, "comment on GitHub issue")
+	}
+
+	return &Comment{ID: ghComment.GetID(), Body: ghComment.GetBody()}, nil
+}
+
+// ListComments returns the comments on a GitHub issue, following pagination
+func (p *GitHubProvider) ListComments(ctx context.Context, token string, repoStr string, issueNumber int) ([]*Comment, error) {
+	owner, repo, err := parseRepo(repoStr)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := p.newClient(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := &github.IssueListCommentsOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	var result []*Comment
+	for {
+		ghComments, resp, err := client.Issues.ListComments(ctx, owner, repo, issueNumber, opts)
+		if err != nil {
+			return nil, githubError(err, "list GitHub issue comments")
+		}
+		for _, ghComment := range ghComments {
+			result = append(result, &Comment{ID: ghComment.GetID(), Body: ghComment.GetBody()})
+		}
+		if resp.NextPage == 0 {
+			return result, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// Lock locks the conversation of a GitHub issue as resolved
+func (p *GitHubProvider) Lock(ctx context.Context, token string, repoStr string, issueNumber int) error {
+	owner, repo, err := parseRepo(repoStr)
+	if err != nil {
+		return err
+	}
+
+	client, err := p.newClient(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.Issues.Lock(ctx, owner, repo, issueNumber, &github.LockIssueOptions{
+		LockReason: "resolved",
+	})
+	if err != nil {
+		return githubError(err, "lock GitHub issue")
+	}
+
+	return nil
+}
+
+// EnsureLabels creates the missing labels of a repository and restyles the
+// others. GitHub matches label names case-insensitively.
+func (p *GitHubProvider) EnsureLabels(ctx context.Context, token string, repoStr string, labels []LabelSpec) error {
+	owner, repo, err := parseRepo(repoStr)
+	if err != nil {
+		return err
+	}
+
+	client, err := p.newClient(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	existing := make(map[string]*github.Label)
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		page, resp, err := client.Issues.ListLabels(ctx, owner, repo, opts)
+		if err != nil {
+			return githubError(err, "list GitHub labels")
+		}
+		for _, label := range page {
+			existing[strings.ToLower(label.GetName())] = label
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	for _, spec := range labels {
+		want := &github.Label{
+			Name:        github.String(spec.Name),
+			Color:       github.String(strings.ToLower(spec.Color)),
+			Description: github.String(spec.Description),
+		}
+		current, ok := existing[strings.ToLower(spec.Name)]
+		if !ok {
+			if _, _, err := client.Issues.CreateLabel(ctx, owner, repo, want); err != nil {
+				return githubError(err, fmt.Sprintf("create GitHub label %q", spec.Name))
+			}
+			continue
+		}
+		if strings.EqualFold(current.GetColor(), spec.Color) && current.GetDescription() == spec.Description {
+			continue
+		}
+		if _, _, err := client.Issues.EditLabel(ctx, owner, repo, current.GetName(), want); err != nil {
+			return githubError(err, fmt.Sprintf("update GitHub label %q", spec.Name))
+		}
+	}
+	return nil
+}
+
+// transferIssueMutation is the GraphQL mutation behind Transfer; the REST API
+// has no equivalent.
+const transferIssueMutation = `mutation($issueId: ID!, $repositoryId: ID!) {
+  transferIssue(input: {issueId: $issueId, repositoryId: $repositoryId}) {
+    issue { number }
+  }
+}`
+
+// graphQLPath returns the GraphQL endpoint relative to the client's BaseURL.
+// GitHub Enterprise Server serves it at /api/graphql, beside /api/v3/.
+func (p *GitHubProvider) graphQLPath() string {
+	if p.EnterpriseBaseURL != "" {
+		return "../graphql"
+	}
+	return "graphql"
+}
+
+// Transfer moves a GitHub issue to another repository
+func (p *GitHubProvider) Transfer(ctx context.Context, token string, fromRepo string, issueNumber int, toRepo string) (*Issue, error) {
+	owner, repo, err := parseRepo(fromRepo)
+	if err != nil {
+		return nil, err
+	}
+	toOwner, toName, err := parseRepo(toRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := p.newClient(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	// The mutation takes GraphQL node IDs rather than names and numbers
+	ghIssue, _, err := client.Issues.Get(ctx, owner, repo, issueNumber)
+	if err != nil {
+		return nil, githubError(err, "get GitHub issue")
+	}
+	target, _, err := client.Repositories.Get(ctx, toOwner, toName)
+	if err != nil {
+		return nil, githubError(err, "get target repository")
+	}
+
+	req, err := client.NewRequest(http.MethodPost, p.graphQLPath(), map[string]any{
+		"query": transferIssueMutation,
+		"variables": map[string]string{
+			"issueId":      ghIssue.GetNodeID(),
+			"repositoryId": target.GetNodeID(),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	var resp struct {
+		Data struct {
+			TransferIssue struct {
+				Issue struct {
+					Number int `json:"number"`
+				} `json:"issue"`
+			} `json:"transferIssue"`
+		} `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if _, err := client.Do(ctx, req, &resp); err != nil {
+		return nil, githubError(err, "transfer GitHub issue")
+	}
+	if len(resp.Errors) > 0 {
+		return nil, fmt.Errorf("failed to transfer GitHub issue: %s", resp.Errors[0].Message)
+	}
+
+	return p.Get(ctx, token, toRepo, resp.Data.TransferIssue.Issue.Number)
+}
+
+// Capabilities reports comment, transfer, assignee, lock and milestone
+// support. Pinning and deleting issues are not implemented yet, nor are
+// projects.
+func (p *GitHubProvider) Capabilities() ProviderCapabilities {
+	return ProviderCapabilities{Comments: true, Transfer: true, Assignees: true, Lock: true, Milestones: true, LabelAppearance: true}
+}
+
+// githubError turns GitHub's primary and secondary rate limit errors into a
+// *RateLimitError, so callers can wait for the reset instead of retrying
+// blindly, and wraps any other error with the failed action.
+func githubError(err error, action string) error {
+	var rateErr *github.RateLimitError
+	if errors.As(err, &rateErr) {
+		return &RateLimitError{Reset: rateErr.Rate.Reset.Time}
+	}
+	var abuseErr *github.AbuseRateLimitError
+	if errors.As(err, &abuseErr) {
+		retryAfter := time.Minute
+		if abuseErr.RetryAfter != nil {
+			retryAfter = *abuseErr.RetryAfter
+		}
+		return &RateLimitError{Reset: time.Now().Add(retryAfter)}
+	}
+	return fmt.Errorf("failed to %s: %w", action, err)
+}
+
+// toIssue converts a GitHub issue to the provider-neutral Issue
+func toIssue(ghIssue *github.Issue) *Issue {
+	return &Issue{
+		Number:      ghIssue.GetNumber(),
+		URL:         ghIssue.GetHTMLURL(),
+		State:       ghIssue.GetState(),
+		StateReason: ghIssue.GetStateReason(),
+		Title:       ghIssue.GetTitle(),
+		Body:        ghIssue.GetBody(),
+		Labels:      SortedLabels(extractLabels(ghIssue.Labels)),
+		Assignees:   SortedLabels(extractLogins(ghIssue.Assignees)),
+		Locked:      ghIssue.GetLocked(),
+		Milestone:   ghIssue.GetMilestone().GetTitle(),
+		Author:      ghIssue.GetUser().GetLogin(),
+		CreatedAt:   ghIssue.GetCreatedAt().Time,
+	}
+}
+
+// extractLogins extracts the logins of GitHub users
+func extractLogins(users []*github.User) []string {
+	result := make([]string, 0, len(users))
+	for _, user := range users {
+		if user.Login != nil {
+			result = append(result, *user.Login)
+		}
+	}
+	return result
+}
+
 // extractLabels extracts label names from GitHub label objects
 func extractLabels(labels []*github.Label) []string {
 	result := make([]string, 0, len(labels))
@@ -0,0 +1,438 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package providers
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/bradleyfalzon/ghinstallation/v2"
+)
+
+func TestGitHubProvider_NewClientTargetsEnterpriseHost(t *testing.T) {
+	tests := []struct {
+		name       string
+		provider   *GitHubProvider
+		wantBase   string
+		wantUpload string
+	}{
+		{"github.com", NewGitHubProvider(), "https://api.github.com/", "https://uploads.github.com/"},
+		{"enterprise", NewGitHubEnterpriseProvider("https://github.example.com", ""),
+			"https://github.example.com/api/v3/", "https://github.example.com/api/uploads/"},
+		{"enterprise with upload host", NewGitHubEnterpriseProvider("https://github.example.com/api/v3/", "https://uploads.example.com"),
+			"https://github.example.com/api/v3/", "https://uploads.example.com/api/uploads/"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := tt.provider.newClient(context.Background(), "token")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := client.BaseURL.String(); got != tt.wantBase {
+				t.Errorf("BaseURL = %q, want %q", got, tt.wantBase)
+			}
+			if got := client.UploadURL.String(); got != tt.wantUpload {
+				t.Errorf("UploadURL = %q, want %q", got, tt.wantUpload)
+			}
+		})
+	}
+}
+
+func TestGitHubProvider_RateLimitErrors(t *testing.T) {
+	reset := time.Now().Add(42 * time.Minute).Truncate(time.Second)
+	tests := []struct {
+		name      string
+		header    map[string]string
+		body      string
+		wantReset time.Time
+	}{
+		{
+			name: "primary rate limit",
+			header: map[string]string{
+				"X-RateLimit-Limit":     "5000",
+				"X-RateLimit-Remaining": "0",
+				"X-RateLimit-Reset":     strconv.FormatInt(reset.Unix(), 10),
+			},
+			body:      `{"message": "API rate limit exceeded"}`,
+			wantReset: reset,
+		},
+		{
+			name:      "secondary rate limit",
+			header:    map[string]string{"Retry-After": "90"},
+			body:      `{"message": "You have exceeded a secondary rate limit", "documentation_url": "https://docs.github.com/rest/overview/resources-in-the-rest-api#secondary-rate-limits"}`,
+			wantReset: time.Now().Add(90 * time.Second),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				for k, v := range tt.header {
+					w.Header().Set(k, v)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			_, err := NewGitHubEnterpriseProvider(srv.URL, "").Get(context.Background(), "token", "owner/repo", 1)
+			var rateErr *RateLimitError
+			if !errors.As(err, &rateErr) {
+				t.Fatalf("Get() error = %v, want a *RateLimitError", err)
+			}
+			if d := rateErr.Reset.Sub(tt.wantReset).Abs(); d > 5*time.Second {
+				t.Errorf("Reset = %v, want %v", rateErr.Reset, tt.wantReset)
+			}
+			wait, ok := RetryAfter(fmt.Errorf("failed to sync: %w", err), time.Now())
+			if !ok || wait <= 0 {
+				t.Errorf("RetryAfter() = %v, %v, want a positive wait", wait, ok)
+			}
+		})
+	}
+}
+
+func TestGitHubProvider_OtherErrorsAreNotRateLimits(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"message": "Resource not accessible by integration"}`))
+	}))
+	defer srv.Close()
+
+	_, err := NewGitHubEnterpriseProvider(srv.URL, "").Get(context.Background(), "token", "owner/repo", 1)
+	if err == nil {
+		t.Fatal("Get() succeeded, want an error")
+	}
+	if _, ok := RetryAfter(err, time.Now()); ok {
+		t.Errorf("RetryAfter(%v) reported a rate limit", err)
+	}
+}
+
+func TestGitHubProvider_NewClientIsCachedPerToken(t *testing.T) {
+	p := NewGitHubProvider()
+	ctx := context.Background()
+	newClient := func(token string) any {
+		t.Helper()
+		client, err := p.newClient(ctx, token)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return client
+	}
+
+	first := newClient("token-a")
+	if newClient("token-a") != first {
+		t.Error("the same token should reuse its client")
+	}
+	if newClient("token-b") == first {
+		t.Error("another token should get its own client")
+	}
+
+	// Fill the cache with other tokens; token-a is used again halfway, so
+	// token-b is evicted and token-a is not
+	for i := range maxCachedClients {
+		if i == maxCachedClients/2 {
+			newClient("token-a")
+		}
+		newClient(fmt.Sprintf("rotated-%d", i))
+	}
+	if len(p.clients.clients) != maxCachedClients {
+		t.Errorf("cache holds %d clients, want at most %d", len(p.clients.clients), maxCachedClients)
+	}
+	if newClient("token-a") != first {
+		t.Error("a recently used client should not be evicted")
+	}
+}
+
+func BenchmarkGitHubProvider_NewClient(b *testing.B) {
+	ctx := context.Background()
+	b.Run("cached", func(b *testing.B) {
+		p := NewGitHubProvider()
+		for range b.N {
+			if _, err := p.newClient(ctx, "token"); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("uncached", func(b *testing.B) {
+		p := NewGitHubProvider()
+		for range b.N {
+			if _, err := p.buildClient(ctx, "token"); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func TestGitHubProvider_GetDeletedIssueIsNotFound(t *testing.T) {
+	tests := []struct {
+		status       int
+		wantNotFound bool
+	}{
+		{http.StatusGone, true},
+		{http.StatusNotFound, true},
+		{http.StatusBadGateway, false},
+	}
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(`{"message": "This issue was deleted"}`))
+			}))
+			defer srv.Close()
+
+			_, err := NewGitHubEnterpriseProvider(srv.URL, "").Get(context.Background(), "token", "owner/repo", 1)
+			if err == nil {
+				t.Fatal("Get() succeeded, want an error")
+			}
+			if got := errors.Is(err, ErrIssueNotFound); got != tt.wantNotFound {
+				t.Errorf("errors.Is(%v, ErrIssueNotFound) = %v, want %v", err, got, tt.wantNotFound)
+			}
+		})
+	}
+}
+
+func TestGitHubProvider_GetReportsAuthorAndCreatedAt(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"number": 1, "state": "open", "user": {"login": "octocat"}, "created_at": "2026-01-02T03:04:05Z"}`))
+	}))
+	defer srv.Close()
+
+	issue, err := NewGitHubEnterpriseProvider(srv.URL, "").Get(context.Background(), "token", "owner/repo", 1)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if issue.Author != "octocat" {
+		t.Errorf("Issue.Author = %q, want octocat", issue.Author)
+	}
+	if want := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC); !issue.CreatedAt.Equal(want) {
+		t.Errorf("Issue.CreatedAt = %v, want %v", issue.CreatedAt, want)
+	}
+}
+
+func TestGitHubProvider_ReportsRemainingQuota(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-RateLimit-Remaining", "42")
+		w.Header().Set("X-RateLimit-Reset", "1767322800")
+		_, _ = w.Write([]byte(`{"number": 1, "state": "open"}`))
+	}))
+	defer srv.Close()
+
+	var remaining int
+	var reset time.Time
+	ctx := WithRateObserver(context.Background(), func(r int, t time.Time) {
+		remaining, reset = r, t
+	})
+	if _, err := NewGitHubEnterpriseProvider(srv.URL, "").Get(ctx, "token", "owner/repo", 1); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if remaining != 42 {
+		t.Errorf("remaining = %d, want 42", remaining)
+	}
+	if want := time.Unix(1767322800, 0); !reset.Equal(want) {
+		t.Errorf("reset = %v, want %v", reset, want)
+	}
+}
+
+func TestGitHubProvider_UpdateSetsAssignees(t *testing.T) {
+	var sent struct {
+		Assignees []string `json:"assignees"`
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&sent); err != nil {
+			t.Errorf("decoding request: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"number": 1, "state": "open", "assignees": [{"login": "oncall-b"}, {"login": "oncall-a"}]}`))
+	}))
+	defer srv.Close()
+
+	issue, err := NewGitHubEnterpriseProvider(srv.URL, "").Update(context.Background(), "token", "owner/repo", 1,
+		UpdateIssueInput{Assignees: []string{"oncall-b", "oncall-a"}})
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if fmt.Sprint(sent.Assignees) != "[oncall-b oncall-a]" {
+		t.Errorf("sent assignees %v, want [oncall-b oncall-a]", sent.Assignees)
+	}
+	if fmt.Sprint(issue.Assignees) != "[oncall-a oncall-b]" {
+		t.Errorf("Issue.Assignees = %v, want [oncall-a oncall-b]", issue.Assignees)
+	}
+}
+
+func TestGitHubProvider_CreateResolvesMilestone(t *testing.T) {
+	tests := []struct {
+		name          string
+		milestones    string
+		wantNumber    int
+		wantMilestone bool
+	}{
+		{"existing milestone", `[{"number": 3, "title": "v1.1"}, {"number": 4, "title": "v1.2", "state": "closed"}]`, 4, false},
+		{"missing milestone", `[{"number": 3, "title": "v1.1"}]`, 5, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var createdMilestone bool
+			var sent struct {
+				Milestone int `json:"milestone"`
+			}
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch r.Method + " " + r.URL.Path {
+				case "GET /api/v3/repos/owner/repo/milestones":
+					_, _ = w.Write([]byte(tt.milestones))
+				case "POST /api/v3/repos/owner/repo/milestones":
+					createdMilestone = true
+					_, _ = w.Write([]byte(`{"number": 5, "title": "v1.2"}`))
+				case "POST /api/v3/repos/owner/repo/issues":
+					if err := json.NewDecoder(r.Body).Decode(&sent); err != nil {
+						t.Errorf("decoding request: %v", err)
+					}
+					_, _ = w.Write([]byte(fmt.Sprintf(`{"number": 1, "state": "open", "milestone": {"number": %d, "title": "v1.2"}}`, sent.Milestone)))
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer srv.Close()
+
+			issue, err := NewGitHubEnterpriseProvider(srv.URL, "").Create(context.Background(), "token",
+				CreateIssueInput{Repo: "owner/repo", Title: "Test Issue", Milestone: "v1.2"})
+			if err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+			if sent.Milestone != tt.wantNumber {
+				t.Errorf("sent milestone %d, want %d", sent.Milestone, tt.wantNumber)
+			}
+			if createdMilestone != tt.wantMilestone {
+				t.Errorf("milestone created = %v, want %v", createdMilestone, tt.wantMilestone)
+			}
+			if issue.Milestone != "v1.2" {
+				t.Errorf("Issue.Milestone = %q, want %q", issue.Milestone, "v1.2")
+			}
+		})
+	}
+}
+
+func TestGitHubProvider_EnsureLabels(t *testing.T) {
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/v3/repos/owner/repo/labels":
+			_, _ = w.Write([]byte(`[
+				{"name": "Bug", "color": "d73a4a", "description": "Something is broken"},
+				{"name": "triage", "color": "ededed", "description": ""}
+			]`))
+			return
+		case "POST /api/v3/repos/owner/repo/labels":
+			_, _ = w.Write([]byte(`{"name": "operator"}`))
+		case "PATCH /api/v3/repos/owner/repo/labels/triage":
+			_, _ = w.Write([]byte(`{"name": "triage"}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, r.Method+" "+string(body))
+	}))
+	defer srv.Close()
+
+	err := NewGitHubEnterpriseProvider(srv.URL, "").EnsureLabels(context.Background(), "token", "owner/repo", []LabelSpec{
+		{Name: "bug", Color: "D73A4A", Description: "Something is broken"},
+		{Name: "triage", Color: "fbca04", Description: "Needs a look"},
+		{Name: "operator", Color: "0e8a16"},
+	})
+	if err != nil {
+		t.Fatalf("EnsureLabels() error = %v", err)
+	}
+	want := []string{
+		`PATCH {"name":"triage","color":"fbca04","description":"Needs a look"}` + "\n",
+		`POST {"name":"operator","color":"0e8a16","description":""}` + "\n",
+	}
+	if !slices.Equal(requests, want) {
+		t.Errorf("requests = %q, want %q", requests, want)
+	}
+}
+
+func TestGitHubProvider_AppCredentialsInstallTransport(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	creds := AppCredentials{
+		AppID:          1234,
+		InstallationID: 5678,
+		PrivateKey:     pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}),
+	}
+
+	tests := []struct {
+		name        string
+		provider    *GitHubProvider
+		wantBaseURL string
+	}{
+		{"github.com", NewGitHubProvider(), "https://api.github.com"},
+		{"enterprise", NewGitHubEnterpriseProvider("https://github.example.com", ""), "https://github.example.com/api/v3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := tt.provider.newClient(context.Background(), creds.Token())
+			if err != nil {
+				t.Fatalf("newClient() error = %v", err)
+			}
+			rate, ok := client.Client().Transport.(rateTransport)
+			if !ok {
+				t.Fatalf("transport is %T, want rateTransport", client.Client().Transport)
+			}
+			transport, ok := rate.base.(*ghinstallation.Transport)
+			if !ok {
+				t.Fatalf("rateTransport wraps %T, want *ghinstallation.Transport", rate.base)
+			}
+			if transport.BaseURL != tt.wantBaseURL {
+				t.Errorf("installation token BaseURL = %q, want %q", transport.BaseURL, tt.wantBaseURL)
+			}
+			appID, installationID := transport.AppID(), transport.InstallationID()
+			if appID != creds.AppID || installationID != creds.InstallationID {
+				t.Errorf("transport for app %d installation %d, want %d and %d", appID, installationID, creds.AppID, creds.InstallationID)
+			}
+		})
+	}
+}
+
+func TestGitHubProvider_InvalidAppCredentials(t *testing.T) {
+	creds := AppCredentials{AppID: 1234, InstallationID: 5678, PrivateKey: []byte("not a key")}
+	if _, err := NewGitHubProvider().newClient(context.Background(), creds.Token()); err == nil {
+		t.Error("newClient() succeeded with an invalid private key")
+	}
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package providers
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// appCredentialsPrefix marks a token that carries GitHub App credentials
+// rather than a personal access token.
+const appCredentialsPrefix = "github-app:"
+
+// AppCredentials authenticate as a GitHub App installation. GitHubProvider
+// mints short-lived installation tokens from them and renews them as they
+// expire. They are passed wherever a token is expected, encoded by Token, so
+// the IssueProvider methods are the same for both kinds of credentials.
+type AppCredentials struct {
+	// AppID is the ID of the GitHub App
+	AppID int64
+	// InstallationID is the ID of the App's installation on the owner of the repos
+	InstallationID int64
+	// PrivateKey is the App's PEM-encoded private key
+	PrivateKey []byte
+}
+
+// Token encodes c as a token for the IssueProvider methods. Only
+// GitHubProvider understands it.
+func (c AppCredentials) Token() string {
+	return fmt.Sprintf("%s%d:%d:%s", appCredentialsPrefix, c.AppID, c.InstallationID,
+		base64.StdEncoding.EncodeToString(c.PrivateKey))
+}
+
+// parseAppCredentials decodes a token made by AppCredentials.Token. It
+// returns nil for any other token, such as a personal access token.
+func parseAppCredentials(token string) (*AppCredentials, error) {
+	encoded, ok := strings.CutPrefix(token, appCredentialsPrefix)
+	if !ok {
+		return nil, nil
+	}
+	parts := strings.SplitN(encoded, ":", 3)
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("malformed GitHub App credentials")
+	}
+	appID, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("malformed GitHub App ID: %w", err)
+	}
+	installationID, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("malformed GitHub App installation ID: %w", err)
+	}
+	key, err := base64.StdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("malformed GitHub App private key: %w", err)
+	}
+	return &AppCredentials{AppID: appID, InstallationID: installationID, PrivateKey: key}, nil
+}
@@ -0,0 +1,307 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package providers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+// DefaultGitLabURL is the GitLab instance used when none is configured
+const DefaultGitLabURL = "https://gitlab.com"
+
+// GitLabProvider implements IssueProvider for GitLab through its REST API.
+// The repo of an issue is the project path, e.g. "group/project" or
+// "group/subgroup/project", and issue numbers are project-scoped IIDs.
+// GitLab does not record why an issue was closed, so StateReason is always empty.
+type GitLabProvider struct {
+	// BaseURL is the GitLab instance, e.g. "https://gitlab.example.com"
+	BaseURL string
+	// HTTPClient sends the requests; nil means go-gitlab's pooled client
+	HTTPClient *http.Client
+}
+
+// NewGitLabProvider creates a GitLabProvider for the instance at baseURL,
+// or gitlab.com if it is empty
+func NewGitLabProvider(baseURL string) *GitLabProvider {
+	if baseURL == "" {
+		baseURL = DefaultGitLabURL
+	}
+	return &GitLabProvider{BaseURL: strings.TrimSuffix(baseURL, "/")}
+}
+
+// newClient creates a GitLab client authenticating with token. Retries are
+// left to the controller, which requeues on errors and honors rate limits.
+func (p *GitLabProvider) newClient(token string) (*gitlab.Client, error) {
+	opts := []gitlab.ClientOptionFunc{gitlab.WithBaseURL(p.BaseURL), gitlab.WithoutRetries()}
+	if p.HTTPClient != nil {
+		opts = append(opts, gitlab.WithHTTPClient(p.HTTPClient))
+	}
+	client, err := gitlab.NewClient(token, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GitLab client: %w", err)
+	}
+	return client, nil
+}
+
+// validateProjectPath checks that repo names a GitLab project
+func validateProjectPath(repo string) error {
+	if repo == "" || strings.HasPrefix(repo, "/") || strings.HasSuffix(repo, "/") || !strings.Contains(repo, "/") {
+		return fmt.Errorf("invalid repo format %q, expected a GitLab project path like 'group/project'", repo)
+	}
+	return nil
+}
+
+// Create creates a new GitLab issue
+func (p *GitLabProvider) Create(ctx context.Context, token string, input CreateIssueInput) (*Issue, error) {
+	if err := validateProjectPath(input.Repo); err != nil {
+		return nil, err
+	}
+	client, err := p.newClient(token)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := &gitlab.CreateIssueOptions{Title: &input.Title, Description: &input.Body}
+	if len(input.Labels) > 0 {
+		opts.Labels = (*gitlab.LabelOptions)(&input.Labels)
+	}
+
+	glIssue, _, err := client.Issues.CreateIssue(input.Repo, opts, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GitLab issue: %w", gitlabError(err))
+	}
+	return fromGitLabIssue(glIssue), nil
+}
+
+// Get retrieves an existing GitLab issue
+func (p *GitLabProvider) Get(ctx context.Context, token string, repo string, issueNumber int) (*Issue, error) {
+	if err := validateProjectPath(repo); err != nil {
+		return nil, err
+	}
+	client, err := p.newClient(token)
+	if err != nil {
+		return nil, err
+	}
+
+	glIssue, _, err := client.Issues.GetIssue(repo, issueNumber, gitlab.WithContext(ctx))
+	if err != nil {
+		err = gitlabError(err)
+		if errors.Is(err, errNotFound) {
+			err = fmt.Errorf("%w: %w", ErrIssueNotFound, err)
+		}
+		return nil, fmt.Errorf("failed to get GitLab issue: %w", err)
+	}
+	return fromGitLabIssue(glIssue), nil
+}
+
+// List returns the GitLab issues in a project matching opts, following pagination
+func (p *GitLabProvider) List(ctx context.Context, token string, repo string, opts ListIssuesOptions) ([]*Issue, error) {
+	if err := validateProjectPath(repo); err != nil {
+		return nil, err
+	}
+	client, err := p.newClient(token)
+	if err != nil {
+		return nil, err
+	}
+
+	listOpts := &gitlab.ListProjectIssuesOptions{State: gitlab.Ptr(gitlabState(opts.State))}
+	if len(opts.Labels) > 0 {
+		listOpts.Labels = (*gitlab.LabelOptions)(&opts.Labels)
+	}
+	issues, err := listPages(&listOpts.ListOptions, func() ([]*gitlab.Issue, *gitlab.Response, error) {
+		return client.Issues.ListProjectIssues(repo, listOpts, gitlab.WithContext(ctx))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list GitLab issues: %w", err)
+	}
+	return issues, nil
+}
+
+// Search returns the GitLab issues matching a query in GitHub's issue search
+// syntax. The repo:, is:, state:, label: and in:title qualifiers are turned
+// into issue list filters and the remaining words into GitLab's search term;
+// without repo: every issue the token can see is searched.
+func (p *GitLabProvider) Search(ctx context.Context, token string, query string) ([]*Issue, error) {
+	q := parseSearchQuery(query)
+	if q.repo != "" {
+		if err := validateProjectPath(q.repo); err != nil {
+			return nil, err
+		}
+	}
+	client, err := p.newClient(token)
+	if err != nil {
+		return nil, err
+	}
+
+	state := gitlab.Ptr(gitlabState(q.state))
+	var labels *gitlab.LabelOptions
+	if len(q.labels) > 0 {
+		labels = (*gitlab.LabelOptions)(&q.labels)
+	}
+	var search, in *string
+	if len(q.terms) > 0 {
+		search = gitlab.Ptr(strings.Join(q.terms, " "))
+		if q.inTitle {
+			in = gitlab.Ptr("title")
+		}
+	}
+
+	var issues []*Issue
+	if q.repo != "" {
+		listOpts := &gitlab.ListProjectIssuesOptions{State: state, Labels: labels, Search: search, In: in}
+		issues, err = listPages(&listOpts.ListOptions, func() ([]*gitlab.Issue, *gitlab.Response, error) {
+			return client.Issues.ListProjectIssues(q.repo, listOpts, gitlab.WithContext(ctx))
+		})
+	} else {
+		listOpts := &gitlab.ListIssuesOptions{State: state, Labels: labels, Search: search, In: in,
+			Scope: gitlab.Ptr("all")}
+		issues, err = listPages(&listOpts.ListOptions, func() ([]*gitlab.Issue, *gitlab.Response, error) {
+			return client.Issues.ListIssues(listOpts, gitlab.WithContext(ctx))
+		})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to search GitLab issues: %w", err)
+	}
+	return issues, nil
+}
+
+// listPages collects the issues of every page of a list request, advancing
+// page between calls to list
+func listPages(page *gitlab.ListOptions, list func() ([]*gitlab.Issue, *gitlab.Response, error)) ([]*Issue, error) {
+	page.PerPage = 100
+	var result []*Issue
+	for {
+		glIssues, resp, err := list()
+		if err != nil {
+			return nil, gitlabError(err)
+		}
+		for _, glIssue := range glIssues {
+			result = append(result, fromGitLabIssue(glIssue))
+		}
+		if resp.NextPage == 0 {
+			return result, nil
+		}
+		page.Page = resp.NextPage
+	}
+}
+
+// Update updates an existing GitLab issue. StateReason is ignored.
+func (p *GitLabProvider) Update(ctx context.Context, token string, repo string, issueNumber int, input UpdateIssueInput) (*Issue, error) {
+	if err := validateProjectPath(repo); err != nil {
+		return nil, err
+	}
+	client, err := p.newClient(token)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := &gitlab.UpdateIssueOptions{}
+	if input.Title != "" {
+		opts.Title = &input.Title
+	}
+	if input.Body != "" {
+		opts.Description = &input.Body
+	}
+	if input.Labels != nil {
+		// An empty list is sent as an empty string, which clears the labels
+		opts.Labels = (*gitlab.LabelOptions)(&input.Labels)
+	}
+	switch input.State {
+	case "open":
+		opts.StateEvent = gitlab.Ptr("reopen")
+	case "closed":
+		opts.StateEvent = gitlab.Ptr("close")
+	}
+
+	glIssue, _, err := client.Issues.UpdateIssue(repo, issueNumber, opts, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to update GitLab issue: %w", gitlabError(err))
+	}
+	return fromGitLabIssue(glIssue), nil
+}
+
+// Close closes a GitLab issue
+func (p *GitLabProvider) Close(ctx context.Context, token string, repo string, issueNumber int) error {
+	if _, err := p.Update(ctx, token, repo, issueNumber, UpdateIssueInput{State: "closed"}); err != nil {
+		return fmt.Errorf("failed to close GitLab issue: %w", err)
+	}
+	return nil
+}
+
+// Reopen reopens a closed GitLab issue
+func (p *GitLabProvider) Reopen(ctx context.Context, token string, repo string, issueNumber int) error {
+	if _, err := p.Update(ctx, token, repo, issueNumber, UpdateIssueInput{State: "open"}); err != nil {
+		return fmt.Errorf("failed to reopen GitLab issue: %w", err)
+	}
+	return nil
+}
+
+// Capabilities reports no optional operations; pinning, comments, transfers,
+// assignees, locking, milestones and projects are not implemented for GitLab yet.
+func (p *GitLabProvider) Capabilities() ProviderCapabilities {
+	return ProviderCapabilities{}
+}
+
+// gitlabError turns a go-gitlab error response into the provider errors the
+// controller understands: a 404, which go-gitlab reports as ErrNotFound,
+// wraps errNotFound and a 429 becomes a RateLimitError. Other errors are
+// returned unchanged.
+func gitlabError(err error) error {
+	if errors.Is(err, gitlab.ErrNotFound) {
+		return fmt.Errorf("%w: %w", errNotFound, err)
+	}
+	var errResp *gitlab.ErrorResponse
+	if errors.As(err, &errResp) && errResp.Response != nil && errResp.Response.StatusCode == http.StatusTooManyRequests {
+		return &RateLimitError{Reset: rateLimitReset(errResp.Response.Header)}
+	}
+	return err
+}
+
+// gitlabState translates a provider-neutral state filter to GitLab's
+func gitlabState(state string) string {
+	switch state {
+	case "open":
+		return "opened"
+	case "closed":
+		return "closed"
+	default:
+		return "all"
+	}
+}
+
+// fromGitLabIssue converts a GitLab issue to the provider-neutral Issue,
+// translating GitLab's "opened" state to "open"
+func fromGitLabIssue(glIssue *gitlab.Issue) *Issue {
+	state := glIssue.State
+	if state == "opened" {
+		state = "open"
+	}
+	return &Issue{
+		Number: glIssue.IID,
+		URL:    glIssue.WebURL,
+		State:  state,
+		Title:  glIssue.Title,
+		Body:   glIssue.Description,
+		Labels: SortedLabels(glIssue.Labels),
+	}
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+func TestGitLabProvider_UpdateClosesThroughStateEvent(t *testing.T) {
+	var got map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.EscapedPath() != "/api/v4/projects/group%2Fproject/issues/7" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.EscapedPath())
+		}
+		if r.Header.Get("PRIVATE-TOKEN") != "token" {
+			t.Errorf("PRIVATE-TOKEN = %q, want %q", r.Header.Get("PRIVATE-TOKEN"), "token")
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatal(err)
+		}
+		_ = json.NewEncoder(w).Encode(gitlab.Issue{IID: 7, State: "closed", Title: "t", Labels: []string{"b", "a"}})
+	}))
+	defer srv.Close()
+
+	p := NewGitLabProvider(srv.URL)
+	issue, err := p.Update(context.Background(), "token", "group/project", 7, UpdateIssueInput{State: "closed", Labels: []string{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got["state_event"] != "close" {
+		t.Errorf("state_event = %v, want %q", got["state_event"], "close")
+	}
+	if labels, ok := got["labels"]; !ok || labels != "" {
+		t.Errorf("labels = %v, want an empty list that clears them", labels)
+	}
+	if issue.State != "closed" || issue.Labels[0] != "a" {
+		t.Errorf("issue = %+v, want closed with sorted labels", issue)
+	}
+}
+
+func TestGitLabProvider_ListFollowsPagesAndTranslatesState(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("state") != "opened" {
+			t.Errorf("state = %q, want %q", r.URL.Query().Get("state"), "opened")
+		}
+		if r.URL.Query().Get("page") == "" {
+			w.Header().Set("X-Next-Page", "2")
+			_ = json.NewEncoder(w).Encode([]*gitlab.Issue{{IID: 1, State: "opened"}})
+			return
+		}
+		_ = json.NewEncoder(w).Encode([]*gitlab.Issue{{IID: 2, State: "opened"}})
+	}))
+	defer srv.Close()
+
+	p := NewGitLabProvider(srv.URL)
+	issues, err := p.List(context.Background(), "token", "group/project", ListIssuesOptions{State: "open"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 2 || issues[1].Number != 2 || issues[0].State != "open" {
+		t.Errorf("issues = %+v, want #1 and #2, both open", issues)
+	}
+}
+
+func TestGitLabProvider_TooManyRequestsIsRateLimitError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("RateLimit-Reset", "1700000000")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	p := NewGitLabProvider(srv.URL)
+	_, err := p.Get(context.Background(), "token", "group/project", 1)
+	var rateLimitErr *RateLimitError
+	if !errors.As(err, &rateLimitErr) {
+		t.Fatalf("err = %v, want a RateLimitError", err)
+	}
+	if rateLimitErr.Reset.Unix() != 1700000000 {
+		t.Errorf("reset = %v, want the RateLimit-Reset time", rateLimitErr.Reset)
+	}
+}
+
+func TestGitLabProvider_GetMissingIssueIsNotFound(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"404 Not found"}`))
+	}))
+	defer srv.Close()
+
+	p := NewGitLabProvider(srv.URL)
+	if _, err := p.Get(context.Background(), "token", "group/project", 1); !errors.Is(err, ErrIssueNotFound) {
+		t.Errorf("err = %v, want ErrIssueNotFound", err)
+	}
+}
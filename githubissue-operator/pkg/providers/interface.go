@@ -16,7 +16,13 @@ limitations under the License.
 
 package providers
 
-import "context"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
 
 // Issue represents a remote issue from any provider
 type Issue struct {
@@ -26,12 +32,30 @@ type Issue struct {
 	URL string
 	// State is the current state: "open" or "closed"
 	State string
+	// StateReason qualifies State: "completed" or "not_planned" when closed,
+	// "reopened" after a reopen, empty if the provider does not track it
+	StateReason string
 	// Title is the issue title
 	Title string
 	// Body is the issue description
 	Body string
-	// Labels are the labels applied to the issue
+	// Labels are the labels applied to the issue, sorted by name so that
+	// provider ordering never shows up as a change
 	Labels []string
+	// Assignees are the logins of the users assigned to the issue, sorted
+	Assignees []string
+	// Milestone is the title of the issue's milestone, empty if it has none
+	Milestone string
+	// Pinned reports whether the issue is pinned to the repository
+	Pinned bool
+	// Locked reports whether the issue's conversation is locked
+	Locked bool
+	// Author is the login of the user who opened the issue, empty if the
+	// provider does not report it
+	Author string
+	// CreatedAt is when the issue was opened, zero if the provider does not
+	// report it
+	CreatedAt time.Time
 }
 
 // CreateIssueInput contains the data needed to create an issue
@@ -44,6 +68,10 @@ type CreateIssueInput struct {
 	Body string
 	// Labels to apply
 	Labels []string
+	// Assignees are the logins to assign the issue to
+	Assignees []string
+	// Milestone is the title of the milestone to add the issue to (optional)
+	Milestone string
 }
 
 // UpdateIssueInput contains the data needed to update an issue
@@ -54,6 +82,22 @@ type UpdateIssueInput struct {
 	Body string
 	// Labels to apply (nil means no change, empty slice clears labels)
 	Labels []string
+	// Assignees to set (nil means no change, empty slice removes all assignees)
+	Assignees []string
+	// Milestone is the title of the milestone to move the issue to (optional, empty means no change)
+	Milestone string
+	// State to move the issue to, "open" or "closed" (optional, empty means no change)
+	State string
+	// StateReason to record with State, e.g. "not_planned" (optional, empty means no change)
+	StateReason string
+}
+
+// ListIssuesOptions filters the issues returned by List
+type ListIssuesOptions struct {
+	// Labels restricts results to issues carrying all of these labels
+	Labels []string
+	// State is "open", "closed" or "all" (empty means "all")
+	State string
 }
 
 // IssueProvider defines the interface for managing remote issues
@@ -61,9 +105,14 @@ type IssueProvider interface {
 	// Create creates a new issue and returns the created issue details
 	Create(ctx context.Context, token string, input CreateIssueInput) (*Issue, error)
 
-	// Get retrieves an existing issue by repo and issue number
+	// Get retrieves an existing issue by repo and issue number. If the issue
+	// does not exist, e.g. because it was deleted, the error wraps
+	// ErrIssueNotFound.
 	Get(ctx context.Context, token string, repo string, issueNumber int) (*Issue, error)
 
+	// List returns the issues in a repo matching opts, excluding pull requests
+	List(ctx context.Context, token string, repo string, opts ListIssuesOptions) ([]*Issue, error)
+
 	// Update updates an existing issue
 	Update(ctx context.Context, token string, repo string, issueNumber int, input UpdateIssueInput) (*Issue, error)
 
@@ -72,4 +121,161 @@ type IssueProvider interface {
 
 	// Reopen reopens a closed issue
 	Reopen(ctx context.Context, token string, repo string, issueNumber int) error
+
+	// Search returns the issues matching a query in GitHub's issue search
+	// syntax, e.g. `repo:owner/repo is:open in:title "flaky test"`, excluding
+	// pull requests
+	Search(ctx context.Context, token string, query string) ([]*Issue, error)
+}
+
+// ErrIssueNotFound is wrapped by the error Get returns when the provider
+// answered that the issue does not exist. Network failures and other errors
+// never wrap it.
+var ErrIssueNotFound = errors.New("issue not found")
+
+// RateLimitError is returned when a provider's rate limit is exhausted. The
+// call can be retried once the limit resets.
+type RateLimitError struct {
+	// Reset is when the limit resets
+	Reset time.Time
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("rate limit exceeded, resets at %s", e.Reset.UTC().Format(time.RFC3339))
+}
+
+// RetryAfter reports how long after now to wait before retrying when err is
+// or wraps a *RateLimitError. The wait is at least a second.
+func RetryAfter(err error, now time.Time) (time.Duration, bool) {
+	var rateErr *RateLimitError
+	if !errors.As(err, &rateErr) {
+		return 0, false
+	}
+	return max(rateErr.Reset.Sub(now), time.Second), true
+}
+
+// RateObserver is told how many calls the token of a provider call has left
+// and when that quota resets, whenever the provider reports them.
+type RateObserver func(remaining int, reset time.Time)
+
+type rateObserverKey struct{}
+
+// WithRateObserver returns a copy of ctx whose provider calls report the
+// remaining quota of their token to observe. Providers that are not told the
+// quota in their API's responses never call it.
+func WithRateObserver(ctx context.Context, observe RateObserver) context.Context {
+	return context.WithValue(ctx, rateObserverKey{}, observe)
+}
+
+// ProviderCapabilities reports which optional operations a provider implements.
+// The reconciler checks these before using an optional interface so that an
+// unsupported feature surfaces as a condition rather than a failed API call.
+type ProviderCapabilities struct {
+	// Delete means issues can be deleted rather than only closed (see Deleter)
+	Delete bool
+	// Pin means issues can be pinned to the repository (see Pinner)
+	Pin bool
+	// Projects means issues can be added to projects
+	Projects bool
+	// Comments means comments can be posted on issues
+	Comments bool
+	// Transfer means issues can be moved to another repository (see Transferer)
+	Transfer bool
+	// Assignees means issues can be assigned to users by login, and Issue
+	// reports who they are assigned to
+	Assignees bool
+	// Lock means issues can be locked against further comments (see Locker)
+	Lock bool
+	// Milestones means issues can be added to milestones by title, and Issue
+	// reports their milestone
+	Milestones bool
+	// LabelAppearance means repository labels can be created and restyled
+	// with a color and description (see LabelManager)
+	LabelAppearance bool
+}
+
+// CapabilityReporter is implemented by providers that support optional operations
+type CapabilityReporter interface {
+	// Capabilities returns the optional operations this provider supports
+	Capabilities() ProviderCapabilities
+}
+
+// CapabilitiesOf returns the capabilities of p, or none if p does not report them
+func CapabilitiesOf(p IssueProvider) ProviderCapabilities {
+	if r, ok := p.(CapabilityReporter); ok {
+		return r.Capabilities()
+	}
+	return ProviderCapabilities{}
+}
+
+// Pinner is implemented by providers that report Pin support
+type Pinner interface {
+	// SetPinned pins or unpins an issue
+	SetPinned(ctx context.Context, token string, repo string, issueNumber int, pinned bool) error
+}
+
+// Comment is a comment on a remote issue
+type Comment struct {
+	// ID identifies the comment across the provider
+	ID int64
+	// Body is the comment text
+	Body string
+}
+
+// Commenter is implemented by providers that report Comments support
+type Commenter interface {
+	// AddComment posts a comment with the given body on an issue and returns it
+	AddComment(ctx context.Context, token string, repo string, issueNumber int, body string) (*Comment, error)
+
+	// ListComments returns the comments on an issue, oldest first
+	ListComments(ctx context.Context, token string, repo string, issueNumber int) ([]*Comment, error)
+}
+
+// Locker is implemented by providers that report Lock support
+type Locker interface {
+	// Lock locks an issue's conversation so that only collaborators can
+	// still comment on or edit it
+	Lock(ctx context.Context, token string, repo string, issueNumber int) error
+}
+
+// LabelSpec describes how a repository label should look
+type LabelSpec struct {
+	// Name of the label
+	Name string
+	// Color is a six digit hex color without the leading "#", e.g. "d73a4a"
+	Color string
+	// Description is a short description of the label
+	Description string
+}
+
+// LabelManager is implemented by providers that report LabelAppearance support
+type LabelManager interface {
+	// EnsureLabels creates the labels missing from repo and updates the color
+	// and description of the others where they differ
+	EnsureLabels(ctx context.Context, token string, repo string, labels []LabelSpec) error
+}
+
+// Deleter is implemented by providers that report Delete support
+type Deleter interface {
+	// Delete removes an issue for good. If it does not exist the error wraps
+	// ErrIssueNotFound.
+	Delete(ctx context.Context, token string, repo string, issueNumber int) error
+}
+
+// Transferer is implemented by providers that report Transfer support
+type Transferer interface {
+	// Transfer moves an issue to toRepo and returns it as it is there,
+	// usually under a new number
+	Transfer(ctx context.Context, token string, fromRepo string, issueNumber int, toRepo string) (*Issue, error)
+}
+
+// SortedLabels returns a sorted copy of labels. Providers use it to normalize
+// Issue.Labels and Issue.Assignees; nil stays nil.
+func SortedLabels(labels []string) []string {
+	if labels == nil {
+		return nil
+	}
+	sorted := slices.Clone(labels)
+	slices.Sort(sorted)
+	return sorted
 }
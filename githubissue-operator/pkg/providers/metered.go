@@ -0,0 +1,205 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package providers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// ProviderCallDuration is the latency of issue provider calls made through a
+// MeteredProvider, by operation (the method name, e.g. "Create") and outcome
+// ("success" or "error"). It is not registered anywhere; the manager's
+// metrics registry is the usual home.
+var ProviderCallDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "githubissue_provider_call_duration_seconds",
+	Help:    "Latency of issue provider calls by operation and outcome.",
+	Buckets: prometheus.DefBuckets,
+}, []string{"operation", "outcome"})
+
+// MeteredProvider wraps an IssueProvider and times every call into a
+// histogram. The optional operations are passed through as well, and
+// Capabilities are those of the wrapped provider, so wrapping does not change
+// what the reconciler can do.
+type MeteredProvider struct {
+	IssueProvider
+	// Duration observes the latency of each call; it needs the labels
+	// "operation" and "outcome"
+	Duration *prometheus.HistogramVec
+}
+
+// NewMeteredProvider wraps p so that its calls are timed into ProviderCallDuration
+func NewMeteredProvider(p IssueProvider) *MeteredProvider {
+	return &MeteredProvider{IssueProvider: p, Duration: ProviderCallDuration}
+}
+
+// observe records a call to operation that started at start and returned err
+func (p *MeteredProvider) observe(operation string, start time.Time, err error) {
+	outcome := "success"
+	if err != nil {
+		outcome = "error"
+	}
+	p.Duration.WithLabelValues(operation, outcome).Observe(time.Since(start).Seconds())
+}
+
+// Create times IssueProvider.Create
+func (p *MeteredProvider) Create(ctx context.Context, token string, input CreateIssueInput) (*Issue, error) {
+	start := time.Now()
+	issue, err := p.IssueProvider.Create(ctx, token, input)
+	p.observe("Create", start, err)
+	return issue, err
+}
+
+// Get times IssueProvider.Get
+func (p *MeteredProvider) Get(ctx context.Context, token string, repo string, issueNumber int) (*Issue, error) {
+	start := time.Now()
+	issue, err := p.IssueProvider.Get(ctx, token, repo, issueNumber)
+	p.observe("Get", start, err)
+	return issue, err
+}
+
+// List times IssueProvider.List
+func (p *MeteredProvider) List(ctx context.Context, token string, repo string, opts ListIssuesOptions) ([]*Issue, error) {
+	start := time.Now()
+	issues, err := p.IssueProvider.List(ctx, token, repo, opts)
+	p.observe("List", start, err)
+	return issues, err
+}
+
+// Update times IssueProvider.Update
+func (p *MeteredProvider) Update(ctx context.Context, token string, repo string, issueNumber int, input UpdateIssueInput) (*Issue, error) {
+	start := time.Now()
+	issue, err := p.IssueProvider.Update(ctx, token, repo, issueNumber, input)
+	p.observe("Update", start, err)
+	return issue, err
+}
+
+// Close times IssueProvider.Close
+func (p *MeteredProvider) Close(ctx context.Context, token string, repo string, issueNumber int) error {
+	start := time.Now()
+	err := p.IssueProvider.Close(ctx, token, repo, issueNumber)
+	p.observe("Close", start, err)
+	return err
+}
+
+// Reopen times IssueProvider.Reopen
+func (p *MeteredProvider) Reopen(ctx context.Context, token string, repo string, issueNumber int) error {
+	start := time.Now()
+	err := p.IssueProvider.Reopen(ctx, token, repo, issueNumber)
+	p.observe("Reopen", start, err)
+	return err
+}
+
+// Search times IssueProvider.Search
+func (p *MeteredProvider) Search(ctx context.Context, token string, query string) ([]*Issue, error) {
+	start := time.Now()
+	issues, err := p.IssueProvider.Search(ctx, token, query)
+	p.observe("Search", start, err)
+	return issues, err
+}
+
+// Capabilities reports the capabilities of the wrapped provider
+func (p *MeteredProvider) Capabilities() ProviderCapabilities {
+	return CapabilitiesOf(p.IssueProvider)
+}
+
+// Delete times Deleter.Delete of the wrapped provider
+func (p *MeteredProvider) Delete(ctx context.Context, token string, repo string, issueNumber int) error {
+	deleter, ok := p.IssueProvider.(Deleter)
+	if !ok {
+		return fmt.Errorf("issue provider cannot delete issues")
+	}
+	start := time.Now()
+	err := deleter.Delete(ctx, token, repo, issueNumber)
+	p.observe("Delete", start, err)
+	return err
+}
+
+// SetPinned times Pinner.SetPinned of the wrapped provider
+func (p *MeteredProvider) SetPinned(ctx context.Context, token string, repo string, issueNumber int, pinned bool) error {
+	pinner, ok := p.IssueProvider.(Pinner)
+	if !ok {
+		return fmt.Errorf("issue provider cannot pin issues")
+	}
+	start := time.Now()
+	err := pinner.SetPinned(ctx, token, repo, issueNumber, pinned)
+	p.observe("SetPinned", start, err)
+	return err
+}
+
+// Lock times Locker.Lock of the wrapped provider
+func (p *MeteredProvider) Lock(ctx context.Context, token string, repo string, issueNumber int) error {
+	locker, ok := p.IssueProvider.(Locker)
+	if !ok {
+		return fmt.Errorf("issue provider cannot lock issues")
+	}
+	start := time.Now()
+	err := locker.Lock(ctx, token, repo, issueNumber)
+	p.observe("Lock", start, err)
+	return err
+}
+
+// EnsureLabels times LabelManager.EnsureLabels of the wrapped provider
+func (p *MeteredProvider) EnsureLabels(ctx context.Context, token string, repo string, labels []LabelSpec) error {
+	manager, ok := p.IssueProvider.(LabelManager)
+	if !ok {
+		return fmt.Errorf("issue provider cannot manage labels")
+	}
+	start := time.Now()
+	err := manager.EnsureLabels(ctx, token, repo, labels)
+	p.observe("EnsureLabels", start, err)
+	return err
+}
+
+// AddComment times Commenter.AddComment of the wrapped provider
+func (p *MeteredProvider) AddComment(ctx context.Context, token string, repo string, issueNumber int, body string) (*Comment, error) {
+	commenter, ok := p.IssueProvider.(Commenter)
+	if !ok {
+		return nil, fmt.Errorf("issue provider cannot comment on issues")
+	}
+	start := time.Now()
+	comment, err := commenter.AddComment(ctx, token, repo, issueNumber, body)
+	p.observe("AddComment", start, err)
+	return comment, err
+}
+
+// ListComments times Commenter.ListComments of the wrapped provider
+func (p *MeteredProvider) ListComments(ctx context.Context, token string, repo string, issueNumber int) ([]*Comment, error) {
+	commenter, ok := p.IssueProvider.(Commenter)
+	if !ok {
+		return nil, fmt.Errorf("issue provider cannot list comments")
+	}
+	start := time.Now()
+	comments, err := commenter.ListComments(ctx, token, repo, issueNumber)
+	p.observe("ListComments", start, err)
+	return comments, err
+}
+
+// Transfer times Transferer.Transfer of the wrapped provider
+func (p *MeteredProvider) Transfer(ctx context.Context, token string, fromRepo string, issueNumber int, toRepo string) (*Issue, error) {
+	transferer, ok := p.IssueProvider.(Transferer)
+	if !ok {
+		return nil, fmt.Errorf("issue provider cannot transfer issues")
+	}
+	start := time.Now()
+	issue, err := transferer.Transfer(ctx, token, fromRepo, issueNumber, toRepo)
+	p.observe("Transfer", start, err)
+	return issue, err
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package providers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// newTestMeteredProvider wraps a fresh MockProvider into a MeteredProvider
+// observing into a fresh histogram
+func newTestMeteredProvider() (*MeteredProvider, *MockProvider) {
+	mock := NewMockProvider()
+	p := NewMeteredProvider(mock)
+	p.Duration = prometheus.NewHistogramVec(prometheus.HistogramOpts{Name: "test_duration_seconds"},
+		[]string{"operation", "outcome"})
+	return p, mock
+}
+
+// sampleCount returns how many calls p observed for operation and outcome
+func sampleCount(t *testing.T, p *MeteredProvider, operation, outcome string) uint64 {
+	t.Helper()
+	var m dto.Metric
+	if err := p.Duration.WithLabelValues(operation, outcome).(prometheus.Histogram).Write(&m); err != nil {
+		t.Fatal(err)
+	}
+	return m.GetHistogram().GetSampleCount()
+}
+
+func TestMeteredProvider_ObservesEveryCall(t *testing.T) {
+	p, _ := newTestMeteredProvider()
+	ctx := context.Background()
+
+	created, err := p.Create(ctx, "token", CreateIssueInput{Repo: "owner/repo", Title: "Bug"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p.Get(ctx, "token", "owner/repo", created.Number); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p.Update(ctx, "token", "owner/repo", created.Number, UpdateIssueInput{Title: "Bug!"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Close(ctx, "token", "owner/repo", created.Number); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Reopen(ctx, "token", "owner/repo", created.Number); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Close(ctx, "token", "owner/repo", created.Number); err != nil {
+		t.Fatal(err)
+	}
+
+	for operation, want := range map[string]uint64{"Create": 1, "Get": 1, "Update": 1, "Close": 2, "Reopen": 1} {
+		if got := sampleCount(t, p, operation, "success"); got != want {
+			t.Errorf("%s success samples = %d, want %d", operation, got, want)
+		}
+		if got := sampleCount(t, p, operation, "error"); got != 0 {
+			t.Errorf("%s error samples = %d, want 0", operation, got)
+		}
+	}
+}
+
+func TestMeteredProvider_ObservesErrors(t *testing.T) {
+	p, mock := newTestMeteredProvider()
+	mock.FailNext("Create", 1, errors.New("502 Bad Gateway"))
+
+	if _, err := p.Create(context.Background(), "token", CreateIssueInput{Repo: "owner/repo", Title: "Bug"}); err == nil {
+		t.Fatal("Create succeeded, want the injected error")
+	}
+	if _, err := p.Get(context.Background(), "token", "owner/repo", 1); !errors.Is(err, ErrIssueNotFound) {
+		t.Fatalf("err = %v, want ErrIssueNotFound passed through", err)
+	}
+	if got := sampleCount(t, p, "Create", "error"); got != 1 {
+		t.Errorf("Create error samples = %d, want 1", got)
+	}
+	if got := sampleCount(t, p, "Get", "error"); got != 1 {
+		t.Errorf("Get error samples = %d, want 1", got)
+	}
+}
+
+func TestMeteredProvider_KeepsOptionalOperations(t *testing.T) {
+	p, mock := newTestMeteredProvider()
+	if CapabilitiesOf(p) != mock.Caps {
+		t.Errorf("Capabilities = %+v, want those of the wrapped provider %+v", CapabilitiesOf(p), mock.Caps)
+	}
+	var _ interface {
+		Deleter
+		Pinner
+		Locker
+		Commenter
+		LabelManager
+		Transferer
+	} = p
+
+	created, err := p.Create(context.Background(), "token", CreateIssueInput{Repo: "owner/repo", Title: "Bug"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Lock(context.Background(), "token", "owner/repo", created.Number); err != nil {
+		t.Fatal(err)
+	}
+	if got := sampleCount(t, p, "Lock", "success"); got != 1 {
+		t.Errorf("Lock success samples = %d, want 1", got)
+	}
+
+	bare := &MeteredProvider{IssueProvider: NewGiteaProvider("https://gitea.example.com"), Duration: p.Duration}
+	if err := bare.Lock(context.Background(), "token", "owner/repo", 1); err == nil {
+		t.Error("Lock through a provider without Locker succeeded, want an error")
+	}
+}
@@ -17,70 +17,158 @@ limitations under the License.
 package providers
 
 import (
+	"cmp"
 	"context"
 	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
+	"slices"
+	"strconv"
+	"strings"
 	"sync"
+	"time"
 )
 
+// MockAuthor is the login MockProvider reports as the author of the issues it creates
+const MockAuthor = "mock-user"
+
 // MockProvider implements IssueProvider for testing
 type MockProvider struct {
-	mu           sync.RWMutex
-	issues       map[string]*Issue // key: "repo#number"
-	nextNumber   int
-	CreateFunc   func(ctx context.Context, token string, input CreateIssueInput) (*Issue, error)
-	GetFunc      func(ctx context.Context, token string, repo string, issueNumber int) (*Issue, error)
-	UpdateFunc   func(ctx context.Context, token string, repo string, issueNumber int, input UpdateIssueInput) (*Issue, error)
-	CloseFunc    func(ctx context.Context, token string, repo string, issueNumber int) error
+	mu         sync.RWMutex
+	issues     map[string]*Issue // key: "repo#number"
+	comments   map[string][]*Comment
+	labels     map[string][]LabelSpec      // key: repo
+	failNext   map[string]*injectedFailure // key: method name
+	nextNumber int
+	// lastCommentID numbers comments across all issues, like GitHub does
+	lastCommentID int64
+	CreateFunc    func(ctx context.Context, token string, input CreateIssueInput) (*Issue, error)
+	GetFunc       func(ctx context.Context, token string, repo string, issueNumber int) (*Issue, error)
+	UpdateFunc    func(ctx context.Context, token string, repo string, issueNumber int, input UpdateIssueInput) (*Issue, error)
+	CloseFunc     func(ctx context.Context, token string, repo string, issueNumber int) error
+	LockFunc      func(ctx context.Context, token string, repo string, issueNumber int) error
+	// Caps is what Capabilities reports; NewMockProvider enables everything the mock implements
+	Caps         ProviderCapabilities
 	CreateCalled int
 	GetCalled    int
 	UpdateCalled int
 	CloseCalled  int
+	DeleteCalled int
+	LockCalled   int
+	ListCalled   int
+	SearchFunc   func(ctx context.Context, token string, query string) ([]*Issue, error)
+	SearchCalled int
+
+	EnsureLabelsCalled int
 }
 
 // NewMockProvider creates a new MockProvider
 func NewMockProvider() *MockProvider {
 	return &MockProvider{
 		issues:     make(map[string]*Issue),
+		comments:   make(map[string][]*Comment),
+		labels:     make(map[string][]LabelSpec),
+		failNext:   make(map[string]*injectedFailure),
 		nextNumber: 1,
+		Caps: ProviderCapabilities{Delete: true, Pin: true, Comments: true, Transfer: true, Assignees: true,
+			Lock: true, Milestones: true, LabelAppearance: true},
+	}
+}
+
+// mockMethods are the provider methods FailNext can make fail
+var mockMethods = []string{"Create", "Get", "List", "Search", "Update", "Close", "Reopen", "Delete",
+	"SetPinned", "Lock", "EnsureLabels", "AddComment", "ListComments", "Transfer"}
+
+// injectedFailure is a failure queued by FailNext
+type injectedFailure struct {
+	remaining int
+	err       error
+}
+
+// FailNext makes the next n calls to method, e.g. "Create", return err, after
+// which calls succeed again. The failing calls are counted but run neither
+// the method's Func hook nor its effect. It replaces whatever was queued for
+// method before; n <= 0 clears it.
+func (m *MockProvider) FailNext(method string, n int, err error) {
+	if !slices.Contains(mockMethods, method) {
+		panic(fmt.Sprintf("MockProvider.FailNext: unknown method %q", method))
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if n <= 0 {
+		delete(m.failNext, method)
+		return
 	}
+	m.failNext[method] = &injectedFailure{remaining: n, err: err}
+}
+
+// takeFailure uses up one failure FailNext queued for method and returns its
+// error, or nil if none is queued. The caller must hold m.mu for writing.
+func (m *MockProvider) takeFailure(method string) error {
+	f, ok := m.failNext[method]
+	if !ok {
+		return nil
+	}
+	f.remaining--
+	if f.remaining == 0 {
+		delete(m.failNext, method)
+	}
+	return f.err
 }
 
 func issueKey(repo string, number int) string {
 	return fmt.Sprintf("%s#%d", repo, number)
 }
 
+// clone copies an issue so callers never share state with the mock's store
+func (i *Issue) clone() *Issue {
+	c := *i
+	c.Labels = append([]string(nil), i.Labels...)
+	c.Assignees = append([]string(nil), i.Assignees...)
+	return &c
+}
+
 // Create creates a mock issue
 func (m *MockProvider) Create(ctx context.Context, token string, input CreateIssueInput) (*Issue, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	m.CreateCalled++
+	if err := m.takeFailure("Create"); err != nil {
+		return nil, err
+	}
 
 	if m.CreateFunc != nil {
 		return m.CreateFunc(ctx, token, input)
 	}
 
 	issue := &Issue{
-		Number: m.nextNumber,
-		URL:    fmt.Sprintf("https://github.com/%s/issues/%d", input.Repo, m.nextNumber),
-		State:  "open",
-		Title:  input.Title,
-		Body:   input.Body,
-		Labels: input.Labels,
+		Number:    m.nextNumber,
+		URL:       fmt.Sprintf("https://github.com/%s/issues/%d", input.Repo, m.nextNumber),
+		State:     "open",
+		Title:     input.Title,
+		Body:      input.Body,
+		Labels:    SortedLabels(input.Labels),
+		Assignees: SortedLabels(input.Assignees),
+		Milestone: input.Milestone,
+		Author:    MockAuthor,
+		CreatedAt: time.Now(),
 	}
 	m.issues[issueKey(input.Repo, m.nextNumber)] = issue
 	m.nextNumber++
 
-	return issue, nil
+	return issue.clone(), nil
 }
 
 // Get retrieves a mock issue
 func (m *MockProvider) Get(ctx context.Context, token string, repo string, issueNumber int) (*Issue, error) {
-	m.mu.RLock()
-	defer m.mu.RUnlock()
+	// Write lock: GetCalled is mutated
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.GetCalled++
+	if err := m.takeFailure("Get"); err != nil {
+		return nil, err
+	}
 
 	if m.GetFunc != nil {
 		return m.GetFunc(ctx, token, repo, issueNumber)
@@ -88,9 +176,172 @@ func (m *MockProvider) Get(ctx context.Context, token string, repo string, issue
 
 	issue, ok := m.issues[issueKey(repo, issueNumber)]
 	if !ok {
-		return nil, fmt.Errorf("issue not found: %s#%d", repo, issueNumber)
+		return nil, fmt.Errorf("%w: %s#%d", ErrIssueNotFound, repo, issueNumber)
+	}
+	return issue.clone(), nil
+}
+
+// List returns mock issues in a repo matching opts, ordered by number
+func (m *MockProvider) List(ctx context.Context, token string, repo string, opts ListIssuesOptions) ([]*Issue, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.ListCalled++
+	if err := m.takeFailure("List"); err != nil {
+		return nil, err
+	}
+
+	var result []*Issue
+	for number := 1; number < m.nextNumber; number++ {
+		issue, ok := m.issues[issueKey(repo, number)]
+		if !ok {
+			continue
+		}
+		if opts.State != "" && opts.State != "all" && issue.State != opts.State {
+			continue
+		}
+		if !hasAllLabels(issue.Labels, opts.Labels) {
+			continue
+		}
+		result = append(result, issue.clone())
+	}
+	return result, nil
+}
+
+// hasAllLabels reports whether have contains every label in want
+func hasAllLabels(have, want []string) bool {
+	for _, w := range want {
+		if !slices.Contains(have, w) {
+			return false
+		}
+	}
+	return true
+}
+
+// Search returns mock issues matching query, ordered by repo and number. It
+// understands the repo:, is:, state:, label: and in:title qualifiers, quoted
+// phrases and bare words, all matched case-insensitively; other qualifiers are
+// ignored.
+func (m *MockProvider) Search(ctx context.Context, token string, query string) ([]*Issue, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.SearchCalled++
+	if err := m.takeFailure("Search"); err != nil {
+		return nil, err
+	}
+
+	if m.SearchFunc != nil {
+		return m.SearchFunc(ctx, token, query)
+	}
+
+	q := parseSearchQuery(query)
+	keys := make([]string, 0, len(m.issues))
+	for key := range m.issues {
+		keys = append(keys, key)
+	}
+	slices.SortFunc(keys, compareIssueKeys)
+
+	var result []*Issue
+	for _, key := range keys {
+		repo, _, _ := strings.Cut(key, "#")
+		if issue := m.issues[key]; q.matches(repo, issue) {
+			result = append(result, issue.clone())
+		}
+	}
+	return result, nil
+}
+
+// compareIssueKeys orders issue keys by repo, then by number
+func compareIssueKeys(a, b string) int {
+	repoA, numA, _ := strings.Cut(a, "#")
+	repoB, numB, _ := strings.Cut(b, "#")
+	if c := strings.Compare(repoA, repoB); c != 0 {
+		return c
+	}
+	na, _ := strconv.Atoi(numA)
+	nb, _ := strconv.Atoi(numB)
+	return cmp.Compare(na, nb)
+}
+
+// searchQuery is the subset of GitHub's issue search syntax the mock understands
+type searchQuery struct {
+	repo    string
+	state   string
+	labels  []string
+	inTitle bool
+	terms   []string
+}
+
+// parseSearchQuery splits query into qualifiers and search terms. Quoted
+// phrases are kept together as a single term.
+func parseSearchQuery(query string) searchQuery {
+	var q searchQuery
+	for _, token := range splitSearchQuery(query) {
+		key, value, ok := strings.Cut(token, ":")
+		if !ok || strings.HasPrefix(token, `"`) {
+			q.terms = append(q.terms, strings.ToLower(strings.Trim(token, `"`)))
+			continue
+		}
+		switch key {
+		case "repo":
+			q.repo = value
+		case "is", "state":
+			if value == "open" || value == "closed" {
+				q.state = value
+			}
+		case "label":
+			q.labels = append(q.labels, strings.Trim(value, `"`))
+		case "in":
+			q.inTitle = value == "title"
+		}
 	}
-	return issue, nil
+	return q
+}
+
+// splitSearchQuery splits query on spaces outside double quotes
+func splitSearchQuery(query string) []string {
+	var tokens []string
+	var current strings.Builder
+	quoted := false
+	for _, r := range query {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			current.WriteRune(r)
+		case r == ' ' && !quoted:
+			if current.Len() > 0 {
+				tokens = append(tokens, current.String())
+				current.Reset()
+			}
+		default:
+			current.WriteRune(r)
+		}
+	}
+	if current.Len() > 0 {
+		tokens = append(tokens, current.String())
+	}
+	return tokens
+}
+
+func (q searchQuery) matches(repo string, issue *Issue) bool {
+	if q.repo != "" && !strings.EqualFold(q.repo, repo) {
+		return false
+	}
+	if q.state != "" && q.state != issue.State {
+		return false
+	}
+	if !hasAllLabels(issue.Labels, q.labels) {
+		return false
+	}
+	text := strings.ToLower(issue.Title)
+	if !q.inTitle {
+		text += "\n" + strings.ToLower(issue.Body)
+	}
+	for _, term := range q.terms {
+		if !strings.Contains(text, term) {
+			return false
+		}
+	}
+	return true
 }
 
 // Update updates a mock issue
@@ -98,6 +349,9 @@ func (m *MockProvider) Update(ctx context.Context, token string, repo string, is
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	m.UpdateCalled++
+	if err := m.takeFailure("Update"); err != nil {
+		return nil, err
+	}
 
 	if m.UpdateFunc != nil {
 		return m.UpdateFunc(ctx, token, repo, issueNumber, input)
@@ -106,7 +360,7 @@ func (m *MockProvider) Update(ctx context.Context, token string, repo string, is
 	key := issueKey(repo, issueNumber)
 	issue, ok := m.issues[key]
 	if !ok {
-		return nil, fmt.Errorf("issue not found: %s#%d", repo, issueNumber)
+		return nil, fmt.Errorf("%w: %s#%d", ErrIssueNotFound, repo, issueNumber)
 	}
 
 	if input.Title != "" {
@@ -116,10 +370,23 @@ func (m *MockProvider) Update(ctx context.Context, token string, repo string, is
 		issue.Body = input.Body
 	}
 	if input.Labels != nil {
-		issue.Labels = input.Labels
+		issue.Labels = SortedLabels(input.Labels)
+	}
+	if input.Assignees != nil {
+		issue.Assignees = SortedLabels(input.Assignees)
+	}
+	if input.Milestone != "" {
+		issue.Milestone = input.Milestone
+	}
+	if input.State != "" && input.State != issue.State {
+		issue.State = input.State
+		issue.StateReason = defaultStateReason(input.State)
+	}
+	if input.StateReason != "" {
+		issue.StateReason = input.StateReason
 	}
 
-	return issue, nil
+	return issue.clone(), nil
 }
 
 // Close closes a mock issue
@@ -127,6 +394,9 @@ func (m *MockProvider) Close(ctx context.Context, token string, repo string, iss
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	m.CloseCalled++
+	if err := m.takeFailure("Close"); err != nil {
+		return err
+	}
 
 	if m.CloseFunc != nil {
 		return m.CloseFunc(ctx, token, repo, issueNumber)
@@ -135,10 +405,11 @@ func (m *MockProvider) Close(ctx context.Context, token string, repo string, iss
 	key := issueKey(repo, issueNumber)
 	issue, ok := m.issues[key]
 	if !ok {
-		return fmt.Errorf("issue not found: %s#%d", repo, issueNumber)
+		return fmt.Errorf("%w: %s#%d", ErrIssueNotFound, repo, issueNumber)
 	}
 
 	issue.State = "closed"
+	issue.StateReason = defaultStateReason("closed")
 	return nil
 }
 
@@ -146,30 +417,289 @@ func (m *MockProvider) Close(ctx context.Context, token string, repo string, iss
 func (m *MockProvider) Reopen(ctx context.Context, token string, repo string, issueNumber int) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	if err := m.takeFailure("Reopen"); err != nil {
+		return err
+	}
 
 	key := issueKey(repo, issueNumber)
 	issue, ok := m.issues[key]
 	if !ok {
-		return fmt.Errorf("issue not found: %s#%d", repo, issueNumber)
+		return fmt.Errorf("%w: %s#%d", ErrIssueNotFound, repo, issueNumber)
 	}
 
 	issue.State = "open"
+	issue.StateReason = defaultStateReason("open")
+	return nil
+}
+
+// Delete removes a mock issue and its comments
+func (m *MockProvider) Delete(ctx context.Context, token string, repo string, issueNumber int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.DeleteCalled++
+	if err := m.takeFailure("Delete"); err != nil {
+		return err
+	}
+
+	key := issueKey(repo, issueNumber)
+	if _, ok := m.issues[key]; !ok {
+		return fmt.Errorf("%w: %s#%d", ErrIssueNotFound, repo, issueNumber)
+	}
+	delete(m.issues, key)
+	delete(m.comments, key)
+	return nil
+}
+
+// defaultStateReason mirrors the reason GitHub records for a state change
+// made without an explicit reason
+func defaultStateReason(state string) string {
+	if state == "closed" {
+		return "completed"
+	}
+	return "reopened"
+}
+
+// Capabilities reports Caps
+func (m *MockProvider) Capabilities() ProviderCapabilities {
+	return m.Caps
+}
+
+// SetPinned pins or unpins a mock issue
+func (m *MockProvider) SetPinned(ctx context.Context, token string, repo string, issueNumber int, pinned bool) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.takeFailure("SetPinned"); err != nil {
+		return err
+	}
+
+	key := issueKey(repo, issueNumber)
+	issue, ok := m.issues[key]
+	if !ok {
+		return fmt.Errorf("%w: %s#%d", ErrIssueNotFound, repo, issueNumber)
+	}
+
+	issue.Pinned = pinned
 	return nil
 }
 
+// Lock locks a mock issue
+func (m *MockProvider) Lock(ctx context.Context, token string, repo string, issueNumber int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.LockCalled++
+	if err := m.takeFailure("Lock"); err != nil {
+		return err
+	}
+
+	if m.LockFunc != nil {
+		return m.LockFunc(ctx, token, repo, issueNumber)
+	}
+
+	key := issueKey(repo, issueNumber)
+	issue, ok := m.issues[key]
+	if !ok {
+		return fmt.Errorf("%w: %s#%d", ErrIssueNotFound, repo, issueNumber)
+	}
+
+	issue.Locked = true
+	return nil
+}
+
+// EnsureLabels records the labels of a mock repository, replacing those with
+// the same name
+func (m *MockProvider) EnsureLabels(ctx context.Context, token string, repo string, labels []LabelSpec) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.EnsureLabelsCalled++
+	if err := m.takeFailure("EnsureLabels"); err != nil {
+		return err
+	}
+
+	for _, label := range labels {
+		i := slices.IndexFunc(m.labels[repo], func(l LabelSpec) bool { return strings.EqualFold(l.Name, label.Name) })
+		if i < 0 {
+			m.labels[repo] = append(m.labels[repo], label)
+			continue
+		}
+		m.labels[repo][i] = label
+	}
+	return nil
+}
+
+// RepoLabels returns the labels EnsureLabels recorded for a mock repository
+func (m *MockProvider) RepoLabels(repo string) []LabelSpec {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return slices.Clone(m.labels[repo])
+}
+
+// AddComment records a comment on a mock issue
+func (m *MockProvider) AddComment(ctx context.Context, token string, repo string, issueNumber int, body string) (*Comment, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.takeFailure("AddComment"); err != nil {
+		return nil, err
+	}
+
+	key := issueKey(repo, issueNumber)
+	if _, ok := m.issues[key]; !ok {
+		return nil, fmt.Errorf("%w: %s#%d", ErrIssueNotFound, repo, issueNumber)
+	}
+
+	m.lastCommentID++
+	comment := &Comment{ID: m.lastCommentID, Body: body}
+	m.comments[key] = append(m.comments[key], comment)
+	c := *comment
+	return &c, nil
+}
+
+// ListComments returns the comments on a mock issue
+func (m *MockProvider) ListComments(ctx context.Context, token string, repo string, issueNumber int) ([]*Comment, error) {
+	// Write lock: FailNext failures are used up
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.takeFailure("ListComments"); err != nil {
+		return nil, err
+	}
+
+	key := issueKey(repo, issueNumber)
+	if _, ok := m.issues[key]; !ok {
+		return nil, fmt.Errorf("%w: %s#%d", ErrIssueNotFound, repo, issueNumber)
+	}
+
+	comments := make([]*Comment, 0, len(m.comments[key]))
+	for _, comment := range m.comments[key] {
+		c := *comment
+		comments = append(comments, &c)
+	}
+	return comments, nil
+}
+
+// Transfer moves a mock issue to toRepo under a new number
+func (m *MockProvider) Transfer(ctx context.Context, token string, fromRepo string, issueNumber int, toRepo string) (*Issue, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.takeFailure("Transfer"); err != nil {
+		return nil, err
+	}
+
+	key := issueKey(fromRepo, issueNumber)
+	issue, ok := m.issues[key]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s#%d", ErrIssueNotFound, fromRepo, issueNumber)
+	}
+
+	delete(m.issues, key)
+	issue.Number = m.nextNumber
+	issue.URL = fmt.Sprintf("https://github.com/%s/issues/%d", toRepo, m.nextNumber)
+	m.issues[issueKey(toRepo, m.nextNumber)] = issue
+	m.nextNumber++
+
+	return issue.clone(), nil
+}
+
+// DeleteComment removes a comment, as if it was deleted on the provider.
+func (m *MockProvider) DeleteComment(repo string, number int, id int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := issueKey(repo, number)
+	m.comments[key] = slices.DeleteFunc(m.comments[key], func(c *Comment) bool { return c.ID == id })
+}
+
+// DeleteIssue removes an issue, as if it was deleted on the provider.
+func (m *MockProvider) DeleteIssue(repo string, number int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.issues, issueKey(repo, number))
+}
+
 // Reset clears all mock state
 func (m *MockProvider) Reset() {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	m.issues = make(map[string]*Issue)
+	m.comments = make(map[string][]*Comment)
+	m.labels = make(map[string][]LabelSpec)
+	m.failNext = make(map[string]*injectedFailure)
 	m.nextNumber = 1
+	m.lastCommentID = 0
 	m.CreateCalled = 0
 	m.GetCalled = 0
 	m.UpdateCalled = 0
 	m.CloseCalled = 0
+	m.DeleteCalled = 0
+	m.LockCalled = 0
+	m.EnsureLabelsCalled = 0
+	m.ListCalled = 0
+	m.SearchCalled = 0
 }
 
-// GetIssue returns a stored issue for inspection in tests
+// CreateCount returns how many times Create was called. Use it instead of
+// reading CreateCalled when reconciles may be running concurrently.
+func (m *MockProvider) CreateCount() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.CreateCalled
+}
+
+// GetCount returns how many times Get was called
+func (m *MockProvider) GetCount() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.GetCalled
+}
+
+// UpdateCount returns how many times Update was called
+func (m *MockProvider) UpdateCount() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.UpdateCalled
+}
+
+// CloseCount returns how many times Close was called
+func (m *MockProvider) CloseCount() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.CloseCalled
+}
+
+// DeleteCount returns how many times Delete was called
+func (m *MockProvider) DeleteCount() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.DeleteCalled
+}
+
+// LockCount returns how many times Lock was called
+func (m *MockProvider) LockCount() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.LockCalled
+}
+
+// EnsureLabelsCount returns how many times EnsureLabels was called
+func (m *MockProvider) EnsureLabelsCount() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.EnsureLabelsCalled
+}
+
+// ListCount returns how many times List was called
+func (m *MockProvider) ListCount() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.ListCalled
+}
+
+// SearchCount returns how many times Search was called
+func (m *MockProvider) SearchCount() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.SearchCalled
+}
+
+// GetIssue returns a stored issue for inspection in tests. The issue is shared
+// with the mock, so use Get instead while reconciles may still be running.
 func (m *MockProvider) GetIssue(repo string, number int) *Issue {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
@@ -232,6 +762,8 @@ func (m *MockProvider) Handler() http.Handler {
 			"getCalled":    m.GetCalled,
 			"updateCalled": m.UpdateCalled,
 			"closeCalled":  m.CloseCalled,
+			"deleteCalled": m.DeleteCalled,
+			"listCalled":   m.ListCalled,
 			"totalIssues":  len(m.issues),
 		}
 
@@ -241,3 +773,14 @@ func (m *MockProvider) Handler() http.Handler {
 
 	return mux
 }
+
+// Comments returns the bodies of the comments posted on an issue, oldest first
+func (m *MockProvider) Comments(repo string, number int) []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var bodies []string
+	for _, comment := range m.comments[issueKey(repo, number)] {
+		bodies = append(bodies, comment.Body)
+	}
+	return bodies
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package providers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// errNotFound is wrapped by sendJSON errors for 404 responses.
+var errNotFound = errors.New("not found")
+
+// sendJSON sends a request to a REST API at baseURL+path with the given
+// headers and a JSON body, if body is not nil, and decodes the JSON response
+// into out, if it is not nil. A 429 response becomes a RateLimitError, and
+// the error for a 404 response wraps errNotFound. It
+// returns the response headers for pagination. A nil client means
+// http.DefaultClient.
+func sendJSON(ctx context.Context, client *http.Client, method, baseURL, path string, query url.Values, header http.Header, body, out any) (http.Header, error) {
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reqBody = bytes.NewReader(data)
+	}
+	target := baseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, reqBody)
+	if err != nil {
+		return nil, err
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	observeRate(ctx, resp.Header)
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return nil, &RateLimitError{Reset: rateLimitReset(resp.Header)}
+	}
+	if resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		err := fmt.Errorf("%s %s: %s: %s", method, path, resp.Status, strings.TrimSpace(string(msg)))
+		if resp.StatusCode == http.StatusNotFound {
+			err = fmt.Errorf("%w: %w", errNotFound, err)
+		}
+		return nil, err
+	}
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return nil, fmt.Errorf("failed to decode response: %w", err)
+		}
+	}
+	return resp.Header, nil
+}
+
+// rateLimitReset reads when a rate limit resets from the RateLimit-Reset
+// header (a Unix time) or Retry-After (seconds), defaulting to a minute
+func rateLimitReset(h http.Header) time.Time {
+	if reset, err := strconv.ParseInt(h.Get("RateLimit-Reset"), 10, 64); err == nil {
+		return time.Unix(reset, 0)
+	}
+	if after, err := strconv.Atoi(h.Get("Retry-After")); err == nil {
+		return time.Now().Add(time.Duration(after) * time.Second)
+	}
+	return time.Now().Add(time.Minute)
+}
+
+// observeRate passes the remaining quota in the rate limit headers h, as
+// X-RateLimit-Remaining (GitHub, Gitea) or RateLimit-Remaining (GitLab), to
+// the RateObserver of ctx. Without one, or without the headers, it does nothing.
+func observeRate(ctx context.Context, h http.Header) {
+	observe, _ := ctx.Value(rateObserverKey{}).(RateObserver)
+	if observe == nil {
+		return
+	}
+	for _, prefix := range []string{"X-RateLimit-", "RateLimit-"} {
+		remaining, err := strconv.Atoi(h.Get(prefix + "Remaining"))
+		if err != nil {
+			continue
+		}
+		var reset time.Time
+		if unix, err := strconv.ParseInt(h.Get(prefix+"Reset"), 10, 64); err == nil {
+			reset = time.Unix(unix, 0)
+		}
+		observe(remaining, reset)
+		return
+	}
+}
+
+// rateTransport reports the rate limit headers of every response to the
+// RateObserver of its request's context, for clients that do not go
+// through sendJSON.
+type rateTransport struct {
+	base http.RoundTripper
+}
+
+func (t rateTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err == nil {
+		observeRate(req.Context(), resp.Header)
+	}
+	return resp, err
+}
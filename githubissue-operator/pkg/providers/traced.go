@@ -0,0 +1,206 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package providers
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracedProvider wraps an IssueProvider and records every call as a span,
+// a child of the span in the call's context. The optional operations are
+// passed through as well, and Capabilities are those of the wrapped
+// provider, so wrapping does not change what the reconciler can do.
+type TracedProvider struct {
+	IssueProvider
+	// Tracer starts the spans; a no-op tracer makes the wrapper free
+	Tracer trace.Tracer
+}
+
+// NewTracedProvider wraps p so that its calls are traced with tracer
+func NewTracedProvider(p IssueProvider, tracer trace.Tracer) *TracedProvider {
+	return &TracedProvider{IssueProvider: p, Tracer: tracer}
+}
+
+// start begins the span of a call to operation
+func (p *TracedProvider) start(ctx context.Context, operation string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return p.Tracer.Start(ctx, "IssueProvider."+operation,
+		trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+}
+
+// endSpan finishes span, marking it failed if the call returned err
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// issueAttributes identify the issue a call is about
+func issueAttributes(repo string, issueNumber int) []attribute.KeyValue {
+	return []attribute.KeyValue{attribute.String("repo", repo), attribute.Int("issue.number", issueNumber)}
+}
+
+// Create traces IssueProvider.Create
+func (p *TracedProvider) Create(ctx context.Context, token string, input CreateIssueInput) (*Issue, error) {
+	ctx, span := p.start(ctx, "Create", attribute.String("repo", input.Repo))
+	issue, err := p.IssueProvider.Create(ctx, token, input)
+	endSpan(span, err)
+	return issue, err
+}
+
+// Get traces IssueProvider.Get
+func (p *TracedProvider) Get(ctx context.Context, token string, repo string, issueNumber int) (*Issue, error) {
+	ctx, span := p.start(ctx, "Get", issueAttributes(repo, issueNumber)...)
+	issue, err := p.IssueProvider.Get(ctx, token, repo, issueNumber)
+	endSpan(span, err)
+	return issue, err
+}
+
+// List traces IssueProvider.List
+func (p *TracedProvider) List(ctx context.Context, token string, repo string, opts ListIssuesOptions) ([]*Issue, error) {
+	ctx, span := p.start(ctx, "List", attribute.String("repo", repo))
+	issues, err := p.IssueProvider.List(ctx, token, repo, opts)
+	endSpan(span, err)
+	return issues, err
+}
+
+// Update traces IssueProvider.Update
+func (p *TracedProvider) Update(ctx context.Context, token string, repo string, issueNumber int, input UpdateIssueInput) (*Issue, error) {
+	ctx, span := p.start(ctx, "Update", issueAttributes(repo, issueNumber)...)
+	issue, err := p.IssueProvider.Update(ctx, token, repo, issueNumber, input)
+	endSpan(span, err)
+	return issue, err
+}
+
+// Close traces IssueProvider.Close
+func (p *TracedProvider) Close(ctx context.Context, token string, repo string, issueNumber int) error {
+	ctx, span := p.start(ctx, "Close", issueAttributes(repo, issueNumber)...)
+	err := p.IssueProvider.Close(ctx, token, repo, issueNumber)
+	endSpan(span, err)
+	return err
+}
+
+// Reopen traces IssueProvider.Reopen
+func (p *TracedProvider) Reopen(ctx context.Context, token string, repo string, issueNumber int) error {
+	ctx, span := p.start(ctx, "Reopen", issueAttributes(repo, issueNumber)...)
+	err := p.IssueProvider.Reopen(ctx, token, repo, issueNumber)
+	endSpan(span, err)
+	return err
+}
+
+// Search traces IssueProvider.Search
+func (p *TracedProvider) Search(ctx context.Context, token string, query string) ([]*Issue, error) {
+	ctx, span := p.start(ctx, "Search", attribute.String("query", query))
+	issues, err := p.IssueProvider.Search(ctx, token, query)
+	endSpan(span, err)
+	return issues, err
+}
+
+// Capabilities reports the capabilities of the wrapped provider
+func (p *TracedProvider) Capabilities() ProviderCapabilities {
+	return CapabilitiesOf(p.IssueProvider)
+}
+
+// Delete traces Deleter.Delete of the wrapped provider
+func (p *TracedProvider) Delete(ctx context.Context, token string, repo string, issueNumber int) error {
+	deleter, ok := p.IssueProvider.(Deleter)
+	if !ok {
+		return fmt.Errorf("issue provider cannot delete issues")
+	}
+	ctx, span := p.start(ctx, "Delete", issueAttributes(repo, issueNumber)...)
+	err := deleter.Delete(ctx, token, repo, issueNumber)
+	endSpan(span, err)
+	return err
+}
+
+// SetPinned traces Pinner.SetPinned of the wrapped provider
+func (p *TracedProvider) SetPinned(ctx context.Context, token string, repo string, issueNumber int, pinned bool) error {
+	pinner, ok := p.IssueProvider.(Pinner)
+	if !ok {
+		return fmt.Errorf("issue provider cannot pin issues")
+	}
+	ctx, span := p.start(ctx, "SetPinned", issueAttributes(repo, issueNumber)...)
+	err := pinner.SetPinned(ctx, token, repo, issueNumber, pinned)
+	endSpan(span, err)
+	return err
+}
+
+// Lock traces Locker.Lock of the wrapped provider
+func (p *TracedProvider) Lock(ctx context.Context, token string, repo string, issueNumber int) error {
+	locker, ok := p.IssueProvider.(Locker)
+	if !ok {
+		return fmt.Errorf("issue provider cannot lock issues")
+	}
+	ctx, span := p.start(ctx, "Lock", issueAttributes(repo, issueNumber)...)
+	err := locker.Lock(ctx, token, repo, issueNumber)
+	endSpan(span, err)
+	return err
+}
+
+// EnsureLabels traces LabelManager.EnsureLabels of the wrapped provider
+func (p *TracedProvider) EnsureLabels(ctx context.Context, token string, repo string, labels []LabelSpec) error {
+	manager, ok := p.IssueProvider.(LabelManager)
+	if !ok {
+		return fmt.Errorf("issue provider cannot manage labels")
+	}
+	ctx, span := p.start(ctx, "EnsureLabels", attribute.String("repo", repo))
+	err := manager.EnsureLabels(ctx, token, repo, labels)
+	endSpan(span, err)
+	return err
+}
+
+// AddComment traces Commenter.AddComment of the wrapped provider
+func (p *TracedProvider) AddComment(ctx context.Context, token string, repo string, issueNumber int, body string) (*Comment, error) {
+	commenter, ok := p.IssueProvider.(Commenter)
+	if !ok {
+		return nil, fmt.Errorf("issue provider cannot comment on issues")
+	}
+	ctx, span := p.start(ctx, "AddComment", issueAttributes(repo, issueNumber)...)
+	comment, err := commenter.AddComment(ctx, token, repo, issueNumber, body)
+	endSpan(span, err)
+	return comment, err
+}
+
+// ListComments traces Commenter.ListComments of the wrapped provider
+func (p *TracedProvider) ListComments(ctx context.Context, token string, repo string, issueNumber int) ([]*Comment, error) {
+	commenter, ok := p.IssueProvider.(Commenter)
+	if !ok {
+		return nil, fmt.Errorf("issue provider cannot list comments")
+	}
+	ctx, span := p.start(ctx, "ListComments", issueAttributes(repo, issueNumber)...)
+	comments, err := commenter.ListComments(ctx, token, repo, issueNumber)
+	endSpan(span, err)
+	return comments, err
+}
+
+// Transfer traces Transferer.Transfer of the wrapped provider
+func (p *TracedProvider) Transfer(ctx context.Context, token string, fromRepo string, issueNumber int, toRepo string) (*Issue, error) {
+	transferer, ok := p.IssueProvider.(Transferer)
+	if !ok {
+		return nil, fmt.Errorf("issue provider cannot transfer issues")
+	}
+	ctx, span := p.start(ctx, "Transfer", append(issueAttributes(fromRepo, issueNumber), attribute.String("to", toRepo))...)
+	issue, err := transferer.Transfer(ctx, token, fromRepo, issueNumber, toRepo)
+	endSpan(span, err)
+	return issue, err
+}
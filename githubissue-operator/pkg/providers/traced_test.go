@@ -0,0 +1,92 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package providers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// newTestTracedProvider wraps a fresh MockProvider into a TracedProvider
+// recording into an in-memory exporter
+func newTestTracedProvider(t *testing.T) (*TracedProvider, *MockProvider, *tracetest.InMemoryExporter) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	t.Cleanup(func() { _ = tp.Shutdown(context.Background()) })
+	mock := NewMockProvider()
+	return NewTracedProvider(mock, tp.Tracer("test")), mock, exporter
+}
+
+func TestTracedProvider_SpansAreChildrenOfTheCaller(t *testing.T) {
+	p, _, exporter := newTestTracedProvider(t)
+	ctx, parent := p.Tracer.Start(context.Background(), "Reconcile")
+
+	created, err := p.Create(ctx, "token", CreateIssueInput{Repo: "owner/repo", Title: "Bug"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Close(ctx, "token", "owner/repo", created.Number); err != nil {
+		t.Fatal(err)
+	}
+	parent.End()
+
+	spans := exporter.GetSpans()
+	if len(spans) != 3 {
+		t.Fatalf("got %d spans, want Create, Close and the parent", len(spans))
+	}
+	for i, name := range []string{"IssueProvider.Create", "IssueProvider.Close"} {
+		if spans[i].Name != name {
+			t.Errorf("span %d = %q, want %q", i, spans[i].Name, name)
+		}
+		if spans[i].Parent.SpanID() != parent.SpanContext().SpanID() {
+			t.Errorf("%s is not a child of the caller's span", name)
+		}
+	}
+}
+
+func TestTracedProvider_RecordsErrors(t *testing.T) {
+	p, mock, exporter := newTestTracedProvider(t)
+	mock.FailNext("Create", 1, errors.New("502 Bad Gateway"))
+
+	if _, err := p.Create(context.Background(), "token", CreateIssueInput{Repo: "owner/repo", Title: "Bug"}); err == nil {
+		t.Fatal("Create succeeded, want the injected error")
+	}
+	spans := exporter.GetSpans()
+	if len(spans) != 1 || spans[0].Status.Code != codes.Error {
+		t.Fatalf("spans = %+v, want one failed span", spans)
+	}
+}
+
+func TestTracedProvider_KeepsOptionalOperations(t *testing.T) {
+	p, mock, _ := newTestTracedProvider(t)
+	if CapabilitiesOf(p) != mock.Caps {
+		t.Errorf("Capabilities = %+v, want those of the wrapped provider %+v", CapabilitiesOf(p), mock.Caps)
+	}
+	var _ interface {
+		Deleter
+		Pinner
+		Locker
+		Commenter
+		LabelManager
+		Transferer
+	} = p
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	issuesv1 "github.com/zhangbiao2009/controller_exercise/githubissue-operator/api/v1"
+	"github.com/zhangbiao2009/controller_exercise/githubissue-operator/pkg/providers"
+)
+
+var _ = Describe("GitHubIssue controller against a real API server", Ordered, func() {
+	const (
+		resourceName = "integration-issue"
+		namespace    = "default"
+		secretName   = "github-token"
+		repo         = "owner/repo"
+		timeout      = 10 * time.Second
+		interval     = 100 * time.Millisecond
+	)
+
+	ctx := context.Background()
+	namespacedName := types.NamespacedName{Name: resourceName, Namespace: namespace}
+
+	BeforeAll(func() {
+		Expect(k8sClient.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: secretName, Namespace: namespace},
+			Data:       map[string][]byte{"token": []byte("fake-token")},
+		})).To(Succeed())
+	})
+
+	It("should reject a CR missing required fields", func() {
+		invalid := &issuesv1.GitHubIssue{
+			ObjectMeta: metav1.ObjectMeta{Name: "invalid-issue", Namespace: namespace},
+			Spec:       issuesv1.GitHubIssueSpec{Title: "no repo"},
+		}
+		Expect(apierrors.IsInvalid(k8sClient.Create(ctx, invalid))).To(BeTrue())
+	})
+
+	It("should create the remote issue and report it in status", func() {
+		Expect(k8sClient.Create(ctx, &issuesv1.GitHubIssue{
+			ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: namespace},
+			Spec: issuesv1.GitHubIssueSpec{
+				Repo:           repo,
+				Title:          "Integration Issue",
+				Body:           "Created by the integration suite",
+				Labels:         []string{"bug"},
+				TokenSecretRef: secretName,
+			},
+		})).To(Succeed())
+
+		Eventually(func(g Gomega) {
+			var issue issuesv1.GitHubIssue
+			g.Expect(k8sClient.Get(ctx, namespacedName, &issue)).To(Succeed())
+			g.Expect(issue.Finalizers).NotTo(BeEmpty())
+			g.Expect(issue.Status.IssueNumber).To(Equal(1))
+			g.Expect(issue.Status.State).To(Equal("open"))
+		}, timeout, interval).Should(Succeed())
+
+		Expect(remoteTitle(mockProvider, repo, 1)).To(Equal("Integration Issue"))
+	})
+
+	It("should push spec changes to the remote issue", func() {
+		Eventually(func() error {
+			var issue issuesv1.GitHubIssue
+			if err := k8sClient.Get(ctx, namespacedName, &issue); err != nil {
+				return err
+			}
+			issue.Spec.Title = "Updated Integration Issue"
+			return k8sClient.Update(ctx, &issue)
+		}, timeout, interval).Should(Succeed())
+
+		Eventually(func() string {
+			return remoteTitle(mockProvider, repo, 1)
+		}, timeout, interval).Should(Equal("Updated Integration Issue"))
+	})
+
+	It("should close the remote issue and let the CR go on deletion", func() {
+		var issue issuesv1.GitHubIssue
+		Expect(k8sClient.Get(ctx, namespacedName, &issue)).To(Succeed())
+		Expect(k8sClient.Delete(ctx, &issue)).To(Succeed())
+
+		Eventually(func() bool {
+			return apierrors.IsNotFound(k8sClient.Get(ctx, namespacedName, &issuesv1.GitHubIssue{}))
+		}, timeout, interval).Should(BeTrue())
+		Expect(remoteState(mockProvider, repo, 1)).To(Equal("closed"))
+	})
+})
+
+// remoteTitle reads the title of a mock issue. It goes through Get, which copies
+// the issue under the mock's lock, because the manager may be updating it.
+func remoteTitle(m *providers.MockProvider, repo string, number int) string {
+	if issue, err := m.Get(context.Background(), "", repo, number); err == nil {
+		return issue.Title
+	}
+	return ""
+}
+
+// remoteState reads the state of a mock issue, like remoteTitle.
+func remoteState(m *providers.MockProvider, repo string, number int) string {
+	if issue, err := m.Get(context.Background(), "", repo, number); err == nil {
+		return issue.State
+	}
+	return ""
+}
@@ -17,12 +17,37 @@ limitations under the License.
 package v1
 
 import (
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
+// SidecarStartOrder controls when Website sidecars start relative to the web server.
+type SidecarStartOrder string
+
+const (
+	// SidecarStartOrderParallel starts sidecars together with the web server.
+	SidecarStartOrderParallel SidecarStartOrder = "Parallel"
+	// SidecarStartOrderBeforeWebServer starts sidecars before the web server.
+	SidecarStartOrderBeforeWebServer SidecarStartOrder = "BeforeWebServer"
+)
+
+// ServerType selects the web server a Website runs.
+type ServerType string
+
+const (
+	// ServerTypeNginx runs nginx, serving from /usr/share/nginx/html.
+	ServerTypeNginx ServerType = "nginx"
+	// ServerTypeHttpd runs Apache httpd, serving from /usr/local/apache2/htdocs.
+	ServerTypeHttpd ServerType = "httpd"
+	// ServerTypeCaddy runs Caddy, serving from /usr/share/caddy.
+	ServerTypeCaddy ServerType = "caddy"
+)
+
 // WebsiteSpec defines the desired state of Website
 type WebsiteSpec struct {
 	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
@@ -36,6 +61,207 @@ type WebsiteSpec struct {
 	// +kubebuilder:default=1
 	// +kubebuilder:validation:Minimum=1
 	Replicas int32 `json:"replicas,omitempty"`
+
+	// Port is the port the Service exposes. Traffic is forwarded to the web
+	// server on port 80.
+	// +kubebuilder:default=80
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	Port int32 `json:"port,omitempty"`
+
+	// DeploymentAnnotations are added to the web server Deployments, e.g. Argo
+	// CD sync waves. Pod annotations are unaffected. Keys under
+	// deployment.kubernetes.io/ and sites.davidweb.com/ are reserved.
+	// +optional
+	DeploymentAnnotations map[string]string `json:"deploymentAnnotations,omitempty"`
+
+	// ServiceAnnotations are added to the Service, e.g. cloud load balancer
+	// settings. Keys under sites.davidweb.com/ are reserved.
+	// +optional
+	ServiceAnnotations map[string]string `json:"serviceAnnotations,omitempty"`
+
+	// AntiAffinity spreads replicas across nodes with a preferred pod
+	// anti-affinity, so one node failure does not take the whole site down.
+	// +optional
+	AntiAffinity bool `json:"antiAffinity,omitempty"`
+
+	// ServerType is the web server the site runs: "nginx" (the default),
+	// "httpd" or "caddy". It picks the default image, container name and
+	// command, which copies the synced content from /git/current into the
+	// server's html root before starting it.
+	// +kubebuilder:validation:Enum=nginx;httpd;caddy
+	// +kubebuilder:default=nginx
+	// +optional
+	ServerType ServerType `json:"serverType,omitempty"`
+
+	// Image is the web server image. Defaults to nginx:alpine, httpd:alpine or
+	// caddy:alpine by ServerType. Images of another server should also set
+	// Command and/or Args. The server must listen on port 80.
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// ContainerName is the name of the web server container. Defaults to the
+	// ServerType, e.g. "nginx".
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +optional
+	ContainerName string `json:"containerName,omitempty"`
+
+	// Command overrides the web server container entrypoint. When set without
+	// Args, the default arguments are dropped too.
+	// +optional
+	Command []string `json:"command,omitempty"`
+
+	// Args overrides the web server container arguments. The synced site is
+	// available under /git/current.
+	// +optional
+	Args []string `json:"args,omitempty"`
+
+	// TerminationGracePeriodSeconds is how long pods get to shut down before
+	// they are killed. Unset uses the Kubernetes default of 30 seconds.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	TerminationGracePeriodSeconds *int64 `json:"terminationGracePeriodSeconds,omitempty"`
+
+	// GracefulShutdown adds a preStop hook that runs "nginx -s quit" in the web
+	// server container and waits for nginx to exit, so open connections are
+	// drained before the pod stops. Requires ServerType nginx.
+	// +optional
+	GracefulShutdown bool `json:"gracefulShutdown,omitempty"`
+
+	// ContentSizeLimit caps the emptyDir the site is cloned into, so a large
+	// repository cannot fill the node's disk. Pods exceeding it are evicted.
+	// Defaults to 1Gi.
+	// +optional
+	ContentSizeLimit *resource.Quantity `json:"contentSizeLimit,omitempty"`
+
+	// Volumes are extra pod volumes added alongside the managed "web-content" volume,
+	// e.g. shared caches or Secrets mounted as files. Names must not collide with
+	// managed volumes.
+	// +optional
+	Volumes []corev1.Volume `json:"volumes,omitempty"`
+
+	// VolumeMounts are extra mounts added to the web server container alongside
+	// the managed "web-content" mount.
+	// +optional
+	VolumeMounts []corev1.VolumeMount `json:"volumeMounts,omitempty"`
+
+	// AutoRestartOnDegraded restarts the pods (like "kubectl rollout restart")
+	// when only some replicas have been available for longer than the
+	// controller's threshold, to unstick rollouts that stopped making progress.
+	// +optional
+	AutoRestartOnDegraded bool `json:"autoRestartOnDegraded,omitempty"`
+
+	// Sidecars are extra containers run in each pod, e.g. log shippers or
+	// exporters. Names must not collide with "git-sync" or the web server.
+	// +optional
+	Sidecars []corev1.Container `json:"sidecars,omitempty"`
+
+	// SidecarStartOrder controls when sidecars start. With "Parallel" (the
+	// default) they start alongside the web server once git-sync has finished.
+	// With "BeforeWebServer" they run as native sidecars: started in order after
+	// git-sync and before the web server, and kept running for the pod's lifetime.
+	// +kubebuilder:validation:Enum=Parallel;BeforeWebServer
+	// +kubebuilder:default=Parallel
+	// +optional
+	SidecarStartOrder SidecarStartOrder `json:"sidecarStartOrder,omitempty"`
+
+	// ReadinessGates are extra pod conditions that must be True before a pod
+	// counts as ready, set by a service mesh or another external controller.
+	// +optional
+	ReadinessGates []corev1.PodReadinessGate `json:"readinessGates,omitempty"`
+
+	// Schedule scales the site down outside a time window, e.g. business hours.
+	// Replicas applies inside the window.
+	// +optional
+	Schedule *WebsiteSchedule `json:"schedule,omitempty"`
+
+	// Canary runs part of the replicas from a second "<name>-canary"
+	// Deployment with another image. Removing it deletes that Deployment.
+	// +optional
+	Canary *WebsiteCanary `json:"canary,omitempty"`
+
+	// Autoscaling sizes the site with a HorizontalPodAutoscaler instead of
+	// Replicas, which is then ignored. Removing it hands the replica count back
+	// to Replicas and deletes the autoscaler. Cannot be combined with Schedule
+	// or Canary.
+	// +optional
+	Autoscaling *WebsiteAutoscaling `json:"autoscaling,omitempty"`
+
+	// Metrics exposes nginx metrics in Prometheus format through an exporter
+	// sidecar and a "metrics" port on the Service.
+	// +optional
+	Metrics *WebsiteMetrics `json:"metrics,omitempty"`
+}
+
+// WebsiteMetrics configures the nginx-prometheus-exporter sidecar of a Website.
+type WebsiteMetrics struct {
+	// Enabled runs the exporter in every pod. It scrapes nginx's stub_status,
+	// which the default nginx command serves on 127.0.0.1:8081/stub_status;
+	// a web server with a custom Command or Args must serve it there itself.
+	// Requires ServerType nginx.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+}
+
+// WebsiteAutoscaling configures the HorizontalPodAutoscaler of a Website,
+// which scales its Deployment on CPU utilization.
+type WebsiteAutoscaling struct {
+	// MinReplicas is the lowest number of nginx pods. Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+
+	// MaxReplicas is the highest number of nginx pods.
+	// +kubebuilder:validation:Minimum=1
+	MaxReplicas int32 `json:"maxReplicas"`
+
+	// TargetCPUUtilizationPercentage is the average CPU utilization, relative
+	// to the pods' requests, the autoscaler aims for. Defaults to 80.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TargetCPUUtilizationPercentage *int32 `json:"targetCPUUtilizationPercentage,omitempty"`
+
+	// Behavior tunes how fast the autoscaler scales up and down, e.g. a longer
+	// scaleDown stabilization window to ride out traffic dips. Unset uses the
+	// Kubernetes defaults.
+	// +optional
+	Behavior *autoscalingv2.HorizontalPodAutoscalerBehavior `json:"behavior,omitempty"`
+}
+
+// WebsiteCanary splits a Website's replicas between the stable image and a
+// canary image. Both sets of pods sit behind the Website's Service, which
+// spreads connections across pods, so traffic follows the replica split.
+type WebsiteCanary struct {
+	// Image is the web server image the canary pods run.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Image string `json:"image"`
+
+	// Weight is the percentage of replicas, and thus traffic, that goes to the
+	// canary. It is rounded to whole pods, with at least one canary pod when
+	// non-zero.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	Weight int32 `json:"weight"`
+}
+
+// WebsiteSchedule switches a Website between Replicas and DownReplicas on cron
+// boundaries. Cron expressions use the standard five fields and are evaluated in UTC.
+type WebsiteSchedule struct {
+	// ScaleUpCron is when the site scales up to spec.replicas, e.g. "0 9 * * 1-5".
+	// +kubebuilder:validation:Required
+	ScaleUpCron string `json:"scaleUpCron"`
+
+	// ScaleDownCron is when the site scales down to DownReplicas, e.g. "0 18 * * 1-5".
+	// +kubebuilder:validation:Required
+	ScaleDownCron string `json:"scaleDownCron"`
+
+	// DownReplicas is the number of nginx pods to run outside the window.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	DownReplicas int32 `json:"downReplicas,omitempty"`
 }
 
 // WebsiteStatus defines the observed state of Website
@@ -49,6 +275,28 @@ type WebsiteStatus struct {
 
 	// AvailableReplicas is the number of ready pods
 	AvailableReplicas int32 `json:"availableReplicas,omitempty"`
+
+	// CanaryAvailableReplicas is the number of ready canary pods; see spec.canary.
+	// +optional
+	CanaryAvailableReplicas int32 `json:"canaryAvailableReplicas,omitempty"`
+
+	// DegradedSince is when the Website started running with some, but not
+	// all, replicas available. Cleared once it recovers or is fully down.
+	// +optional
+	DegradedSince *metav1.Time `json:"degradedSince,omitempty"`
+
+	// LastAutoRestart is when the pods were last restarted because the Website
+	// stayed degraded; see spec.autoRestartOnDegraded.
+	// +optional
+	LastAutoRestart *metav1.Time `json:"lastAutoRestart,omitempty"`
+
+	// ConsecutiveFailures counts reconciles in a row that hit a transient API
+	// error; it drives the requeue backoff and resets after a successful reconcile.
+	// +optional
+	ConsecutiveFailures int32 `json:"consecutiveFailures,omitempty"`
+
+	// Conditions for status reporting
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 //+kubebuilder:object:root=true
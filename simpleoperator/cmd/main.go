@@ -17,14 +17,17 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"crypto/tls"
 	"flag"
+	"math"
 	"os"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	"go.opentelemetry.io/otel"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
@@ -66,6 +69,27 @@ func main() {
 		"If set the metrics endpoint is served securely")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	var config controller.ReconcileConfig
+	flag.BoolVar(&config.AuditOnly, "audit-only", false,
+		"Compute desired state and log what would change, without writing to the cluster.")
+	flag.BoolVar(&config.DryRun, "dry-run", false,
+		"Send all writes to the API server as dry runs, so they are validated but not persisted.")
+	flag.StringVar(&config.DefaultImage, "default-image", "",
+		"Web server image for nginx Websites that do not set spec.image. Empty means nginx:alpine.")
+	flag.StringVar(&config.GitSyncImage, "git-sync-image", "",
+		"Image of the git-sync init container. Empty means the built-in version.")
+	flag.StringVar(&config.ExporterImage, "exporter-image", "",
+		"Image of the nginx-prometheus-exporter sidecar of Websites with metrics enabled. Empty means the built-in version.")
+	var defaultReplicas int
+	flag.IntVar(&defaultReplicas, "default-replicas", 1,
+		"Replicas for Websites whose spec.replicas is unset.")
+	var enableWebhooks bool
+	flag.BoolVar(&enableWebhooks, "enable-webhooks", false,
+		"Serve the Website validating webhook. Requires serving certificates and the config/webhook manifests.")
+	var otelEndpoint string
+	flag.StringVar(&otelEndpoint, "otel-endpoint", "",
+		"OTLP/gRPC endpoint URL, e.g. http://otel-collector:4317, to export reconcile and client "+
+			"traces to. Empty disables tracing.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -74,6 +98,12 @@ func main() {
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
+	if defaultReplicas < 1 || defaultReplicas > math.MaxInt32 {
+		setupLog.Error(nil, "--default-replicas must be a positive number", "defaultReplicas", defaultReplicas)
+		os.Exit(1)
+	}
+	config.DefaultReplicas = int32(defaultReplicas)
+
 	// if the enable-http2 flag is false (the default), http/2 should be disabled
 	// due to its vulnerabilities. More specifically, disabling http/2 will
 	// prevent from being vulnerable to the HTTP/2 Stream Cancellation and
@@ -122,20 +152,45 @@ func main() {
 		os.Exit(1)
 	}
 
+	if config.AuditOnly {
+		setupLog.Info("running in audit-only mode, no changes will be made")
+	}
+	if config.DryRun {
+		setupLog.Info("running in dry-run mode, writes are validated but not persisted")
+	}
+
+	// Without an endpoint the global tracer provider stays a no-op, and so do
+	// the spans of reconciles and client calls
+	if otelEndpoint != "" {
+		tracerProvider, err := newTracerProvider(context.Background(), otelEndpoint)
+		if err != nil {
+			setupLog.Error(err, "unable to set up tracing")
+			os.Exit(1)
+		}
+		defer func() { _ = tracerProvider.Shutdown(context.Background()) }()
+		otel.SetTracerProvider(tracerProvider)
+		setupLog.Info("exporting traces", "endpoint", otelEndpoint)
+	}
+
 	if err = (&controller.WebsiteReconciler{
 		Client: mgr.GetClient(),
 		Scheme: mgr.GetScheme(),
+		Config: config,
+		Tracer: otel.Tracer(tracerName),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Website")
 		os.Exit(1)
 	}
+	if enableWebhooks {
+		(&controller.WebsiteValidator{}).SetupWebhookWithManager(mgr)
+	}
 	//+kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		setupLog.Error(err, "unable to set up health check")
 		os.Exit(1)
 	}
-	if err := mgr.AddReadyzCheck("readyz", healthz.Ping); err != nil {
+	if err := mgr.AddReadyzCheck("readyz", cacheSyncedCheck(mgr.GetCache())); err != nil {
 		setupLog.Error(err, "unable to set up ready check")
 		os.Exit(1)
 	}
@@ -0,0 +1,53 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+// fakeCache reports synced once its flag is set.
+type fakeCache struct {
+	synced atomic.Bool
+}
+
+func (c *fakeCache) WaitForCacheSync(ctx context.Context) bool {
+	if c.synced.Load() {
+		return true
+	}
+	<-ctx.Done()
+	return false
+}
+
+func TestCacheSyncedCheck(t *testing.T) {
+	cache := &fakeCache{}
+	check := cacheSyncedCheck(cache)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := check(httptest.NewRequest("GET", "/readyz", nil).WithContext(ctx)); err == nil {
+		t.Fatal("expected not ready before the caches synced")
+	}
+
+	cache.synced.Store(true)
+	if err := check(httptest.NewRequest("GET", "/readyz", nil)); err != nil {
+		t.Fatalf("expected ready after the caches synced, got %v", err)
+	}
+}
@@ -0,0 +1,186 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	appsv1ac "k8s.io/client-go/applyconfigurations/apps/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	sitesv1 "github.com/zhangbiao2009/controller_exercise/simpleoperator/api/v1"
+)
+
+const (
+	// defaultMinReplicas is the autoscaler floor when spec.autoscaling.minReplicas is unset.
+	defaultMinReplicas int32 = 1
+	// defaultTargetCPUUtilization is the CPU target when spec.autoscaling does not set one.
+	defaultTargetCPUUtilization int32 = 80
+)
+
+// minReplicas returns the autoscaler floor, defaulting to one pod.
+func minReplicas(autoscaling *sitesv1.WebsiteAutoscaling) int32 {
+	if autoscaling.MinReplicas == nil {
+		return defaultMinReplicas
+	}
+	return *autoscaling.MinReplicas
+}
+
+// targetCPUUtilization returns the autoscaler CPU target, defaulting to 80%.
+func targetCPUUtilization(autoscaling *sitesv1.WebsiteAutoscaling) int32 {
+	if autoscaling.TargetCPUUtilizationPercentage == nil {
+		return defaultTargetCPUUtilization
+	}
+	return *autoscaling.TargetCPUUtilizationPercentage
+}
+
+// autoscaledReplicas returns the replica count to split with the canary while
+// an autoscaler owns it: whatever the Deployment runs now, or the autoscaler
+// floor for a new Deployment. The stable Deployment is applied without it.
+func (r *WebsiteReconciler) autoscaledReplicas(ctx context.Context, website *sitesv1.Website) (int32, error) {
+	dep := &appsv1.Deployment{}
+	err := r.Get(ctx, types.NamespacedName{Name: website.Name, Namespace: website.Namespace}, dep)
+	if errors.IsNotFound(err) {
+		return minReplicas(website.Spec.Autoscaling), nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return desiredReplicas(dep), nil
+}
+
+// replicasHandoverManager keeps spec.replicas of a Deployment while its
+// ownership passes from the controller to the autoscaler.
+const replicasHandoverManager = "website-controller-replicas-handover"
+
+// handOverReplicas lets go of spec.replicas of the stable Deployment without
+// resetting it. Dropping a field from an apply removes it when no other
+// manager owns it, which would scale the Deployment down to the default of one
+// replica, so the current count is first applied by a second manager that
+// keeps it until the autoscaler takes over.
+func (r *WebsiteReconciler) handOverReplicas(ctx context.Context, website *sitesv1.Website) error {
+	dep := &appsv1.Deployment{}
+	if err := r.Get(ctx, types.NamespacedName{Name: website.Name, Namespace: website.Namespace}, dep); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if !ownsReplicas(dep, fieldManager) {
+		return nil
+	}
+	replicas := desiredReplicas(dep)
+	handover := appsv1ac.Deployment(dep.Name, dep.Namespace).WithSpec(appsv1ac.DeploymentSpec().WithReplicas(replicas))
+	log.FromContext(ctx).Info("Handing Deployment replicas over to the autoscaler", "name", dep.Name, "replicas", replicas)
+	// Forced, though it only claims the count the Deployment already runs
+	return r.Apply(ctx, handover, client.FieldOwner(replicasHandoverManager), client.ForceOwnership)
+}
+
+// ownsReplicas reports whether manager applied spec.replicas of dep.
+func ownsReplicas(dep *appsv1.Deployment, manager string) bool {
+	for _, entry := range dep.ManagedFields {
+		if entry.Manager != manager || entry.Operation != metav1.ManagedFieldsOperationApply || entry.FieldsV1 == nil {
+			continue
+		}
+		var fields struct {
+			Spec map[string]any `json:"f:spec"`
+		}
+		if err := json.Unmarshal(entry.FieldsV1.Raw, &fields); err != nil {
+			continue
+		}
+		if _, ok := fields.Spec["f:replicas"]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// reconcileAutoscaler applies the HorizontalPodAutoscaler while
+// spec.autoscaling is set and deletes it once autoscaling is turned off.
+func (r *WebsiteReconciler) reconcileAutoscaler(ctx context.Context, website *sitesv1.Website) error {
+	log := log.FromContext(ctx)
+	autoscaling := website.Spec.Autoscaling
+
+	if autoscaling == nil {
+		existing := &autoscalingv2.HorizontalPodAutoscaler{}
+		if err := r.Get(ctx, types.NamespacedName{Name: website.Name, Namespace: website.Namespace}, existing); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+		if !metav1.IsControlledBy(existing, website) {
+			return nil
+		}
+		log.Info("Deleting HorizontalPodAutoscaler", "name", existing.Name)
+		return client.IgnoreNotFound(r.Delete(ctx, existing))
+	}
+
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "autoscaling/v2",
+			Kind:       "HorizontalPodAutoscaler",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      website.Name,
+			Namespace: website.Namespace,
+		},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Name:       website.Name,
+			},
+			MinReplicas: ptr.To(minReplicas(autoscaling)),
+			MaxReplicas: autoscaling.MaxReplicas,
+			Metrics: []autoscalingv2.MetricSpec{{
+				Type: autoscalingv2.ResourceMetricSourceType,
+				Resource: &autoscalingv2.ResourceMetricSource{
+					Name: corev1.ResourceCPU,
+					Target: autoscalingv2.MetricTarget{
+						Type:               autoscalingv2.UtilizationMetricType,
+						AverageUtilization: ptr.To(targetCPUUtilization(autoscaling)),
+					},
+				},
+			}},
+			Behavior: autoscaling.Behavior,
+		},
+	}
+	if err := ctrl.SetControllerReference(website, hpa, r.Scheme); err != nil {
+		return err
+	}
+	log.Info("Applying HorizontalPodAutoscaler", "name", hpa.Name, "min", minReplicas(autoscaling), "max", autoscaling.MaxReplicas)
+	return r.apply(ctx, hpa)
+}
+
+// autoscaledCondition reports that the autoscaler, not spec.replicas, sizes the Deployment.
+func autoscaledCondition(website *sitesv1.Website) metav1.Condition {
+	autoscaling := website.Spec.Autoscaling
+	return metav1.Condition{
+		Type:   conditionAutoscaled,
+		Status: metav1.ConditionTrue,
+		Reason: "HPAAuthoritative",
+		Message: fmt.Sprintf("HorizontalPodAutoscaler %s scales the Deployment between %d and %d replicas; spec.replicas (%d) is ignored",
+			website.Name, minReplicas(autoscaling), autoscaling.MaxReplicas, website.Spec.Replicas),
+		ObservedGeneration: website.Generation,
+	}
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+const (
+	// transientBaseDelay is the requeue delay after the first transient failure.
+	transientBaseDelay = time.Second
+	// transientMaxDelay caps the requeue delay for repeated transient failures.
+	transientMaxDelay = 5 * time.Minute
+)
+
+// isTransient reports whether an API error is likely to clear up on its own,
+// e.g. while the API server is overloaded or restarting.
+func isTransient(err error) bool {
+	return apierrors.IsServerTimeout(err) ||
+		apierrors.IsTimeout(err) ||
+		apierrors.IsTooManyRequests(err) ||
+		apierrors.IsServiceUnavailable(err) ||
+		apierrors.IsInternalError(err)
+}
+
+// transientBackoff returns the requeue delay after the given number of
+// consecutive transient failures: 1s, 2s, 4s, ... capped at transientMaxDelay.
+func transientBackoff(failures int32) time.Duration {
+	delay := transientBaseDelay
+	for i := int32(1); i < failures; i++ {
+		delay *= 2
+		if delay >= transientMaxDelay {
+			return transientMaxDelay
+		}
+	}
+	return delay
+}
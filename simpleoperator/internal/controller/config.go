@@ -0,0 +1,107 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	sitesv1 "github.com/zhangbiao2009/controller_exercise/simpleoperator/api/v1"
+)
+
+// defaultGitSyncImage is the git-sync image used when ReconcileConfig.GitSyncImage is empty.
+const defaultGitSyncImage = "registry.k8s.io/git-sync/git-sync:v4.2.1"
+
+// defaultExporterImage is the metrics exporter image used when ReconcileConfig.ExporterImage is empty.
+const defaultExporterImage = "nginx/nginx-prometheus-exporter:1.4.0"
+
+// ReconcileConfig holds operator-wide settings for the Website controller,
+// usually populated from command-line flags. Zero values fall back to the
+// built-in defaults, so the zero ReconcileConfig behaves like no config.
+type ReconcileConfig struct {
+	// DefaultImage is the web server image for nginx Websites without
+	// spec.image. Empty means nginx:alpine. Other server types use their own
+	// default image.
+	DefaultImage string
+
+	// GitSyncImage is the image of the init container that fetches the site.
+	GitSyncImage string
+
+	// ExporterImage is the image of the metrics exporter sidecar; see spec.metrics.
+	ExporterImage string
+
+	// DefaultReplicas applies to Websites whose spec.replicas is unset, which
+	// only happens when the CRD default was bypassed. Zero means 1.
+	DefaultReplicas int32
+
+	// DryRun sends every write to the API server as a dry run, so it is
+	// validated and admitted but never persisted.
+	DryRun bool
+
+	// AuditOnly skips every write and logs what would change instead.
+	AuditOnly bool
+}
+
+// image returns the web server image for the Website.
+func (c ReconcileConfig) image(website *sitesv1.Website) string {
+	switch {
+	case website.Spec.Image != "":
+		return website.Spec.Image
+	case c.DefaultImage != "" && serverType(website) == sitesv1.ServerTypeNginx:
+		return c.DefaultImage
+	default:
+		return server(website).image
+	}
+}
+
+// gitSyncImage returns the git-sync image.
+func (c ReconcileConfig) gitSyncImage() string {
+	if c.GitSyncImage == "" {
+		return defaultGitSyncImage
+	}
+	return c.GitSyncImage
+}
+
+// exporterImage returns the metrics exporter image.
+func (c ReconcileConfig) exporterImage() string {
+	if c.ExporterImage == "" {
+		return defaultExporterImage
+	}
+	return c.ExporterImage
+}
+
+// replicas returns spec.replicas, or the default when it is unset.
+func (c ReconcileConfig) replicas(website *sitesv1.Website) int32 {
+	switch {
+	case website.Spec.Replicas != 0:
+		return website.Spec.Replicas
+	case c.DefaultReplicas != 0:
+		return c.DefaultReplicas
+	default:
+		return 1
+	}
+}
+
+// wrapClient applies the DryRun and AuditOnly settings to c.
+func (c ReconcileConfig) wrapClient(cl client.Client) client.Client {
+	if c.DryRun {
+		cl = client.NewDryRunClient(cl)
+	}
+	if c.AuditOnly {
+		cl = NewAuditOnlyClient(cl)
+	}
+	return cl
+}
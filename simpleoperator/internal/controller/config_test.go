@@ -0,0 +1,127 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	sitesv1 "github.com/zhangbiao2009/controller_exercise/simpleoperator/api/v1"
+)
+
+var _ = Describe("ReconcileConfig", func() {
+	withSpec := func(spec sitesv1.WebsiteSpec) *sitesv1.Website {
+		return &sitesv1.Website{Spec: spec}
+	}
+
+	DescribeTable("web server image",
+		func(config ReconcileConfig, specImage, want string) {
+			Expect(config.image(withSpec(sitesv1.WebsiteSpec{Image: specImage}))).To(Equal(want))
+		},
+		Entry("built-in default", ReconcileConfig{}, "", defaultImage),
+		Entry("operator default", ReconcileConfig{DefaultImage: "httpd:2.4"}, "", "httpd:2.4"),
+		Entry("spec wins over the operator default", ReconcileConfig{DefaultImage: "httpd:2.4"}, "caddy:2", "caddy:2"),
+	)
+
+	DescribeTable("server type defaults",
+		func(serverType sitesv1.ServerType, wantImage, wantContainer, wantContentDir string) {
+			website := withSpec(sitesv1.WebsiteSpec{ServerType: serverType})
+			// The operator default image only replaces nginx:alpine
+			Expect(ReconcileConfig{DefaultImage: "mirror.example.com/nginx:alpine"}.image(website)).To(Equal(wantImage))
+			Expect(containerName(website)).To(Equal(wantContainer))
+			Expect(server(website).port).To(Equal(int32(80)))
+			command, args := entrypoint(website)
+			Expect(command).To(Equal([]string{"/bin/sh", "-c"}))
+			Expect(args[0]).To(HavePrefix("cp -rL /git/current/* " + wantContentDir + "/ && "))
+		},
+		Entry("unset means nginx", sitesv1.ServerType(""), "mirror.example.com/nginx:alpine", "nginx", "/usr/share/nginx/html"),
+		Entry("nginx", sitesv1.ServerTypeNginx, "mirror.example.com/nginx:alpine", "nginx", "/usr/share/nginx/html"),
+		Entry("httpd", sitesv1.ServerTypeHttpd, "httpd:alpine", "httpd", "/usr/local/apache2/htdocs"),
+		Entry("caddy", sitesv1.ServerTypeCaddy, "caddy:alpine", "caddy", "/usr/share/caddy"),
+	)
+
+	It("should let spec.image and spec.containerName override the server type", func() {
+		website := withSpec(sitesv1.WebsiteSpec{ServerType: sitesv1.ServerTypeHttpd, Image: "httpd:2.4", ContainerName: "web"})
+		Expect(ReconcileConfig{}.image(website)).To(Equal("httpd:2.4"))
+		Expect(containerName(website)).To(Equal("web"))
+	})
+
+	It("should reject nginx-only features for other server types", func() {
+		website := withSpec(sitesv1.WebsiteSpec{ServerType: sitesv1.ServerTypeCaddy, GracefulShutdown: true})
+		Expect(validateSpec(website)).To(MatchError(ContainSubstring("spec.gracefulShutdown: requires serverType nginx")))
+		website.Spec.GracefulShutdown = false
+		website.Spec.Metrics = &sitesv1.WebsiteMetrics{Enabled: true}
+		Expect(validateSpec(website)).To(MatchError(ContainSubstring("spec.metrics: requires serverType nginx")))
+	})
+
+	DescribeTable("replicas",
+		func(config ReconcileConfig, specReplicas, want int32) {
+			Expect(config.replicas(withSpec(sitesv1.WebsiteSpec{Replicas: specReplicas}))).To(Equal(want))
+		},
+		Entry("built-in default", ReconcileConfig{}, int32(0), int32(1)),
+		Entry("operator default", ReconcileConfig{DefaultReplicas: 3}, int32(0), int32(3)),
+		Entry("spec wins over the operator default", ReconcileConfig{DefaultReplicas: 3}, int32(2), int32(2)),
+	)
+
+	It("should default the git-sync image", func() {
+		Expect(ReconcileConfig{}.gitSyncImage()).To(Equal(defaultGitSyncImage))
+		Expect(ReconcileConfig{GitSyncImage: "mirror.example.com/git-sync:v4"}.gitSyncImage()).To(Equal("mirror.example.com/git-sync:v4"))
+	})
+
+	It("should apply the operator defaults to the Deployment", func() {
+		ctx := context.Background()
+		namespacedName := types.NamespacedName{Name: "configured-site", Namespace: "default"}
+		c := newFakeClient()
+		reconciler := &WebsiteReconciler{Client: c, Scheme: testScheme, Config: ReconcileConfig{
+			DefaultImage:    "httpd:2.4",
+			GitSyncImage:    "mirror.example.com/git-sync:v4",
+			DefaultReplicas: 2,
+		}}
+		Expect(c.Create(ctx, &sitesv1.Website{
+			ObjectMeta: metav1.ObjectMeta{Name: namespacedName.Name, Namespace: namespacedName.Namespace},
+			Spec:       sitesv1.WebsiteSpec{GitURL: "https://example.com/site.git"},
+		})).To(Succeed())
+
+		_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: namespacedName})
+		Expect(err).NotTo(HaveOccurred())
+
+		var dep appsv1.Deployment
+		Expect(c.Get(ctx, namespacedName, &dep)).To(Succeed())
+		Expect(*dep.Spec.Replicas).To(Equal(int32(2)))
+		Expect(dep.Spec.Template.Spec.Containers[0].Image).To(Equal("httpd:2.4"))
+		Expect(dep.Spec.Template.Spec.InitContainers[0].Image).To(Equal("mirror.example.com/git-sync:v4"))
+	})
+
+	It("should wrap the client for dry runs and audits", func() {
+		ctx := context.Background()
+		c := newFakeClient()
+		dep := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "wrapped", Namespace: "default"}}
+
+		Expect(ReconcileConfig{AuditOnly: true}.wrapClient(c).Create(ctx, dep.DeepCopy())).To(MatchError(errAuditOnly))
+		Expect(ReconcileConfig{DryRun: true}.wrapClient(c).Create(ctx, dep.DeepCopy())).To(Succeed())
+		Expect(errors.IsNotFound(c.Get(ctx, types.NamespacedName{Name: "wrapped", Namespace: "default"}, &appsv1.Deployment{}))).
+			To(BeTrue(), "neither mode should persist the object")
+		Expect(ReconcileConfig{}.wrapClient(c)).To(BeIdenticalTo(c))
+	})
+})
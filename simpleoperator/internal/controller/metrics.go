@@ -0,0 +1,79 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	sitesv1 "github.com/zhangbiao2009/controller_exercise/simpleoperator/api/v1"
+)
+
+const (
+	// exporterContainerName is the nginx-prometheus-exporter sidecar.
+	exporterContainerName = "nginx-exporter"
+	// metricsPortName names the exporter's container port and Service port,
+	// so a ServiceMonitor can select it.
+	metricsPortName = "metrics"
+	// exporterPort is the port the exporter serves metrics on.
+	exporterPort = 9113
+	// stubStatusAddr is where nginx serves stub_status for the exporter. It
+	// only listens on loopback, so it is not reachable from outside the pod.
+	stubStatusAddr = "127.0.0.1:8081"
+	// stubStatusPath is the stub_status location on stubStatusAddr.
+	stubStatusPath = "/stub_status"
+)
+
+// metricsEnabled reports whether the Website runs the exporter sidecar.
+func metricsEnabled(website *sitesv1.Website) bool {
+	return website.Spec.Metrics != nil && website.Spec.Metrics.Enabled
+}
+
+// stubStatusScript writes an nginx server block serving stub_status on
+// stubStatusAddr into conf.d, which the stock nginx.conf includes.
+func stubStatusScript() string {
+	return fmt.Sprintf("echo 'server { listen %s; location = %s { stub_status; } }' > /etc/nginx/conf.d/stub_status.conf",
+		stubStatusAddr, stubStatusPath)
+}
+
+// exporterContainers returns the exporter sidecar when metrics are enabled.
+func (r *WebsiteReconciler) exporterContainers(website *sitesv1.Website) []corev1.Container {
+	if !metricsEnabled(website) {
+		return nil
+	}
+	return []corev1.Container{{
+		Name:  exporterContainerName,
+		Image: r.Config.exporterImage(),
+		Args:  []string{"--nginx.scrape-uri=http://" + stubStatusAddr + stubStatusPath},
+		Ports: []corev1.ContainerPort{{Name: metricsPortName, ContainerPort: exporterPort}},
+	}}
+}
+
+// metricsServicePorts returns the Service port for the exporter when metrics
+// are enabled.
+func metricsServicePorts(website *sitesv1.Website) []corev1.ServicePort {
+	if !metricsEnabled(website) {
+		return nil
+	}
+	return []corev1.ServicePort{{
+		Name:       metricsPortName,
+		Port:       exporterPort,
+		TargetPort: intstr.FromString(metricsPortName),
+	}}
+}
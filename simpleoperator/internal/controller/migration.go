@@ -0,0 +1,118 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	sitesv1 "github.com/zhangbiao2009/controller_exercise/simpleoperator/api/v1"
+)
+
+// retiredLabel marks the ReplicaSets of a stable Deployment deleted by
+// migrateStableSelector, for deleteRetiredReplicaSets to remove.
+const retiredLabel = "sites.davidweb.com/retired"
+
+// migrateStableSelector makes way for dep, the stable Deployment, when the
+// existing one selects pods by an older selector, such as the bare app label
+// that also matched canary pods. A selector cannot be changed in place, so
+// the old Deployment is deleted, orphaning its ReplicaSets: their pods keep
+// serving through the Service until the recreated Deployment's are available,
+// and deleteRetiredReplicaSets removes them then.
+//
+// It reports whether the old Deployment is still being deleted. dep must not
+// be applied until it is gone; its deletion requeues the Website.
+func (r *WebsiteReconciler) migrateStableSelector(ctx context.Context, website *sitesv1.Website, dep *appsv1.Deployment) (bool, error) {
+	existing := &appsv1.Deployment{}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(dep), existing); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+	if !metav1.IsControlledBy(existing, website) || equality.Semantic.DeepEqual(existing.Spec.Selector, dep.Spec.Selector) {
+		return false, nil
+	}
+	if existing.DeletionTimestamp != nil {
+		return true, nil
+	}
+
+	log.FromContext(ctx).Info("Recreating Deployment with a new selector", "name", existing.Name,
+		"oldSelector", existing.Spec.Selector.MatchLabels, "newSelector", dep.Spec.Selector.MatchLabels)
+	if err := r.retireReplicaSets(ctx, existing); err != nil {
+		return false, err
+	}
+	err := r.Delete(ctx, existing, client.PropagationPolicy(metav1.DeletePropagationOrphan),
+		client.Preconditions{UID: &existing.UID})
+	if err != nil && !errors.IsNotFound(err) {
+		return false, err
+	}
+	return true, nil
+}
+
+// retireReplicaSets labels the ReplicaSets dep controls as retired, so they
+// can still be found once deleting dep has orphaned them.
+func (r *WebsiteReconciler) retireReplicaSets(ctx context.Context, dep *appsv1.Deployment) error {
+	var replicaSets appsv1.ReplicaSetList
+	if err := r.List(ctx, &replicaSets, client.InNamespace(dep.Namespace), client.MatchingLabels(dep.Spec.Selector.MatchLabels)); err != nil {
+		return err
+	}
+	for i := range replicaSets.Items {
+		rs := &replicaSets.Items[i]
+		if !metav1.IsControlledBy(rs, dep) || rs.Labels[retiredLabel] == "true" {
+			continue
+		}
+		patch := client.MergeFrom(rs.DeepCopy())
+		metav1.SetMetaDataLabel(&rs.ObjectMeta, retiredLabel, "true")
+		if err := r.Patch(ctx, rs, patch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteRetiredReplicaSets removes the ReplicaSets migrateStableSelector
+// left behind, along with their pods, once the recreated stable Deployment
+// has all its pods available.
+func (r *WebsiteReconciler) deleteRetiredReplicaSets(ctx context.Context, website *sitesv1.Website) error {
+	dep := &appsv1.Deployment{}
+	if err := r.Get(ctx, types.NamespacedName{Name: website.Name, Namespace: website.Namespace}, dep); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if dep.Status.ObservedGeneration < dep.Generation || dep.Status.AvailableReplicas < desiredReplicas(dep) {
+		return nil
+	}
+
+	var replicaSets appsv1.ReplicaSetList
+	err := r.List(ctx, &replicaSets, client.InNamespace(website.Namespace),
+		client.MatchingLabels{"app": website.Name, retiredLabel: "true"})
+	if err != nil {
+		return err
+	}
+	for i := range replicaSets.Items {
+		rs := &replicaSets.Items[i]
+		log.FromContext(ctx).Info("Deleting ReplicaSet retired by a selector migration", "name", rs.Name)
+		if err := r.Delete(ctx, rs, client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	return nil
+}
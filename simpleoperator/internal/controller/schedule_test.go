@@ -0,0 +1,73 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("cron schedules", func() {
+	at := func(value string) time.Time {
+		t, err := time.Parse(time.RFC3339, value)
+		Expect(err).NotTo(HaveOccurred())
+		return t
+	}
+
+	DescribeTable("next boundary",
+		func(expr, from, want string) {
+			s, err := parseCron(expr)
+			Expect(err).NotTo(HaveOccurred())
+			got, ok := s.next(at(from))
+			Expect(ok).To(BeTrue())
+			Expect(got).To(Equal(at(want)))
+		},
+		// 2026-10-14 is a Wednesday
+		Entry("later the same day", "0 9 * * 1-5", "2026-10-14T08:30:00Z", "2026-10-14T09:00:00Z"),
+		Entry("strictly after an exact match", "0 9 * * 1-5", "2026-10-14T09:00:00Z", "2026-10-15T09:00:00Z"),
+		Entry("skipping the weekend", "0 9 * * 1-5", "2026-10-16T10:00:00Z", "2026-10-19T09:00:00Z"),
+		Entry("steps", "*/15 * * * *", "2026-10-14T08:31:00Z", "2026-10-14T08:45:00Z"),
+		Entry("lists across a month", "30 6 1,15 * *", "2026-10-20T00:00:00Z", "2026-11-01T06:30:00Z"),
+		Entry("Sunday as 7", "0 0 * * 7", "2026-10-14T00:00:00Z", "2026-10-18T00:00:00Z"),
+	)
+
+	It("should find the previous boundary at or before a time", func() {
+		s, err := parseCron("0 18 * * 1-5")
+		Expect(err).NotTo(HaveOccurred())
+		got, ok := s.prev(at("2026-10-18T12:00:00Z")) // Sunday
+		Expect(ok).To(BeTrue())
+		Expect(got).To(Equal(at("2026-10-16T18:00:00Z")))
+
+		got, ok = s.prev(at("2026-10-14T18:00:00Z"))
+		Expect(ok).To(BeTrue())
+		Expect(got).To(Equal(at("2026-10-14T18:00:00Z")))
+	})
+
+	DescribeTable("invalid expressions",
+		func(expr string) {
+			_, err := parseCron(expr)
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("too few fields", "0 9 * *"),
+		Entry("out of range", "60 9 * * *"),
+		Entry("reversed range", "0 18-9 * * *"),
+		Entry("bad step", "*/0 * * * *"),
+		Entry("not a number", "0 nine * * *"),
+	)
+})
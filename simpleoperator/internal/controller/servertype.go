@@ -0,0 +1,74 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	sitesv1 "github.com/zhangbiao2009/controller_exercise/simpleoperator/api/v1"
+)
+
+// serverDefaults describes how to run one kind of web server.
+type serverDefaults struct {
+	// image is the default web server image.
+	image string
+	// containerName is the default web server container name.
+	containerName string
+	// contentDir is the html root the synced site is copied into.
+	contentDir string
+	// port is the port the server listens on inside the pod.
+	port int32
+	// start runs the server in the foreground.
+	start string
+}
+
+// serverTypes holds the defaults of every spec.serverType.
+var serverTypes = map[sitesv1.ServerType]serverDefaults{
+	sitesv1.ServerTypeNginx: {
+		image:         defaultImage,
+		containerName: defaultContainerName,
+		contentDir:    "/usr/share/nginx/html",
+		port:          webServerPort,
+		start:         "nginx -g 'daemon off;'",
+	},
+	sitesv1.ServerTypeHttpd: {
+		image:         "httpd:alpine",
+		containerName: "httpd",
+		contentDir:    "/usr/local/apache2/htdocs",
+		port:          webServerPort,
+		start:         "httpd-foreground",
+	},
+	sitesv1.ServerTypeCaddy: {
+		image:         "caddy:alpine",
+		containerName: "caddy",
+		contentDir:    "/usr/share/caddy",
+		port:          webServerPort,
+		start:         "caddy run --config /etc/caddy/Caddyfile --adapter caddyfile",
+	},
+}
+
+// serverType returns the Website's server type, defaulting to nginx when the
+// field was never defaulted.
+func serverType(website *sitesv1.Website) sitesv1.ServerType {
+	if _, ok := serverTypes[website.Spec.ServerType]; !ok {
+		return sitesv1.ServerTypeNginx
+	}
+	return website.Spec.ServerType
+}
+
+// server returns the defaults of the Website's server type.
+func server(website *sitesv1.Website) serverDefaults {
+	return serverTypes[serverType(website)]
+}
@@ -0,0 +1,200 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// tracerOrNoop returns tracer, or a tracer that records nothing when it is nil.
+func tracerOrNoop(tracer trace.Tracer) trace.Tracer {
+	if tracer == nil {
+		return noop.NewTracerProvider().Tracer("")
+	}
+	return tracer
+}
+
+// tracedReconcile runs reconcile for req in a span called name. Client calls
+// made with the span's context become its children.
+func tracedReconcile(ctx context.Context, tracer trace.Tracer, name string, req ctrl.Request,
+	reconcile func(context.Context, ctrl.Request) (ctrl.Result, error)) (ctrl.Result, error) {
+	ctx, span := tracerOrNoop(tracer).Start(ctx, name, trace.WithAttributes(
+		attribute.String("namespace", req.Namespace), attribute.String("name", req.Name)))
+	defer span.End()
+
+	result, err := reconcile(ctx, req)
+	recordError(span, err)
+	return result, err
+}
+
+// recordError marks span failed if the operation it covers returned err.
+func recordError(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
+
+// NewTracingClient wraps c so that every call, including status and other
+// subresource calls, is recorded as a span, a child of the span in the
+// call's context.
+func NewTracingClient(c client.Client, tracer trace.Tracer) client.Client {
+	return &tracingClient{Client: c, tracer: tracer}
+}
+
+type tracingClient struct {
+	client.Client
+	tracer trace.Tracer
+}
+
+// trace runs call in a span named after the operation on obj.
+func (c *tracingClient) trace(ctx context.Context, operation string, obj client.Object, call func(context.Context) error) error {
+	return traceCall(ctx, c.tracer, operation, objectAttributes(obj), call)
+}
+
+func (c *tracingClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	return traceCall(ctx, c.tracer, "Get", []attribute.KeyValue{
+		attribute.String("kind", kindOf(obj)), attribute.String("namespace", key.Namespace), attribute.String("name", key.Name),
+	}, func(ctx context.Context) error {
+		return c.Client.Get(ctx, key, obj, opts...)
+	})
+}
+
+func (c *tracingClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	return traceCall(ctx, c.tracer, "List", []attribute.KeyValue{attribute.String("kind", fmt.Sprintf("%T", list))},
+		func(ctx context.Context) error {
+			return c.Client.List(ctx, list, opts...)
+		})
+}
+
+func (c *tracingClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	return c.trace(ctx, "Create", obj, func(ctx context.Context) error {
+		return c.Client.Create(ctx, obj, opts...)
+	})
+}
+
+func (c *tracingClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	return c.trace(ctx, "Update", obj, func(ctx context.Context) error {
+		return c.Client.Update(ctx, obj, opts...)
+	})
+}
+
+func (c *tracingClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	return c.trace(ctx, "Patch", obj, func(ctx context.Context) error {
+		return c.Client.Patch(ctx, obj, patch, opts...)
+	})
+}
+
+func (c *tracingClient) Apply(ctx context.Context, obj runtime.ApplyConfiguration, opts ...client.ApplyOption) error {
+	return traceCall(ctx, c.tracer, "Apply", []attribute.KeyValue{attribute.String("kind", fmt.Sprintf("%T", obj))},
+		func(ctx context.Context) error {
+			return c.Client.Apply(ctx, obj, opts...)
+		})
+}
+
+func (c *tracingClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	return c.trace(ctx, "Delete", obj, func(ctx context.Context) error {
+		return c.Client.Delete(ctx, obj, opts...)
+	})
+}
+
+func (c *tracingClient) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) error {
+	return traceCall(ctx, c.tracer, "DeleteAllOf", []attribute.KeyValue{attribute.String("kind", kindOf(obj))},
+		func(ctx context.Context) error {
+			return c.Client.DeleteAllOf(ctx, obj, opts...)
+		})
+}
+
+func (c *tracingClient) Status() client.SubResourceWriter {
+	return c.SubResource("status")
+}
+
+func (c *tracingClient) SubResource(subResource string) client.SubResourceClient {
+	return tracingSubResource{SubResourceClient: c.Client.SubResource(subResource), name: subResource, tracer: c.tracer}
+}
+
+// tracingSubResource records every subresource call as a span.
+type tracingSubResource struct {
+	client.SubResourceClient
+	name   string
+	tracer trace.Tracer
+}
+
+// trace runs call in a span named after the operation on the subresource of obj.
+func (s tracingSubResource) trace(ctx context.Context, operation string, obj client.Object, call func(context.Context) error) error {
+	return traceCall(ctx, s.tracer, s.name+"."+operation, objectAttributes(obj), call)
+}
+
+func (s tracingSubResource) Get(ctx context.Context, obj client.Object, subResource client.Object, opts ...client.SubResourceGetOption) error {
+	return s.trace(ctx, "Get", obj, func(ctx context.Context) error {
+		return s.SubResourceClient.Get(ctx, obj, subResource, opts...)
+	})
+}
+
+func (s tracingSubResource) Create(ctx context.Context, obj client.Object, subResource client.Object, opts ...client.SubResourceCreateOption) error {
+	return s.trace(ctx, "Create", obj, func(ctx context.Context) error {
+		return s.SubResourceClient.Create(ctx, obj, subResource, opts...)
+	})
+}
+
+func (s tracingSubResource) Update(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+	return s.trace(ctx, "Update", obj, func(ctx context.Context) error {
+		return s.SubResourceClient.Update(ctx, obj, opts...)
+	})
+}
+
+func (s tracingSubResource) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+	return s.trace(ctx, "Patch", obj, func(ctx context.Context) error {
+		return s.SubResourceClient.Patch(ctx, obj, patch, opts...)
+	})
+}
+
+func (s tracingSubResource) Apply(ctx context.Context, obj runtime.ApplyConfiguration, opts ...client.SubResourceApplyOption) error {
+	return traceCall(ctx, s.tracer, s.name+".Apply", []attribute.KeyValue{attribute.String("kind", fmt.Sprintf("%T", obj))},
+		func(ctx context.Context) error {
+			return s.SubResourceClient.Apply(ctx, obj, opts...)
+		})
+}
+
+// traceCall runs call in a client span called "client.<operation>".
+func traceCall(ctx context.Context, tracer trace.Tracer, operation string, attrs []attribute.KeyValue, call func(context.Context) error) error {
+	ctx, span := tracer.Start(ctx, "client."+operation,
+		trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+	defer span.End()
+
+	err := call(ctx)
+	recordError(span, err)
+	return err
+}
+
+// objectAttributes identify the object a call is about.
+func objectAttributes(obj client.Object) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String("kind", kindOf(obj)),
+		attribute.String("namespace", obj.GetNamespace()),
+		attribute.String("name", obj.GetName()),
+	}
+}
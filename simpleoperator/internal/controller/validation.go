@@ -0,0 +1,112 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	sitesv1 "github.com/zhangbiao2009/controller_exercise/simpleoperator/api/v1"
+)
+
+// controllerAnnotationPrefix is the annotation domain the controller manages
+// itself, e.g. restartedAtAnnotation.
+const controllerAnnotationPrefix = "sites.davidweb.com/"
+
+// deploymentControllerAnnotationPrefix is the annotation domain the
+// Deployment controller writes, e.g. deployment.kubernetes.io/revision.
+const deploymentControllerAnnotationPrefix = "deployment.kubernetes.io/"
+
+// validateSpec checks the parts of a Website spec the CRD schema cannot express.
+func validateSpec(website *sitesv1.Website) error {
+	for _, v := range website.Spec.Volumes {
+		if v.Name == contentVolumeName {
+			return fmt.Errorf("spec.volumes: name %q is reserved for the managed content volume", v.Name)
+		}
+	}
+	for _, m := range website.Spec.VolumeMounts {
+		if m.Name == contentVolumeName {
+			return fmt.Errorf("spec.volumeMounts: name %q is reserved for the managed content volume", m.Name)
+		}
+		if m.MountPath == contentMountPath || strings.HasPrefix(m.MountPath, contentMountPath+"/") {
+			return fmt.Errorf("spec.volumeMounts: mount path %q would shadow the managed content volume at %s", m.MountPath, contentMountPath)
+		}
+	}
+	if err := checkAnnotations("spec.deploymentAnnotations", website.Spec.DeploymentAnnotations,
+		controllerAnnotationPrefix, deploymentControllerAnnotationPrefix); err != nil {
+		return err
+	}
+	if err := checkAnnotations("spec.serviceAnnotations", website.Spec.ServiceAnnotations, controllerAnnotationPrefix); err != nil {
+		return err
+	}
+	if serverType(website) != sitesv1.ServerTypeNginx {
+		if metricsEnabled(website) {
+			return fmt.Errorf("spec.metrics: requires serverType nginx, got %q", website.Spec.ServerType)
+		}
+		if website.Spec.GracefulShutdown {
+			return fmt.Errorf("spec.gracefulShutdown: requires serverType nginx, got %q", website.Spec.ServerType)
+		}
+	}
+	if website.Spec.ContainerName == gitSyncContainerName {
+		return fmt.Errorf("spec.containerName: %q is reserved for the content sync container", gitSyncContainerName)
+	}
+	names := map[string]bool{gitSyncContainerName: true, containerName(website): true}
+	if metricsEnabled(website) {
+		names[exporterContainerName] = true
+	}
+	for _, c := range website.Spec.Sidecars {
+		if names[c.Name] {
+			return fmt.Errorf("spec.sidecars: container name %q is already in use", c.Name)
+		}
+		names[c.Name] = true
+	}
+	if sched := website.Spec.Schedule; sched != nil {
+		if _, err := parseCron(sched.ScaleUpCron); err != nil {
+			return fmt.Errorf("spec.schedule.scaleUpCron: %w", err)
+		}
+		if _, err := parseCron(sched.ScaleDownCron); err != nil {
+			return fmt.Errorf("spec.schedule.scaleDownCron: %w", err)
+		}
+	}
+	if as := website.Spec.Autoscaling; as != nil {
+		if website.Spec.Schedule != nil {
+			return fmt.Errorf("spec.autoscaling: cannot be combined with spec.schedule, which also sets the replica count")
+		}
+		if website.Spec.Canary != nil {
+			return fmt.Errorf("spec.autoscaling: cannot be combined with spec.canary, whose replica split the autoscaler would not follow")
+		}
+		if floor := minReplicas(as); floor > as.MaxReplicas {
+			return fmt.Errorf("spec.autoscaling: minReplicas (%d) is greater than maxReplicas (%d)", floor, as.MaxReplicas)
+		}
+	}
+	return nil
+}
+
+// checkAnnotations rejects annotation keys under a reserved prefix, which
+// applying would take over from the controller that manages them.
+func checkAnnotations(field string, annotations map[string]string, reserved ...string) error {
+	for _, key := range slices.Sorted(maps.Keys(annotations)) {
+		for _, prefix := range reserved {
+			if strings.HasPrefix(key, prefix) {
+				return fmt.Errorf("%s: key %q is under the reserved prefix %q", field, key, prefix)
+			}
+		}
+	}
+	return nil
+}
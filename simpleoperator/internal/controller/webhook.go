@@ -0,0 +1,61 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	sitesv1 "github.com/zhangbiao2009/controller_exercise/simpleoperator/api/v1"
+)
+
+// WebsiteValidatorPath is the path WebsiteValidator is served on.
+const WebsiteValidatorPath = "/validate-sites-davidweb-com-v1-website"
+
+//+kubebuilder:webhook:path=/validate-sites-davidweb-com-v1-website,mutating=false,failurePolicy=fail,sideEffects=None,groups=sites.davidweb.com,resources=websites,verbs=create;update,versions=v1,name=vwebsite.kb.io,admissionReviewVersions=v1
+
+// WebsiteValidator is a validating admission webhook that rejects the specs
+// the reconciler would mark SpecInvalid, so the mistake surfaces when the
+// Website is applied rather than later in its status. It also warns when
+// spec.replicas is set next to spec.autoscaling, where it has no effect.
+type WebsiteValidator struct{}
+
+// Handle validates the Website in a create or update request.
+func (v *WebsiteValidator) Handle(_ context.Context, req admission.Request) admission.Response {
+	website := &sitesv1.Website{}
+	if err := json.Unmarshal(req.Object.Raw, website); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	if err := validateSpec(website); err != nil {
+		return admission.Denied(err.Error())
+	}
+	resp := admission.Allowed("")
+	if website.Spec.Autoscaling != nil && website.Spec.Replicas > 1 {
+		resp = resp.WithWarnings("spec.replicas is ignored while spec.autoscaling is set; the HorizontalPodAutoscaler decides the replica count")
+	}
+	return resp
+}
+
+// SetupWebhookWithManager serves the validator from the manager's webhook server.
+func (v *WebsiteValidator) SetupWebhookWithManager(mgr ctrl.Manager) {
+	mgr.GetWebhookServer().Register(WebsiteValidatorPath, &webhook.Admission{Handler: v})
+}
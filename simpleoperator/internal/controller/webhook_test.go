@@ -0,0 +1,83 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	sitesv1 "github.com/zhangbiao2009/controller_exercise/simpleoperator/api/v1"
+)
+
+var _ = Describe("Website validating webhook", func() {
+	validate := func(spec sitesv1.WebsiteSpec) admission.Response {
+		raw, err := json.Marshal(&sitesv1.Website{
+			ObjectMeta: metav1.ObjectMeta{Name: "site", Namespace: "default"},
+			Spec:       spec,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		return (&WebsiteValidator{}).Handle(context.Background(), admission.Request{
+			AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: admissionv1.Create,
+				Object:    runtime.RawExtension{Raw: raw},
+			},
+		})
+	}
+
+	It("should allow a valid Website without warnings", func() {
+		resp := validate(sitesv1.WebsiteSpec{GitURL: "https://example.com/site.git", Replicas: 2})
+		Expect(resp.Allowed).To(BeTrue())
+		Expect(resp.Warnings).To(BeEmpty())
+	})
+
+	It("should reject autoscaling combined with a schedule", func() {
+		resp := validate(sitesv1.WebsiteSpec{
+			GitURL:      "https://example.com/site.git",
+			Autoscaling: &sitesv1.WebsiteAutoscaling{MaxReplicas: 4},
+			Schedule:    &sitesv1.WebsiteSchedule{ScaleUpCron: "0 9 * * 1-5", ScaleDownCron: "0 18 * * 1-5"},
+		})
+		Expect(resp.Allowed).To(BeFalse())
+		Expect(resp.Result.Message).To(ContainSubstring("spec.schedule"))
+	})
+
+	It("should reject a floor above the ceiling", func() {
+		resp := validate(sitesv1.WebsiteSpec{
+			GitURL:      "https://example.com/site.git",
+			Autoscaling: &sitesv1.WebsiteAutoscaling{MinReplicas: ptr.To(int32(5)), MaxReplicas: 4},
+		})
+		Expect(resp.Allowed).To(BeFalse())
+		Expect(resp.Result.Message).To(ContainSubstring("greater than maxReplicas"))
+	})
+
+	It("should warn that spec.replicas is ignored while autoscaling", func() {
+		resp := validate(sitesv1.WebsiteSpec{
+			GitURL:      "https://example.com/site.git",
+			Replicas:    3,
+			Autoscaling: &sitesv1.WebsiteAutoscaling{MaxReplicas: 4},
+		})
+		Expect(resp.Allowed).To(BeTrue())
+		Expect(resp.Warnings).To(ConsistOf(ContainSubstring("spec.replicas is ignored")))
+	})
+})
@@ -18,25 +18,92 @@ package controller
 
 import (
 	"context"
+	"fmt"
+	"time"
 
+	"go.opentelemetry.io/otel/trace"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/utils/clock"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	sitesv1 "github.com/zhangbiao2009/controller_exercise/simpleoperator/api/v1"
 )
 
+const (
+	// contentVolumeName is the managed emptyDir shared by git-sync and the web server.
+	contentVolumeName = "web-content"
+	// contentMountPath is where the managed content volume is mounted.
+	contentMountPath = "/git"
+	// webServerPort is the port the stock web servers listen on inside the pod.
+	webServerPort = 80
+	// defaultImage and defaultContainerName describe the stock nginx web server.
+	defaultImage         = "nginx:alpine"
+	defaultContainerName = "nginx"
+	// gitSyncContainerName is the init container that fetches the site.
+	gitSyncContainerName = "git-sync"
+	// canarySuffix is appended to the Website name for the canary Deployment.
+	canarySuffix = "-canary"
+	// trackLabel tells stable and canary pods apart; the Service ignores it.
+	trackLabel = "track"
+)
+
+// restartedAtAnnotation on the pod template records the last automatic
+// restart; changing it rolls the pods.
+const restartedAtAnnotation = "sites.davidweb.com/restartedAt"
+
+// defaultDegradedRestartThreshold is how long a Website may stay degraded
+// before an automatic restart when DegradedRestartThreshold is zero.
+const defaultDegradedRestartThreshold = 10 * time.Minute
+
+// defaultContentSizeLimit caps the content volume when spec.contentSizeLimit is unset.
+var defaultContentSizeLimit = resource.MustParse("1Gi")
+
+// Condition types reported on Website status.
+const (
+	// conditionSpecInvalid is True when the spec fails validation and nothing was applied.
+	conditionSpecInvalid = "SpecInvalid"
+	// conditionReady is True when every Deployment replica is available and the
+	// Service has ready endpoints to send traffic to.
+	conditionReady = "Ready"
+	// conditionAutoscaled is True while spec.autoscaling is set and its
+	// HorizontalPodAutoscaler, not spec.replicas, decides the replica count.
+	conditionAutoscaled = "Autoscaled"
+)
+
 // WebsiteReconciler reconciles a Website object
 type WebsiteReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
+
+	// Clock is used to evaluate spec.schedule and degraded time. Nil means the real clock.
+	Clock clock.PassiveClock
+
+	// DegradedRestartThreshold is how long a Website may run with only some
+	// replicas available before it is restarted (if it opted in) or a warning
+	// is logged. Zero means defaultDegradedRestartThreshold.
+	DegradedRestartThreshold time.Duration
+
+	// Config holds operator-wide defaults and write modes.
+	Config ReconcileConfig
+
+	// Tracer starts a span for every reconcile and client call. Nil records nothing.
+	Tracer trace.Tracer
 }
 
 //+kubebuilder:rbac:groups=sites.davidweb.com,resources=websites,verbs=get;list;watch;create;update;patch;delete
@@ -44,7 +111,10 @@ type WebsiteReconciler struct {
 //+kubebuilder:rbac:groups=sites.davidweb.com,resources=websites/finalizers,verbs=update
 
 //+kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=apps,resources=replicasets,verbs=get;list;watch;patch;delete
 //+kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=discovery.k8s.io,resources=endpointslices,verbs=get;list;watch
+//+kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch;create;update;patch;delete
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -56,7 +126,12 @@ type WebsiteReconciler struct {
 // For more details, check Reconcile and its Result here:
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.17.2/pkg/reconcile
 func (r *WebsiteReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
-	_ = log.FromContext(ctx)
+	return tracedReconcile(ctx, r.Tracer, "Website.Reconcile", req, r.reconcile)
+}
+
+// reconcile does the work of Reconcile within its span.
+func (r *WebsiteReconciler) reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
 
 	// 1. Fetch the Website CR
 	website := &sitesv1.Website{}
@@ -68,86 +143,182 @@ func (r *WebsiteReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		return ctrl.Result{}, err
 	}
 
-	// 2. Create/Update Deployment
-	if err := r.reconcileDeployment(ctx, website); err != nil {
-		return ctrl.Result{}, err
+	// 2. Validate the spec before touching owned objects
+	if err := validateSpec(website); err != nil {
+		logger.Info("Website spec is invalid", "error", err.Error())
+		// Only a spec change can fix this, which triggers a new reconcile.
+		return ctrl.Result{}, r.markSpecInvalid(ctx, website, err)
+	}
+
+	// 3. Work out the replica count for the current schedule window, or keep
+	// the autoscaler's if it owns the count
+	replicas, untilBoundary, err := scheduledReplicas(website, r.Config.replicas(website), r.now())
+	if err != nil {
+		return ctrl.Result{}, r.markSpecInvalid(ctx, website, err)
+	}
+	if website.Spec.Autoscaling != nil {
+		if replicas, err = r.autoscaledReplicas(ctx, website); err != nil {
+			return ctrl.Result{}, err
+		}
 	}
 
-	// 3. Create/Update Service
+	// 4. Create/Update Deployment and its autoscaler
+	if err := r.reconcileDeployment(ctx, website, replicas); err != nil {
+		return r.handleApplyError(ctx, website, err)
+	}
+	if err := r.reconcileAutoscaler(ctx, website); err != nil {
+		return r.handleApplyError(ctx, website, err)
+	}
+
+	// 5. Create/Update Service
 	if err := r.reconcileService(ctx, website); err != nil {
-		return ctrl.Result{}, err
+		return r.handleApplyError(ctx, website, err)
 	}
 
-	// 4. Update Status
-	if err := r.updateStatus(ctx, website); err != nil {
+	// 6. Update Status
+	restart, err := r.updateStatus(ctx, website)
+	if err != nil {
 		return ctrl.Result{}, err
 	}
 
-	return ctrl.Result{}, nil
+	// 7. Roll the pods of a Website that stayed degraded for too long
+	if restart {
+		logger.Info("Restarting pods of degraded Website")
+		if err := r.reconcileDeployment(ctx, website, replicas); err != nil {
+			return r.handleApplyError(ctx, website, err)
+		}
+	}
+
+	// 8. Come back when the schedule next changes the replica count, or when
+	// a degraded Website is due for a restart
+	requeueAfter := untilBoundary
+	if d := r.untilDegradedRestart(website); d > 0 && (requeueAfter == 0 || d < requeueAfter) {
+		requeueAfter = d
+	}
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+func (r *WebsiteReconciler) reconcileDeployment(ctx context.Context, website *sitesv1.Website, replicas int32) error {
+	log := log.FromContext(ctx)
+	stableReplicas, canaryReplicas := splitCanary(website, replicas)
+	labels := map[string]string{"app": website.Name, trackLabel: "stable"}
+	dep := r.desiredDeployment(website, website.Name, r.Config.image(website), labels, stableReplicas)
+	if migrating, err := r.migrateStableSelector(ctx, website, dep); err != nil || migrating {
+		return err
+	}
+	if website.Spec.Autoscaling != nil {
+		// Leave spec.replicas to the autoscaler, or a forced apply would take it back
+		if err := r.handOverReplicas(ctx, website); err != nil {
+			return err
+		}
+		dep.Spec.Replicas = nil
+	}
+
+	// Set OwnerReference - for garbage collection
+	if err := ctrl.SetControllerReference(website, dep, r.Scheme); err != nil {
+		return err
+	}
+
+	// Server-Side Apply: declare ownership of our fields
+	log.Info("Applying Deployment", "name", dep.Name)
+	if err := r.apply(ctx, dep); err != nil {
+		return err
+	}
+	if err := r.deleteRetiredReplicaSets(ctx, website); err != nil {
+		return err
+	}
+	return r.reconcileCanary(ctx, website, canaryReplicas)
 }
 
-func (r *WebsiteReconciler) reconcileDeployment(ctx context.Context, website *sitesv1.Website) error {
+// reconcileCanary applies the canary Deployment while spec.canary is set and
+// deletes it once the canary is removed.
+func (r *WebsiteReconciler) reconcileCanary(ctx context.Context, website *sitesv1.Website, replicas int32) error {
 	log := log.FromContext(ctx)
+	name := website.Name + canarySuffix
 
-	// Define the desired Deployment
-	dep := &appsv1.Deployment{
+	if website.Spec.Canary == nil {
+		existing := &appsv1.Deployment{}
+		if err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: website.Namespace}, existing); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+		if !metav1.IsControlledBy(existing, website) {
+			return nil
+		}
+		log.Info("Deleting canary Deployment", "name", name)
+		return client.IgnoreNotFound(r.Delete(ctx, existing))
+	}
+
+	labels := map[string]string{"app": website.Name, trackLabel: "canary"}
+	dep := r.desiredDeployment(website, name, website.Spec.Canary.Image, labels, replicas)
+	if err := ctrl.SetControllerReference(website, dep, r.Scheme); err != nil {
+		return err
+	}
+	log.Info("Applying canary Deployment", "name", name, "replicas", replicas, "weight", website.Spec.Canary.Weight)
+	return r.apply(ctx, dep)
+}
+
+// desiredDeployment builds a web server Deployment for the Website. The stable
+// and canary Deployments differ only in name, image, pod labels and replicas.
+func (r *WebsiteReconciler) desiredDeployment(website *sitesv1.Website, name, image string, labels map[string]string, replicas int32) *appsv1.Deployment {
+	command, args := entrypoint(website)
+	initSidecars, sidecars := placeSidecars(website)
+
+	return &appsv1.Deployment{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: "apps/v1",
 			Kind:       "Deployment",
 		},
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      website.Name,
-			Namespace: website.Namespace,
+			Name:        name,
+			Namespace:   website.Namespace,
+			Annotations: website.Spec.DeploymentAnnotations,
 		},
 		Spec: appsv1.DeploymentSpec{
-			Replicas: &website.Spec.Replicas,
+			Replicas: &replicas,
 			Selector: &metav1.LabelSelector{
-				MatchLabels: map[string]string{"app": website.Name},
+				MatchLabels: labels,
 			},
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels: map[string]string{"app": website.Name},
+					Labels:      labels,
+					Annotations: restartAnnotations(website),
 				},
 				Spec: corev1.PodSpec{
-					InitContainers: []corev1.Container{{
-						Name:  "git-sync",
-						Image: "registry.k8s.io/git-sync/git-sync:v4.2.1",
-						Args:  []string{"--repo=" + website.Spec.GitURL, "--root=/git", "--link=current", "--one-time"},
+					Affinity:                      spreadAffinity(website),
+					ReadinessGates:                website.Spec.ReadinessGates,
+					TerminationGracePeriodSeconds: website.Spec.TerminationGracePeriodSeconds,
+					// git-sync always runs first and must finish before anything else starts
+					InitContainers: append([]corev1.Container{{
+						Name:  gitSyncContainerName,
+						Image: r.Config.gitSyncImage(),
+						Args:  []string{"--repo=" + website.Spec.GitURL, "--root=" + contentMountPath, "--link=current", "--one-time"},
 						VolumeMounts: []corev1.VolumeMount{{
-							Name:      "web-content",
-							MountPath: "/git",
+							Name:      contentVolumeName,
+							MountPath: contentMountPath,
 						}},
-					}},
-					Containers: []corev1.Container{{
-						Name:    "nginx",
-						Image:   "nginx:alpine",
-						Command: []string{"/bin/sh", "-c"},
-						Args:    []string{"cp -rL /git/current/* /usr/share/nginx/html/ && nginx -g 'daemon off;'"},
-						Ports:   []corev1.ContainerPort{{ContainerPort: 80}},
-						VolumeMounts: []corev1.VolumeMount{{
-							Name:      "web-content",
-							MountPath: "/git",
-						}},
-					}},
-					Volumes: []corev1.Volume{{
-						Name: "web-content",
+					}}, initSidecars...),
+					Containers: append(append([]corev1.Container{{
+						Name:    containerName(website),
+						Image:   image,
+						Command: command,
+						Args:    args,
+						Ports:   []corev1.ContainerPort{{ContainerPort: server(website).port}},
+						VolumeMounts: append([]corev1.VolumeMount{{
+							Name:      contentVolumeName,
+							MountPath: contentMountPath,
+						}}, website.Spec.VolumeMounts...),
+						Lifecycle: preStopQuit(website),
+					}}, sidecars...), r.exporterContainers(website)...),
+					Volumes: append([]corev1.Volume{{
+						Name: contentVolumeName,
 						VolumeSource: corev1.VolumeSource{
-							EmptyDir: &corev1.EmptyDirVolumeSource{},
+							EmptyDir: &corev1.EmptyDirVolumeSource{SizeLimit: contentSizeLimit(website)},
 						},
-					}},
+					}}, website.Spec.Volumes...),
 				},
 			},
 		},
 	}
-
-	// Set OwnerReference - for garbage collection
-	if err := ctrl.SetControllerReference(website, dep, r.Scheme); err != nil {
-		return err
-	}
-
-	// Server-Side Apply: declare ownership of our fields
-	log.Info("Applying Deployment", "name", dep.Name)
-	return r.Patch(ctx, dep, client.Apply, client.FieldOwner("website-controller"), client.ForceOwnership)
 }
 
 func (r *WebsiteReconciler) reconcileService(ctx context.Context, website *sitesv1.Website) error {
@@ -159,15 +330,17 @@ func (r *WebsiteReconciler) reconcileService(ctx context.Context, website *sites
 			Kind:       "Service",
 		},
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      website.Name,
-			Namespace: website.Namespace,
+			Name:        website.Name,
+			Namespace:   website.Namespace,
+			Annotations: website.Spec.ServiceAnnotations,
 		},
 		Spec: corev1.ServiceSpec{
 			Selector: map[string]string{"app": website.Name},
-			Ports: []corev1.ServicePort{{
-				Port:       80,
-				TargetPort: intstr.FromInt(80),
-			}},
+			Ports: append([]corev1.ServicePort{{
+				Name:       "http",
+				Port:       servicePort(website),
+				TargetPort: intstr.FromInt32(server(website).port),
+			}}, metricsServicePorts(website)...),
 			Type: corev1.ServiceTypeClusterIP,
 		},
 	}
@@ -177,36 +350,361 @@ func (r *WebsiteReconciler) reconcileService(ctx context.Context, website *sites
 		return err
 	}
 
-	// Server-Side Apply
-	log.Info("Applying Service", "name", svc.Name)
-	return r.Patch(ctx, svc, client.Apply, client.FieldOwner("website-controller"), client.ForceOwnership)
+	// Server-Side Apply: the selector is an atomic map, so a forced apply resets
+	// any edits to it, and the port list is replaced with ours. Annotations are
+	// merged per key, so those set by others survive and ours are removed once
+	// dropped from the spec. Fields we never set (ClusterIP, NodePort
+	// assignments) keep the values the API server chose.
+	log.Info("Applying Service", "name", svc.Name, "port", servicePort(website))
+	return r.apply(ctx, svc)
+}
+
+// splitCanary divides replicas between the stable and canary Deployments by
+// spec.canary.weight, rounding to the nearest pod but keeping at least one
+// canary pod for a non-zero weight.
+func splitCanary(website *sitesv1.Website, replicas int32) (stable, canary int32) {
+	if website.Spec.Canary == nil {
+		return replicas, 0
+	}
+	weight := website.Spec.Canary.Weight
+	canary = (replicas*weight + 50) / 100
+	if canary == 0 && weight > 0 && replicas > 0 {
+		canary = 1
+	}
+	return replicas - canary, canary
+}
+
+// contentSizeLimit returns the content volume size limit, defaulting to 1Gi.
+func contentSizeLimit(website *sitesv1.Website) *resource.Quantity {
+	if website.Spec.ContentSizeLimit == nil {
+		limit := defaultContentSizeLimit.DeepCopy()
+		return &limit
+	}
+	return website.Spec.ContentSizeLimit
+}
+
+// containerName returns the web server container name, defaulting to the
+// server type's.
+func containerName(website *sitesv1.Website) string {
+	if website.Spec.ContainerName == "" {
+		return server(website).containerName
+	}
+	return website.Spec.ContainerName
 }
 
-func (r *WebsiteReconciler) updateStatus(ctx context.Context, website *sitesv1.Website) error {
+// entrypoint returns the web server command and args. Without overrides the
+// server copies the synced site into its html root and starts in the
+// foreground; nginx first enables stub_status for the exporter when metrics
+// are on.
+func entrypoint(website *sitesv1.Website) ([]string, []string) {
+	if len(website.Spec.Command) > 0 {
+		return website.Spec.Command, website.Spec.Args
+	}
+	args := website.Spec.Args
+	if len(args) == 0 {
+		srv := server(website)
+		script := "cp -rL " + contentMountPath + "/current/* " + srv.contentDir + "/ && " + srv.start
+		if metricsEnabled(website) {
+			script = stubStatusScript() + " && " + script
+		}
+		args = []string{script}
+	}
+	return []string{"/bin/sh", "-c"}, args
+}
+
+// spreadAffinity returns a preferred anti-affinity between the Website's pods
+// across nodes when spec.antiAffinity is set, and nil otherwise.
+func spreadAffinity(website *sitesv1.Website) *corev1.Affinity {
+	if !website.Spec.AntiAffinity {
+		return nil
+	}
+	return &corev1.Affinity{
+		PodAntiAffinity: &corev1.PodAntiAffinity{
+			PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{{
+				Weight: 100,
+				PodAffinityTerm: corev1.PodAffinityTerm{
+					LabelSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"app": website.Name},
+					},
+					TopologyKey: corev1.LabelHostname,
+				},
+			}},
+		},
+	}
+}
+
+// preStopQuit returns a preStop hook that asks nginx to finish serving open
+// connections and waits until it has exited, when spec.gracefulShutdown is
+// set, and nil otherwise. The kubelet only sends SIGTERM once the hook returns.
+func preStopQuit(website *sitesv1.Website) *corev1.Lifecycle {
+	if !website.Spec.GracefulShutdown {
+		return nil
+	}
+	return &corev1.Lifecycle{
+		PreStop: &corev1.LifecycleHandler{
+			Exec: &corev1.ExecAction{
+				Command: []string{"/bin/sh", "-c", "nginx -s quit; while pgrep -x nginx > /dev/null; do sleep 1; done"},
+			},
+		},
+	}
+}
+
+// placeSidecars splits spec.sidecars into init containers and regular
+// containers according to spec.sidecarStartOrder. BeforeWebServer turns them
+// into native sidecars (restartable init containers), which the kubelet starts
+// in order after git-sync and before the web server.
+func placeSidecars(website *sitesv1.Website) (initContainers, containers []corev1.Container) {
+	if website.Spec.SidecarStartOrder != sitesv1.SidecarStartOrderBeforeWebServer {
+		return nil, website.Spec.Sidecars
+	}
+	for _, c := range website.Spec.Sidecars {
+		c := *c.DeepCopy()
+		c.RestartPolicy = ptr.To(corev1.ContainerRestartPolicyAlways)
+		initContainers = append(initContainers, c)
+	}
+	return initContainers, nil
+}
+
+// servicePort returns the Service port for the Website, falling back to the
+// web server port when the field was never defaulted.
+func servicePort(website *sitesv1.Website) int32 {
+	if website.Spec.Port == 0 {
+		return server(website).port
+	}
+	return website.Spec.Port
+}
+
+// fieldManager owns the fields of the objects the controller applies.
+const fieldManager = "website-controller"
+
+// apply server-side applies an owned object, retrying on conflicts.
+func (r *WebsiteReconciler) apply(ctx context.Context, obj client.Object) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		return r.Patch(ctx, obj, client.Apply, client.FieldOwner(fieldManager), client.ForceOwnership)
+	})
+}
+
+// handleApplyError turns a transient API error into a capped, growing requeue
+// delay recorded in status. Other errors are returned for the default rate limiter.
+func (r *WebsiteReconciler) handleApplyError(ctx context.Context, website *sitesv1.Website, applyErr error) (ctrl.Result, error) {
+	if !isTransient(applyErr) {
+		return ctrl.Result{}, applyErr
+	}
+
+	patch := client.MergeFrom(website.DeepCopy())
+	website.Status.ConsecutiveFailures++
+	if err := r.Status().Patch(ctx, website, patch); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	delay := transientBackoff(website.Status.ConsecutiveFailures)
+	log.FromContext(ctx).Info("transient API error, backing off",
+		"error", applyErr.Error(), "failures", website.Status.ConsecutiveFailures, "requeueAfter", delay)
+	return ctrl.Result{RequeueAfter: delay}, nil
+}
+
+// updateStatus records the observed state of the Deployment and its Service.
+// It reports whether the pods should be restarted because the Website stayed
+// degraded past the threshold; the restart time is then already in status.
+func (r *WebsiteReconciler) updateStatus(ctx context.Context, website *sitesv1.Website) (bool, error) {
 	// Get the Deployment to check replicas
 	dep := &appsv1.Deployment{}
 	err := r.Get(ctx, types.NamespacedName{Name: website.Name, Namespace: website.Namespace}, dep)
+	if errors.IsNotFound(err) && r.Config.DryRun {
+		// The dry-run apply was never persisted, so there is nothing to report
+		log.FromContext(ctx).Info("dry-run: Deployment not created, skipping status")
+		return false, nil
+	}
 	if err != nil {
-		return err
+		return false, err
+	}
+
+	readyStatus, readyReason, readyMessage, err := r.readiness(ctx, website, dep)
+	if err != nil {
+		return false, err
+	}
+
+	var canaryAvailable int32
+	if website.Spec.Canary != nil {
+		canary := &appsv1.Deployment{}
+		err := r.Get(ctx, types.NamespacedName{Name: website.Name + canarySuffix, Namespace: website.Namespace}, canary)
+		if client.IgnoreNotFound(err) != nil {
+			return false, err
+		}
+		canaryAvailable = canary.Status.AvailableReplicas
 	}
 
 	// Patch status (avoids conflicts)
 	patch := client.MergeFrom(website.DeepCopy())
 	website.Status.AvailableReplicas = dep.Status.AvailableReplicas
-	if dep.Status.AvailableReplicas > 0 {
+	website.Status.CanaryAvailableReplicas = canaryAvailable
+	website.Status.ConsecutiveFailures = 0
+	meta.RemoveStatusCondition(&website.Status.Conditions, conditionSpecInvalid)
+	meta.SetStatusCondition(&website.Status.Conditions, metav1.Condition{
+		Type:               conditionReady,
+		Status:             readyStatus,
+		Reason:             readyReason,
+		Message:            readyMessage,
+		ObservedGeneration: website.Generation,
+	})
+	if website.Spec.Autoscaling != nil {
+		meta.SetStatusCondition(&website.Status.Conditions, autoscaledCondition(website))
+	} else {
+		meta.RemoveStatusCondition(&website.Status.Conditions, conditionAutoscaled)
+	}
+	if dep.Status.AvailableReplicas+canaryAvailable > 0 {
 		website.Status.Phase = "Running"
 	} else {
 		website.Status.Phase = "Pending"
 	}
+	restart := r.trackDegraded(ctx, website, dep)
+
+	return restart, r.Status().Patch(ctx, website, patch)
+}
+
+// trackDegraded maintains status.degradedSince. Once the Website has been
+// degraded for the threshold it logs a warning and, if the Website opted in,
+// records a restart and starts the degraded clock over.
+func (r *WebsiteReconciler) trackDegraded(ctx context.Context, website *sitesv1.Website, dep *appsv1.Deployment) bool {
+	available := dep.Status.AvailableReplicas
+	degraded := available > 0 && available < desiredReplicas(dep)
+	now := metav1.NewTime(r.now())
+
+	switch {
+	case !degraded:
+		website.Status.DegradedSince = nil
+	case website.Status.DegradedSince == nil:
+		website.Status.DegradedSince = &now
+	case now.Sub(website.Status.DegradedSince.Time) >= r.degradedRestartThreshold():
+		log.FromContext(ctx).Info("Website has been degraded past the threshold",
+			"since", website.Status.DegradedSince.Time, "available", available, "autoRestart", website.Spec.AutoRestartOnDegraded)
+		if website.Spec.AutoRestartOnDegraded {
+			website.Status.LastAutoRestart = &now
+			website.Status.DegradedSince = &now
+			return true
+		}
+	}
+	return false
+}
+
+// untilDegradedRestart returns how long until a degraded Website that opted
+// into restarts is due for one, or zero if none is pending.
+func (r *WebsiteReconciler) untilDegradedRestart(website *sitesv1.Website) time.Duration {
+	if !website.Spec.AutoRestartOnDegraded || website.Status.DegradedSince == nil {
+		return 0
+	}
+	if d := website.Status.DegradedSince.Add(r.degradedRestartThreshold()).Sub(r.now()); d > 0 {
+		return d
+	}
+	return time.Second
+}
+
+// degradedRestartThreshold returns the configured threshold or its default.
+func (r *WebsiteReconciler) degradedRestartThreshold() time.Duration {
+	if r.DegradedRestartThreshold == 0 {
+		return defaultDegradedRestartThreshold
+	}
+	return r.DegradedRestartThreshold
+}
+
+// restartAnnotations returns the pod template annotations that pin the last
+// automatic restart, so re-applying the Deployment does not undo it.
+func restartAnnotations(website *sitesv1.Website) map[string]string {
+	if website.Status.LastAutoRestart == nil {
+		return nil
+	}
+	return map[string]string{restartedAtAnnotation: website.Status.LastAutoRestart.UTC().Format(time.RFC3339)}
+}
+
+// desiredReplicas returns the Deployment's replica count, which defaults to 1.
+func desiredReplicas(dep *appsv1.Deployment) int32 {
+	if dep.Spec.Replicas != nil {
+		return *dep.Spec.Replicas
+	}
+	return 1
+}
+
+// readiness aggregates the Ready condition from the Deployment and the
+// EndpointSlices backing the Service.
+func (r *WebsiteReconciler) readiness(ctx context.Context, website *sitesv1.Website, dep *appsv1.Deployment) (metav1.ConditionStatus, string, string, error) {
+	desired := desiredReplicas(dep)
+	if dep.Status.AvailableReplicas < desired {
+		return metav1.ConditionFalse, "DeploymentUnavailable",
+			fmt.Sprintf("%d of %d replicas available", dep.Status.AvailableReplicas, desired), nil
+	}
+
+	var slices discoveryv1.EndpointSliceList
+	if err := r.List(ctx, &slices, client.InNamespace(website.Namespace),
+		client.MatchingLabels{discoveryv1.LabelServiceName: website.Name}); err != nil {
+		return "", "", "", fmt.Errorf("failed to list endpoint slices: %w", err)
+	}
+	if readyEndpoints(slices.Items) == 0 {
+		return metav1.ConditionFalse, "NoServiceEndpoints",
+			fmt.Sprintf("Service %s has no ready endpoints", website.Name), nil
+	}
+
+	return metav1.ConditionTrue, "Ready", "all replicas are available and the Service has endpoints", nil
+}
+
+// readyEndpoints counts endpoints that can receive traffic. A nil ready
+// condition means ready, per the EndpointSlice API.
+func readyEndpoints(slices []discoveryv1.EndpointSlice) int {
+	n := 0
+	for _, slice := range slices {
+		for _, ep := range slice.Endpoints {
+			if ep.Conditions.Ready == nil || *ep.Conditions.Ready {
+				n++
+			}
+		}
+	}
+	return n
+}
 
+// markSpecInvalid records a validation failure in status without touching owned objects.
+func (r *WebsiteReconciler) markSpecInvalid(ctx context.Context, website *sitesv1.Website, validationErr error) error {
+	patch := client.MergeFrom(website.DeepCopy())
+	website.Status.Phase = "Failed"
+	meta.SetStatusCondition(&website.Status.Conditions, metav1.Condition{
+		Type:               conditionSpecInvalid,
+		Status:             metav1.ConditionTrue,
+		Reason:             "ValidationFailed",
+		Message:            validationErr.Error(),
+		ObservedGeneration: website.Generation,
+	})
 	return r.Status().Patch(ctx, website, patch)
 }
 
-// SetupWithManager sets up the controller with the Manager.
+// now returns the current time from the configured clock.
+func (r *WebsiteReconciler) now() time.Time {
+	if r.Clock == nil {
+		return time.Now()
+	}
+	return r.Clock.Now()
+}
+
+// endpointSliceToWebsite maps an EndpointSlice to the Website whose Service it backs.
+// Slices of unrelated Services map to a Website that does not exist and are dropped by Reconcile.
+func endpointSliceToWebsite(_ context.Context, obj client.Object) []reconcile.Request {
+	name := obj.GetLabels()[discoveryv1.LabelServiceName]
+	if name == "" {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: obj.GetNamespace(), Name: name}}}
+}
+
+// SetupWithManager sets up the controller with the Manager, applying the
+// write modes from Config to the client and tracing its calls with Tracer.
 func (r *WebsiteReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.Client = r.Config.wrapClient(r.Client)
+	if r.Tracer != nil {
+		r.Client = NewTracingClient(r.Client, r.Tracer)
+	}
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&sitesv1.Website{}).
-		Owns(&appsv1.Deployment{}). // Watch Deployments we own
-		Owns(&corev1.Service{}).    // Watch Services we own
-		Complete(r)
+		Owns(&appsv1.Deployment{}).                     // Watch Deployments we own
+		Owns(&corev1.Service{}).                        // Watch Services we own
+		Owns(&autoscalingv2.HorizontalPodAutoscaler{}). // Watch autoscalers we own
+		// EndpointSlices belong to the Service, not to us; map them back by service name
+		Watches(&discoveryv1.EndpointSlice{}, handler.EnqueueRequestsFromMapFunc(endpointSliceToWebsite)).
+		Complete(skipAuditedWrites(r))
 }
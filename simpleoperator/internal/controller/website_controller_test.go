@@ -18,11 +18,28 @@ package controller
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	clocktesting "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -81,4 +98,1520 @@ var _ = Describe("Website Controller", func() {
 			// Example: If you expect a certain status condition after reconciliation, verify it here.
 		})
 	})
+	Context("When configuring extra volumes", func() {
+		const resourceName = "volumes-site"
+
+		ctx := context.Background()
+		namespacedName := types.NamespacedName{Name: resourceName, Namespace: "default"}
+
+		var c client.Client
+		var reconciler *WebsiteReconciler
+
+		BeforeEach(func() {
+			c = newFakeClient()
+			reconciler = &WebsiteReconciler{Client: c, Scheme: testScheme}
+		})
+
+		createWebsite := func(spec sitesv1.WebsiteSpec) {
+			Expect(c.Create(ctx, &sitesv1.Website{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+				Spec:       spec,
+			})).To(Succeed())
+		}
+
+		It("should add custom volumes without disturbing web-content", func() {
+			createWebsite(sitesv1.WebsiteSpec{
+				GitURL:   "https://example.com/site.git",
+				Replicas: 1,
+				Volumes: []corev1.Volume{{
+					Name:         "cache",
+					VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+				}},
+				VolumeMounts: []corev1.VolumeMount{{Name: "cache", MountPath: "/var/cache/nginx"}},
+			})
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: namespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			var dep appsv1.Deployment
+			Expect(c.Get(ctx, namespacedName, &dep)).To(Succeed())
+			podSpec := dep.Spec.Template.Spec
+			Expect(podSpec.Volumes).To(HaveLen(2))
+			Expect(podSpec.Volumes[0].Name).To(Equal("web-content"))
+			Expect(podSpec.Volumes[0].EmptyDir).NotTo(BeNil())
+			Expect(podSpec.Volumes[1].Name).To(Equal("cache"))
+
+			mounts := podSpec.Containers[0].VolumeMounts
+			Expect(mounts).To(ConsistOf(
+				corev1.VolumeMount{Name: "web-content", MountPath: "/git"},
+				corev1.VolumeMount{Name: "cache", MountPath: "/var/cache/nginx"},
+			))
+			Expect(podSpec.InitContainers[0].VolumeMounts).To(HaveLen(1))
+		})
+
+		It("should drop custom volumes removed from the spec", func() {
+			createWebsite(sitesv1.WebsiteSpec{
+				GitURL:   "https://example.com/site.git",
+				Replicas: 1,
+				Volumes: []corev1.Volume{{
+					Name:         "cache",
+					VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+				}},
+				VolumeMounts: []corev1.VolumeMount{{Name: "cache", MountPath: "/var/cache/nginx"}},
+			})
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: namespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			var website sitesv1.Website
+			Expect(c.Get(ctx, namespacedName, &website)).To(Succeed())
+			website.Spec.Volumes = nil
+			website.Spec.VolumeMounts = nil
+			Expect(c.Update(ctx, &website)).To(Succeed())
+
+			_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: namespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			var dep appsv1.Deployment
+			Expect(c.Get(ctx, namespacedName, &dep)).To(Succeed())
+			Expect(dep.Spec.Template.Spec.Volumes).To(HaveLen(1))
+			Expect(dep.Spec.Template.Spec.Containers[0].VolumeMounts).To(HaveLen(1))
+		})
+
+		It("should reject volumes that collide with the managed volume", func() {
+			createWebsite(sitesv1.WebsiteSpec{
+				GitURL:   "https://example.com/site.git",
+				Replicas: 1,
+				Volumes: []corev1.Volume{{
+					Name:         "web-content",
+					VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+				}},
+			})
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: namespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			var dep appsv1.Deployment
+			Expect(errors.IsNotFound(c.Get(ctx, namespacedName, &dep))).To(BeTrue())
+
+			var website sitesv1.Website
+			Expect(c.Get(ctx, namespacedName, &website)).To(Succeed())
+			Expect(website.Status.Phase).To(Equal("Failed"))
+			cond := meta.FindStatusCondition(website.Status.Conditions, conditionSpecInvalid)
+			Expect(cond).NotTo(BeNil())
+			Expect(cond.Message).To(ContainSubstring("web-content"))
+		})
+	})
+	Context("When the Service drifts", func() {
+		const resourceName = "service-site"
+
+		ctx := context.Background()
+		namespacedName := types.NamespacedName{Name: resourceName, Namespace: "default"}
+
+		var c client.Client
+		var reconciler *WebsiteReconciler
+
+		BeforeEach(func() {
+			c = newFakeClient()
+			reconciler = &WebsiteReconciler{Client: c, Scheme: testScheme}
+			Expect(c.Create(ctx, &sitesv1.Website{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+				Spec: sitesv1.WebsiteSpec{
+					GitURL:   "https://example.com/site.git",
+					Replicas: 1,
+				},
+			})).To(Succeed())
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: namespacedName})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		// assignClusterIP simulates the API server allocating a ClusterIP.
+		assignClusterIP := func(ip string) {
+			var svc corev1.Service
+			Expect(c.Get(ctx, namespacedName, &svc)).To(Succeed())
+			svc.Spec.ClusterIP = ip
+			svc.Spec.ClusterIPs = []string{ip}
+			Expect(c.Update(ctx, &svc)).To(Succeed())
+		}
+
+		It("should default the Service port to 80", func() {
+			var svc corev1.Service
+			Expect(c.Get(ctx, namespacedName, &svc)).To(Succeed())
+			Expect(svc.Spec.Ports).To(HaveLen(1))
+			Expect(svc.Spec.Ports[0].Port).To(Equal(int32(80)))
+			Expect(svc.Spec.Ports[0].TargetPort.IntValue()).To(Equal(80))
+		})
+
+		It("should update the port without touching the ClusterIP", func() {
+			assignClusterIP("10.96.0.42")
+
+			var website sitesv1.Website
+			Expect(c.Get(ctx, namespacedName, &website)).To(Succeed())
+			website.Spec.Port = 8080
+			Expect(c.Update(ctx, &website)).To(Succeed())
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: namespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			var svc corev1.Service
+			Expect(c.Get(ctx, namespacedName, &svc)).To(Succeed())
+			Expect(svc.Spec.Ports).To(HaveLen(1))
+			Expect(svc.Spec.Ports[0].Port).To(Equal(int32(8080)))
+			Expect(svc.Spec.Ports[0].TargetPort.IntValue()).To(Equal(80))
+			Expect(svc.Spec.ClusterIP).To(Equal("10.96.0.42"))
+		})
+
+		It("should restore a selector edited out of band", func() {
+			var svc corev1.Service
+			Expect(c.Get(ctx, namespacedName, &svc)).To(Succeed())
+			svc.Spec.Selector = map[string]string{"app": "someone-else", "tier": "web"}
+			Expect(c.Update(ctx, &svc)).To(Succeed())
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: namespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(c.Get(ctx, namespacedName, &svc)).To(Succeed())
+			Expect(svc.Spec.Selector).To(Equal(map[string]string{"app": resourceName}))
+		})
+	})
+	Context("When a schedule is set", func() {
+		const resourceName = "scheduled-site"
+
+		ctx := context.Background()
+		namespacedName := types.NamespacedName{Name: resourceName, Namespace: "default"}
+
+		var c client.Client
+
+		BeforeEach(func() {
+			c = newFakeClient()
+			Expect(c.Create(ctx, &sitesv1.Website{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+				Spec: sitesv1.WebsiteSpec{
+					GitURL:   "https://example.com/site.git",
+					Replicas: 3,
+					Schedule: &sitesv1.WebsiteSchedule{
+						ScaleUpCron:   "0 9 * * 1-5",
+						ScaleDownCron: "0 18 * * 1-5",
+						DownReplicas:  0,
+					},
+				},
+			})).To(Succeed())
+		})
+
+		// reconcileAt runs one reconcile with the clock fixed at the given time.
+		reconcileAt := func(now string) (reconcile.Result, int32) {
+			t, err := time.Parse(time.RFC3339, now)
+			Expect(err).NotTo(HaveOccurred())
+			reconciler := &WebsiteReconciler{Client: c, Scheme: testScheme, Clock: clocktesting.NewFakePassiveClock(t)}
+			result, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: namespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			var dep appsv1.Deployment
+			Expect(c.Get(ctx, namespacedName, &dep)).To(Succeed())
+			return result, *dep.Spec.Replicas
+		}
+
+		It("should run spec.replicas inside the window", func() {
+			result, replicas := reconcileAt("2026-10-14T10:00:00Z") // Wednesday
+			Expect(replicas).To(Equal(int32(3)))
+			Expect(result.RequeueAfter).To(Equal(8 * time.Hour))
+		})
+
+		It("should scale down outside the window", func() {
+			result, replicas := reconcileAt("2026-10-14T20:00:00Z")
+			Expect(replicas).To(Equal(int32(0)))
+			Expect(result.RequeueAfter).To(Equal(13 * time.Hour))
+		})
+
+		It("should stay down over the weekend", func() {
+			result, replicas := reconcileAt("2026-10-17T12:00:00Z") // Saturday
+			Expect(replicas).To(Equal(int32(0)))
+			Expect(result.RequeueAfter).To(Equal(45 * time.Hour))
+		})
+
+		It("should reject an invalid cron expression", func() {
+			var website sitesv1.Website
+			Expect(c.Get(ctx, namespacedName, &website)).To(Succeed())
+			website.Spec.Schedule.ScaleDownCron = "0 25 * * *"
+			Expect(c.Update(ctx, &website)).To(Succeed())
+
+			reconciler := &WebsiteReconciler{Client: c, Scheme: testScheme}
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: namespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(c.Get(ctx, namespacedName, &website)).To(Succeed())
+			cond := meta.FindStatusCondition(website.Status.Conditions, conditionSpecInvalid)
+			Expect(cond).NotTo(BeNil())
+			Expect(cond.Message).To(ContainSubstring("scaleDownCron"))
+		})
+	})
+	Context("When the API server returns transient errors", func() {
+		const resourceName = "flaky-site"
+
+		ctx := context.Background()
+		namespacedName := types.NamespacedName{Name: resourceName, Namespace: "default"}
+
+		// failApplies fails server-side applies of the given kind with errFor until it returns nil.
+		failApplies := func(kind string, errFor func() error) interceptor.Funcs {
+			return interceptor.Funcs{
+				Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+					if obj.GetObjectKind().GroupVersionKind().Kind == kind {
+						if err := errFor(); err != nil {
+							return err
+						}
+					}
+					return c.Patch(ctx, obj, patch, opts...)
+				},
+			}
+		}
+
+		createWebsite := func(c client.Client) {
+			Expect(c.Create(ctx, &sitesv1.Website{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+				Spec:       sitesv1.WebsiteSpec{GitURL: "https://example.com/site.git", Replicas: 1},
+			})).To(Succeed())
+		}
+
+		It("should retry a conflicting apply and recover within one reconcile", func() {
+			conflicts := 2
+			c := newInterceptedClient(failApplies("Deployment", func() error {
+				if conflicts == 0 {
+					return nil
+				}
+				conflicts--
+				return errors.NewConflict(schema.GroupResource{Group: "apps", Resource: "deployments"}, resourceName, nil)
+			}))
+			createWebsite(c)
+
+			reconciler := &WebsiteReconciler{Client: c, Scheme: testScheme}
+			result, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: namespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeZero())
+			Expect(conflicts).To(BeZero())
+
+			var dep appsv1.Deployment
+			Expect(c.Get(ctx, namespacedName, &dep)).To(Succeed())
+		})
+
+		It("should back off with a growing delay and reset once the error clears", func() {
+			failing := true
+			c := newInterceptedClient(failApplies("Service", func() error {
+				if !failing {
+					return nil
+				}
+				return errors.NewServerTimeout(schema.GroupResource{Resource: "services"}, "patch", 1)
+			}))
+			createWebsite(c)
+			reconciler := &WebsiteReconciler{Client: c, Scheme: testScheme}
+
+			var website sitesv1.Website
+			for i, want := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second} {
+				result, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: namespacedName})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.RequeueAfter).To(Equal(want))
+
+				Expect(c.Get(ctx, namespacedName, &website)).To(Succeed())
+				Expect(website.Status.ConsecutiveFailures).To(Equal(int32(i + 1)))
+			}
+
+			failing = false
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: namespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(c.Get(ctx, namespacedName, &website)).To(Succeed())
+			Expect(website.Status.ConsecutiveFailures).To(BeZero())
+		})
+
+		It("should return non-transient errors to the rate limiter", func() {
+			c := newInterceptedClient(failApplies("Deployment", func() error {
+				return errors.NewForbidden(schema.GroupResource{Group: "apps", Resource: "deployments"}, resourceName, nil)
+			}))
+			createWebsite(c)
+
+			reconciler := &WebsiteReconciler{Client: c, Scheme: testScheme}
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: namespacedName})
+			Expect(errors.IsForbidden(err)).To(BeTrue())
+		})
+
+		It("should cap the backoff", func() {
+			Expect(transientBackoff(1)).To(Equal(time.Second))
+			Expect(transientBackoff(9)).To(Equal(256 * time.Second))
+			Expect(transientBackoff(10)).To(Equal(5 * time.Minute))
+			Expect(transientBackoff(1000)).To(Equal(5 * time.Minute))
+		})
+	})
+	Context("When computing readiness", func() {
+		const resourceName = "ready-site"
+
+		ctx := context.Background()
+		namespacedName := types.NamespacedName{Name: resourceName, Namespace: "default"}
+
+		var c client.Client
+		var reconciler *WebsiteReconciler
+
+		BeforeEach(func() {
+			c = newFakeClient()
+			reconciler = &WebsiteReconciler{Client: c, Scheme: testScheme}
+			Expect(c.Create(ctx, &sitesv1.Website{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+				Spec:       sitesv1.WebsiteSpec{GitURL: "https://example.com/site.git", Replicas: 2},
+			})).To(Succeed())
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: namespacedName})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		// setAvailableReplicas simulates the Deployment controller reporting progress.
+		setAvailableReplicas := func(n int32) {
+			var dep appsv1.Deployment
+			Expect(c.Get(ctx, namespacedName, &dep)).To(Succeed())
+			dep.Status.AvailableReplicas = n
+			Expect(c.Status().Update(ctx, &dep)).To(Succeed())
+		}
+
+		// createEndpointSlice simulates the EndpointSlice controller publishing pod endpoints.
+		createEndpointSlice := func(ready ...bool) {
+			slice := &discoveryv1.EndpointSlice{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName + "-abcde",
+					Namespace: "default",
+					Labels:    map[string]string{discoveryv1.LabelServiceName: resourceName},
+				},
+				AddressType: discoveryv1.AddressTypeIPv4,
+			}
+			for i, r := range ready {
+				slice.Endpoints = append(slice.Endpoints, discoveryv1.Endpoint{
+					Addresses:  []string{fmt.Sprintf("10.0.0.%d", i+1)},
+					Conditions: discoveryv1.EndpointConditions{Ready: ptr.To(r)},
+				})
+			}
+			Expect(c.Create(ctx, slice)).To(Succeed())
+		}
+
+		readyCondition := func() *metav1.Condition {
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: namespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			var website sitesv1.Website
+			Expect(c.Get(ctx, namespacedName, &website)).To(Succeed())
+			cond := meta.FindStatusCondition(website.Status.Conditions, conditionReady)
+			Expect(cond).NotTo(BeNil())
+			return cond
+		}
+
+		It("should not be ready while replicas are unavailable", func() {
+			setAvailableReplicas(1)
+			createEndpointSlice(true)
+
+			cond := readyCondition()
+			Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+			Expect(cond.Reason).To(Equal("DeploymentUnavailable"))
+			Expect(cond.Message).To(ContainSubstring("1 of 2"))
+		})
+
+		It("should not be ready without Service endpoints", func() {
+			setAvailableReplicas(2)
+
+			cond := readyCondition()
+			Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+			Expect(cond.Reason).To(Equal("NoServiceEndpoints"))
+		})
+
+		It("should not count endpoints that are not ready", func() {
+			setAvailableReplicas(2)
+			createEndpointSlice(false, false)
+
+			cond := readyCondition()
+			Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+			Expect(cond.Reason).To(Equal("NoServiceEndpoints"))
+		})
+
+		It("should be ready once replicas are available and endpoints are ready", func() {
+			setAvailableReplicas(2)
+			createEndpointSlice(true, false)
+
+			cond := readyCondition()
+			Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+			Expect(cond.Reason).To(Equal("Ready"))
+		})
+
+		It("should map EndpointSlices back to the Website by service name", func() {
+			slice := &discoveryv1.EndpointSlice{ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Labels:    map[string]string{discoveryv1.LabelServiceName: resourceName},
+			}}
+			Expect(endpointSliceToWebsite(ctx, slice)).To(ConsistOf(reconcile.Request{NamespacedName: namespacedName}))
+			Expect(endpointSliceToWebsite(ctx, &discoveryv1.EndpointSlice{})).To(BeEmpty())
+		})
+	})
+	Context("When overriding the web server container", func() {
+		const resourceName = "custom-server-site"
+
+		ctx := context.Background()
+		namespacedName := types.NamespacedName{Name: resourceName, Namespace: "default"}
+
+		var c client.Client
+		var reconciler *WebsiteReconciler
+
+		BeforeEach(func() {
+			c = newFakeClient()
+			reconciler = &WebsiteReconciler{Client: c, Scheme: testScheme}
+		})
+
+		reconcileSpec := func(spec sitesv1.WebsiteSpec) corev1.Container {
+			Expect(c.Create(ctx, &sitesv1.Website{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+				Spec:       spec,
+			})).To(Succeed())
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: namespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			var dep appsv1.Deployment
+			Expect(c.Get(ctx, namespacedName, &dep)).To(Succeed())
+			Expect(dep.Spec.Template.Spec.Containers).To(HaveLen(1))
+			return dep.Spec.Template.Spec.Containers[0]
+		}
+
+		It("should run nginx with the copy-and-serve entrypoint by default", func() {
+			container := reconcileSpec(sitesv1.WebsiteSpec{GitURL: "https://example.com/site.git", Replicas: 1})
+			Expect(container.Name).To(Equal("nginx"))
+			Expect(container.Image).To(Equal("nginx:alpine"))
+			Expect(container.Command).To(Equal([]string{"/bin/sh", "-c"}))
+			Expect(container.Args).To(ConsistOf(ContainSubstring("/git/current")))
+		})
+
+		It("should propagate image, name, command and args to the Deployment", func() {
+			container := reconcileSpec(sitesv1.WebsiteSpec{
+				GitURL:        "https://example.com/site.git",
+				Replicas:      1,
+				Image:         "caddy:2",
+				ContainerName: "caddy",
+				Command:       []string{"caddy"},
+				Args:          []string{"file-server", "--root", "/git/current", "--listen", ":80"},
+			})
+			Expect(container.Name).To(Equal("caddy"))
+			Expect(container.Image).To(Equal("caddy:2"))
+			Expect(container.Command).To(Equal([]string{"caddy"}))
+			Expect(container.Args).To(Equal([]string{"file-server", "--root", "/git/current", "--listen", ":80"}))
+			Expect(container.VolumeMounts).To(ContainElement(corev1.VolumeMount{Name: "web-content", MountPath: "/git"}))
+		})
+
+		It("should drop the default args when only the command is overridden", func() {
+			container := reconcileSpec(sitesv1.WebsiteSpec{
+				GitURL:   "https://example.com/site.git",
+				Replicas: 1,
+				Command:  []string{"/usr/local/bin/serve"},
+			})
+			Expect(container.Command).To(Equal([]string{"/usr/local/bin/serve"}))
+			Expect(container.Args).To(BeEmpty())
+		})
+
+		It("should correct drift when the override changes", func() {
+			reconcileSpec(sitesv1.WebsiteSpec{
+				GitURL:   "https://example.com/site.git",
+				Replicas: 1,
+				Command:  []string{"httpd", "-f"},
+			})
+
+			var website sitesv1.Website
+			Expect(c.Get(ctx, namespacedName, &website)).To(Succeed())
+			website.Spec.Command = []string{"busybox", "httpd", "-f", "-h", "/git/current"}
+			Expect(c.Update(ctx, &website)).To(Succeed())
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: namespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			var dep appsv1.Deployment
+			Expect(c.Get(ctx, namespacedName, &dep)).To(Succeed())
+			Expect(dep.Spec.Template.Spec.Containers[0].Command).To(Equal([]string{"busybox", "httpd", "-f", "-h", "/git/current"}))
+		})
+
+		DescribeTable("rejecting incompatible overrides",
+			func(spec sitesv1.WebsiteSpec, message string) {
+				spec.GitURL = "https://example.com/site.git"
+				spec.Replicas = 1
+				Expect(c.Create(ctx, &sitesv1.Website{
+					ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+					Spec:       spec,
+				})).To(Succeed())
+				_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: namespacedName})
+				Expect(err).NotTo(HaveOccurred())
+
+				var website sitesv1.Website
+				Expect(c.Get(ctx, namespacedName, &website)).To(Succeed())
+				cond := meta.FindStatusCondition(website.Status.Conditions, conditionSpecInvalid)
+				Expect(cond).NotTo(BeNil())
+				Expect(cond.Message).To(ContainSubstring(message))
+			},
+			Entry("a mount shadowing the synced content", sitesv1.WebsiteSpec{
+				Volumes:      []corev1.Volume{{Name: "site", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}}},
+				VolumeMounts: []corev1.VolumeMount{{Name: "site", MountPath: "/git/current"}},
+			}, "shadow"),
+			Entry("the content sync container name", sitesv1.WebsiteSpec{ContainerName: "git-sync"}, "spec.containerName"),
+			Entry("a sidecar named like the web server", sitesv1.WebsiteSpec{
+				Sidecars: []corev1.Container{{Name: "nginx", Image: "busybox"}},
+			}, "spec.sidecars"),
+		)
+	})
+
+	Context("When spreading replicas across nodes", func() {
+		const resourceName = "spread-site"
+
+		ctx := context.Background()
+		namespacedName := types.NamespacedName{Name: resourceName, Namespace: "default"}
+
+		var c client.Client
+		var reconciler *WebsiteReconciler
+
+		BeforeEach(func() {
+			c = newFakeClient()
+			reconciler = &WebsiteReconciler{Client: c, Scheme: testScheme}
+		})
+
+		reconcileAndGetAffinity := func() *corev1.Affinity {
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: namespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			var dep appsv1.Deployment
+			Expect(c.Get(ctx, namespacedName, &dep)).To(Succeed())
+			return dep.Spec.Template.Spec.Affinity
+		}
+
+		It("should add no affinity by default", func() {
+			Expect(c.Create(ctx, &sitesv1.Website{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+				Spec:       sitesv1.WebsiteSpec{GitURL: "https://example.com/site.git", Replicas: 3},
+			})).To(Succeed())
+			Expect(reconcileAndGetAffinity()).To(BeNil())
+		})
+
+		It("should inject a preferred anti-affinity and remove it when turned off", func() {
+			Expect(c.Create(ctx, &sitesv1.Website{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+				Spec:       sitesv1.WebsiteSpec{GitURL: "https://example.com/site.git", Replicas: 3, AntiAffinity: true},
+			})).To(Succeed())
+
+			affinity := reconcileAndGetAffinity()
+			Expect(affinity).NotTo(BeNil())
+			Expect(affinity.PodAntiAffinity).NotTo(BeNil())
+			Expect(affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution).To(BeEmpty())
+			terms := affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution
+			Expect(terms).To(HaveLen(1))
+			Expect(terms[0].PodAffinityTerm.TopologyKey).To(Equal("kubernetes.io/hostname"))
+			Expect(terms[0].PodAffinityTerm.LabelSelector.MatchLabels).To(Equal(map[string]string{"app": resourceName}))
+
+			var website sitesv1.Website
+			Expect(c.Get(ctx, namespacedName, &website)).To(Succeed())
+			website.Spec.AntiAffinity = false
+			Expect(c.Update(ctx, &website)).To(Succeed())
+
+			affinity = reconcileAndGetAffinity()
+			Expect(affinity == nil || affinity.PodAntiAffinity == nil).To(BeTrue())
+		})
+	})
+
+	Context("When running sidecars", func() {
+		const resourceName = "sidecar-site"
+
+		ctx := context.Background()
+		namespacedName := types.NamespacedName{Name: resourceName, Namespace: "default"}
+
+		var c client.Client
+		var reconciler *WebsiteReconciler
+
+		BeforeEach(func() {
+			c = newFakeClient()
+			reconciler = &WebsiteReconciler{Client: c, Scheme: testScheme}
+		})
+
+		reconcilePodSpec := func(order sitesv1.SidecarStartOrder) corev1.PodSpec {
+			Expect(c.Create(ctx, &sitesv1.Website{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+				Spec: sitesv1.WebsiteSpec{
+					GitURL:   "https://example.com/site.git",
+					Replicas: 1,
+					Sidecars: []corev1.Container{
+						{Name: "log-shipper", Image: "fluent-bit"},
+						{Name: "exporter", Image: "nginx-exporter"},
+					},
+					SidecarStartOrder: order,
+				},
+			})).To(Succeed())
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: namespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			var dep appsv1.Deployment
+			Expect(c.Get(ctx, namespacedName, &dep)).To(Succeed())
+			return dep.Spec.Template.Spec
+		}
+
+		containerNames := func(containers []corev1.Container) []string {
+			var names []string
+			for _, c := range containers {
+				names = append(names, c.Name)
+			}
+			return names
+		}
+
+		It("should start sidecars alongside the web server by default", func() {
+			pod := reconcilePodSpec("")
+
+			Expect(containerNames(pod.InitContainers)).To(Equal([]string{"git-sync"}))
+			Expect(containerNames(pod.Containers)).To(Equal([]string{"nginx", "log-shipper", "exporter"}))
+			for _, container := range pod.Containers {
+				Expect(container.RestartPolicy).To(BeNil())
+			}
+		})
+
+		It("should start sidecars after git-sync and before the web server", func() {
+			pod := reconcilePodSpec(sitesv1.SidecarStartOrderBeforeWebServer)
+
+			Expect(containerNames(pod.InitContainers)).To(Equal([]string{"git-sync", "log-shipper", "exporter"}))
+			Expect(pod.InitContainers[0].RestartPolicy).To(BeNil())
+			for _, sidecar := range pod.InitContainers[1:] {
+				Expect(sidecar.RestartPolicy).To(HaveValue(Equal(corev1.ContainerRestartPolicyAlways)))
+			}
+			Expect(containerNames(pod.Containers)).To(Equal([]string{"nginx"}))
+		})
+	})
+
+	Context("When limiting the content volume size", func() {
+		const resourceName = "size-site"
+
+		ctx := context.Background()
+		namespacedName := types.NamespacedName{Name: resourceName, Namespace: "default"}
+
+		var c client.Client
+		var reconciler *WebsiteReconciler
+
+		BeforeEach(func() {
+			c = newFakeClient()
+			reconciler = &WebsiteReconciler{Client: c, Scheme: testScheme}
+		})
+
+		reconcileAndGetSizeLimit := func() *resource.Quantity {
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: namespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			var dep appsv1.Deployment
+			Expect(c.Get(ctx, namespacedName, &dep)).To(Succeed())
+			for _, v := range dep.Spec.Template.Spec.Volumes {
+				if v.Name == contentVolumeName {
+					Expect(v.EmptyDir).NotTo(BeNil())
+					return v.EmptyDir.SizeLimit
+				}
+			}
+			Fail("content volume not found")
+			return nil
+		}
+
+		It("should default the limit to 1Gi and apply changes to it", func() {
+			Expect(c.Create(ctx, &sitesv1.Website{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+				Spec:       sitesv1.WebsiteSpec{GitURL: "https://example.com/site.git", Replicas: 1},
+			})).To(Succeed())
+
+			limit := reconcileAndGetSizeLimit()
+			Expect(limit).NotTo(BeNil())
+			Expect(limit.Cmp(resource.MustParse("1Gi"))).To(BeZero())
+
+			var website sitesv1.Website
+			Expect(c.Get(ctx, namespacedName, &website)).To(Succeed())
+			website.Spec.ContentSizeLimit = ptr.To(resource.MustParse("5Gi"))
+			Expect(c.Update(ctx, &website)).To(Succeed())
+
+			limit = reconcileAndGetSizeLimit()
+			Expect(limit).NotTo(BeNil())
+			Expect(limit.Cmp(resource.MustParse("5Gi"))).To(BeZero())
+		})
+	})
+
+	Context("When a Website stays degraded", func() {
+		const resourceName = "degraded-site"
+
+		ctx := context.Background()
+		namespacedName := types.NamespacedName{Name: resourceName, Namespace: "default"}
+
+		var c client.Client
+		var fakeClock *clocktesting.FakePassiveClock
+		var reconciler *WebsiteReconciler
+
+		BeforeEach(func() {
+			c = newFakeClient()
+			fakeClock = clocktesting.NewFakePassiveClock(time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC))
+			reconciler = &WebsiteReconciler{Client: c, Scheme: testScheme, Clock: fakeClock}
+		})
+
+		createDegraded := func(autoRestart bool) {
+			Expect(c.Create(ctx, &sitesv1.Website{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+				Spec: sitesv1.WebsiteSpec{
+					GitURL:                "https://example.com/site.git",
+					Replicas:              3,
+					AutoRestartOnDegraded: autoRestart,
+				},
+			})).To(Succeed())
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: namespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			var dep appsv1.Deployment
+			Expect(c.Get(ctx, namespacedName, &dep)).To(Succeed())
+			dep.Status.AvailableReplicas = 1
+			Expect(c.Status().Update(ctx, &dep)).To(Succeed())
+		}
+
+		reconcileSite := func() (reconcile.Result, sitesv1.Website, appsv1.Deployment) {
+			result, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: namespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			var website sitesv1.Website
+			Expect(c.Get(ctx, namespacedName, &website)).To(Succeed())
+			var dep appsv1.Deployment
+			Expect(c.Get(ctx, namespacedName, &dep)).To(Succeed())
+			return result, website, dep
+		}
+
+		It("should restart the pods once the threshold has passed", func() {
+			createDegraded(true)
+
+			result, website, dep := reconcileSite()
+			Expect(website.Status.DegradedSince).NotTo(BeNil())
+			Expect(website.Status.DegradedSince.Time).To(BeTemporally("==", fakeClock.Now()))
+			Expect(dep.Spec.Template.Annotations).NotTo(HaveKey(restartedAtAnnotation))
+			Expect(result.RequeueAfter).To(Equal(defaultDegradedRestartThreshold))
+
+			// Not yet
+			fakeClock.SetTime(fakeClock.Now().Add(defaultDegradedRestartThreshold - time.Minute))
+			_, website, dep = reconcileSite()
+			Expect(website.Status.LastAutoRestart).To(BeNil())
+			Expect(dep.Spec.Template.Annotations).NotTo(HaveKey(restartedAtAnnotation))
+
+			fakeClock.SetTime(fakeClock.Now().Add(time.Minute))
+			_, website, dep = reconcileSite()
+			Expect(website.Status.LastAutoRestart).NotTo(BeNil())
+			Expect(website.Status.LastAutoRestart.Time).To(BeTemporally("==", fakeClock.Now()))
+			Expect(website.Status.DegradedSince.Time).To(BeTemporally("==", fakeClock.Now()))
+			Expect(dep.Spec.Template.Annotations).To(HaveKeyWithValue(restartedAtAnnotation, "2026-03-02T09:10:00Z"))
+
+			// Re-applying keeps the annotation, so the pods are not rolled back
+			_, _, dep = reconcileSite()
+			Expect(dep.Spec.Template.Annotations).To(HaveKeyWithValue(restartedAtAnnotation, "2026-03-02T09:10:00Z"))
+		})
+
+		It("should only track the degraded time when restarts are off", func() {
+			createDegraded(false)
+
+			result, website, _ := reconcileSite()
+			Expect(website.Status.DegradedSince).NotTo(BeNil())
+			Expect(result.RequeueAfter).To(BeZero())
+
+			fakeClock.SetTime(fakeClock.Now().Add(2 * defaultDegradedRestartThreshold))
+			_, website, dep := reconcileSite()
+			Expect(website.Status.LastAutoRestart).To(BeNil())
+			Expect(dep.Spec.Template.Annotations).NotTo(HaveKey(restartedAtAnnotation))
+		})
+
+		It("should clear the degraded time once all replicas are available", func() {
+			createDegraded(true)
+			_, website, _ := reconcileSite()
+			Expect(website.Status.DegradedSince).NotTo(BeNil())
+
+			var dep appsv1.Deployment
+			Expect(c.Get(ctx, namespacedName, &dep)).To(Succeed())
+			dep.Status.AvailableReplicas = 3
+			Expect(c.Status().Update(ctx, &dep)).To(Succeed())
+
+			_, website, _ = reconcileSite()
+			Expect(website.Status.DegradedSince).To(BeNil())
+		})
+	})
+
+	Context("When running in audit-only mode", func() {
+		const resourceName = "audited-site"
+
+		ctx := context.Background()
+		namespacedName := types.NamespacedName{Name: resourceName, Namespace: "default"}
+
+		var c client.Client
+		var auditor reconcile.Reconciler
+
+		BeforeEach(func() {
+			c = newFakeClient()
+			auditor = skipAuditedWrites(&WebsiteReconciler{Client: NewAuditOnlyClient(c), Scheme: testScheme})
+			Expect(c.Create(ctx, &sitesv1.Website{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+				Spec:       sitesv1.WebsiteSpec{GitURL: "https://example.com/site.git", Replicas: 2},
+			})).To(Succeed())
+		})
+
+		It("should not create anything for a new Website", func() {
+			var before sitesv1.Website
+			Expect(c.Get(ctx, namespacedName, &before)).To(Succeed())
+
+			result, err := auditor.Reconcile(ctx, reconcile.Request{NamespacedName: namespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(reconcile.Result{}))
+
+			Expect(errors.IsNotFound(c.Get(ctx, namespacedName, &appsv1.Deployment{}))).To(BeTrue())
+			Expect(errors.IsNotFound(c.Get(ctx, namespacedName, &corev1.Service{}))).To(BeTrue())
+			var after sitesv1.Website
+			Expect(c.Get(ctx, namespacedName, &after)).To(Succeed())
+			Expect(after.ResourceVersion).To(Equal(before.ResourceVersion))
+		})
+
+		It("should leave drifted objects and status untouched", func() {
+			_, err := (&WebsiteReconciler{Client: c, Scheme: testScheme}).Reconcile(ctx, reconcile.Request{NamespacedName: namespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			var website sitesv1.Website
+			Expect(c.Get(ctx, namespacedName, &website)).To(Succeed())
+			website.Spec.Replicas = 5
+			website.Spec.Port = 8080
+			Expect(c.Update(ctx, &website)).To(Succeed())
+			var dep appsv1.Deployment
+			Expect(c.Get(ctx, namespacedName, &dep)).To(Succeed())
+			var svc corev1.Service
+			Expect(c.Get(ctx, namespacedName, &svc)).To(Succeed())
+
+			_, err = auditor.Reconcile(ctx, reconcile.Request{NamespacedName: namespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			var afterDep appsv1.Deployment
+			Expect(c.Get(ctx, namespacedName, &afterDep)).To(Succeed())
+			Expect(afterDep.ResourceVersion).To(Equal(dep.ResourceVersion))
+			Expect(*afterDep.Spec.Replicas).To(Equal(int32(2)))
+			var afterSvc corev1.Service
+			Expect(c.Get(ctx, namespacedName, &afterSvc)).To(Succeed())
+			Expect(afterSvc.ResourceVersion).To(Equal(svc.ResourceVersion))
+			var afterSite sitesv1.Website
+			Expect(c.Get(ctx, namespacedName, &afterSite)).To(Succeed())
+			Expect(afterSite.ResourceVersion).To(Equal(website.ResourceVersion))
+		})
+
+		It("should report the skipped write when called directly", func() {
+			_, err := (&WebsiteReconciler{Client: NewAuditOnlyClient(c), Scheme: testScheme}).Reconcile(ctx, reconcile.Request{NamespacedName: namespacedName})
+			Expect(err).To(MatchError(errAuditOnly))
+			Expect(err.Error()).To(ContainSubstring("would patch Deployment default/audited-site"))
+		})
+	})
+
+	Context("When running in dry-run mode", func() {
+		const resourceName = "dry-run-site"
+
+		ctx := context.Background()
+		namespacedName := types.NamespacedName{Name: resourceName, Namespace: "default"}
+
+		It("should not persist anything or requeue for a new Website", func() {
+			c := newFakeClient()
+			Expect(c.Create(ctx, &sitesv1.Website{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+				Spec:       sitesv1.WebsiteSpec{GitURL: "https://example.com/site.git", Replicas: 2},
+			})).To(Succeed())
+			var before sitesv1.Website
+			Expect(c.Get(ctx, namespacedName, &before)).To(Succeed())
+			config := ReconcileConfig{DryRun: true}
+			reconciler := &WebsiteReconciler{Client: config.wrapClient(c), Scheme: testScheme, Config: config}
+
+			result, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: namespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(reconcile.Result{}))
+
+			Expect(errors.IsNotFound(c.Get(ctx, namespacedName, &appsv1.Deployment{}))).To(BeTrue())
+			Expect(errors.IsNotFound(c.Get(ctx, namespacedName, &corev1.Service{}))).To(BeTrue())
+			var after sitesv1.Website
+			Expect(c.Get(ctx, namespacedName, &after)).To(Succeed())
+			Expect(after.ResourceVersion).To(Equal(before.ResourceVersion))
+		})
+	})
+
+	Context("When running a canary", func() {
+		const resourceName = "canary-site"
+
+		ctx := context.Background()
+		namespacedName := types.NamespacedName{Name: resourceName, Namespace: "default"}
+		canaryName := types.NamespacedName{Name: resourceName + canarySuffix, Namespace: "default"}
+
+		var c client.Client
+		var reconciler *WebsiteReconciler
+
+		BeforeEach(func() {
+			c = newFakeClient()
+			reconciler = &WebsiteReconciler{Client: c, Scheme: testScheme}
+			Expect(c.Create(ctx, &sitesv1.Website{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+				Spec: sitesv1.WebsiteSpec{
+					GitURL:   "https://example.com/site.git",
+					Replicas: 4,
+					Canary:   &sitesv1.WebsiteCanary{Image: "nginx:canary", Weight: 25},
+				},
+			})).To(Succeed())
+		})
+
+		reconcileAndGetDeployments := func() (stable, canary *appsv1.Deployment) {
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: namespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			stable = &appsv1.Deployment{}
+			Expect(c.Get(ctx, namespacedName, stable)).To(Succeed())
+			canary = &appsv1.Deployment{}
+			err = c.Get(ctx, canaryName, canary)
+			if errors.IsNotFound(err) {
+				return stable, nil
+			}
+			Expect(err).NotTo(HaveOccurred())
+			return stable, canary
+		}
+
+		It("should create a canary Deployment behind the same Service", func() {
+			stable, canary := reconcileAndGetDeployments()
+			Expect(*stable.Spec.Replicas).To(Equal(int32(3)))
+			Expect(stable.Spec.Template.Spec.Containers[0].Image).To(Equal(defaultImage))
+
+			Expect(canary).NotTo(BeNil())
+			Expect(*canary.Spec.Replicas).To(Equal(int32(1)))
+			Expect(canary.Spec.Template.Spec.Containers[0].Image).To(Equal("nginx:canary"))
+			Expect(canary.Spec.Template.Labels).To(Equal(map[string]string{"app": resourceName, trackLabel: "canary"}))
+			Expect(stable.Spec.Selector.MatchLabels).To(Equal(map[string]string{"app": resourceName, trackLabel: "stable"}),
+				"the stable Deployment must not select canary pods")
+			Expect(canary.OwnerReferences).To(HaveLen(1))
+			Expect(canary.OwnerReferences[0].Name).To(Equal(resourceName))
+
+			var svc corev1.Service
+			Expect(c.Get(ctx, namespacedName, &svc)).To(Succeed())
+			Expect(svc.Spec.Selector).To(Equal(map[string]string{"app": resourceName}))
+		})
+
+		It("should shift replicas when the weight changes and clean up when removed", func() {
+			reconcileAndGetDeployments()
+
+			var website sitesv1.Website
+			Expect(c.Get(ctx, namespacedName, &website)).To(Succeed())
+			website.Spec.Canary.Weight = 50
+			Expect(c.Update(ctx, &website)).To(Succeed())
+			stable, canary := reconcileAndGetDeployments()
+			Expect(*stable.Spec.Replicas).To(Equal(int32(2)))
+			Expect(*canary.Spec.Replicas).To(Equal(int32(2)))
+
+			Expect(c.Get(ctx, namespacedName, &website)).To(Succeed())
+			website.Spec.Canary.Weight = 1
+			Expect(c.Update(ctx, &website)).To(Succeed())
+			stable, canary = reconcileAndGetDeployments()
+			Expect(*stable.Spec.Replicas).To(Equal(int32(3)), "a non-zero weight keeps one canary pod")
+			Expect(*canary.Spec.Replicas).To(Equal(int32(1)))
+
+			Expect(c.Get(ctx, namespacedName, &website)).To(Succeed())
+			website.Spec.Canary = nil
+			Expect(c.Update(ctx, &website)).To(Succeed())
+			stable, canary = reconcileAndGetDeployments()
+			Expect(*stable.Spec.Replicas).To(Equal(int32(4)))
+			Expect(canary).To(BeNil())
+		})
+
+		It("should count available canary pods in status", func() {
+			stable, canary := reconcileAndGetDeployments()
+			stable.Status.AvailableReplicas = 0
+			Expect(c.Status().Update(ctx, stable)).To(Succeed())
+			canary.Status.AvailableReplicas = 1
+			Expect(c.Status().Update(ctx, canary)).To(Succeed())
+
+			reconcileAndGetDeployments()
+			var website sitesv1.Website
+			Expect(c.Get(ctx, namespacedName, &website)).To(Succeed())
+			Expect(website.Status.CanaryAvailableReplicas).To(Equal(int32(1)))
+			Expect(website.Status.Phase).To(Equal("Running"))
+		})
+	})
+
+	Context("When a Deployment predates the track label", func() {
+		const resourceName = "untracked-site"
+
+		ctx := context.Background()
+		namespacedName := types.NamespacedName{Name: resourceName, Namespace: "default"}
+		oldLabels := map[string]string{"app": resourceName}
+
+		var c client.Client
+		var reconciler *WebsiteReconciler
+		var oldDep *appsv1.Deployment
+
+		BeforeEach(func() {
+			c = newFakeClient()
+			reconciler = &WebsiteReconciler{Client: c, Scheme: testScheme}
+			website := &sitesv1.Website{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+				Spec:       sitesv1.WebsiteSpec{GitURL: "https://example.com/site.git", Replicas: 2},
+			}
+			Expect(c.Create(ctx, website)).To(Succeed())
+
+			// The fake client ignores propagation policies; the finalizer stands in
+			// for the one the API server adds when orphaning dependents
+			oldDep = &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default",
+					Finalizers: []string{metav1.FinalizerOrphanDependents}},
+				Spec: appsv1.DeploymentSpec{
+					Selector: &metav1.LabelSelector{MatchLabels: oldLabels},
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{Labels: oldLabels},
+						Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "nginx", Image: defaultImage}}},
+					},
+				},
+			}
+			Expect(ctrl.SetControllerReference(website, oldDep, testScheme)).To(Succeed())
+			Expect(c.Create(ctx, oldDep)).To(Succeed())
+
+			rs := &appsv1.ReplicaSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName + "-abc123",
+					Namespace: "default",
+					Labels:    map[string]string{"app": resourceName, "pod-template-hash": "abc123"},
+				},
+				Spec: appsv1.ReplicaSetSpec{
+					Selector: &metav1.LabelSelector{MatchLabels: oldLabels},
+					Template: oldDep.Spec.Template,
+				},
+			}
+			Expect(ctrl.SetControllerReference(oldDep, rs, testScheme)).To(Succeed())
+			Expect(c.Create(ctx, rs)).To(Succeed())
+		})
+
+		It("should recreate the Deployment and retire its ReplicaSet once replaced", func() {
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: namespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			var deleting appsv1.Deployment
+			Expect(c.Get(ctx, namespacedName, &deleting)).To(Succeed())
+			Expect(deleting.DeletionTimestamp).NotTo(BeNil(), "the old Deployment is deleted, since its selector cannot change")
+			var rs appsv1.ReplicaSet
+			Expect(c.Get(ctx, types.NamespacedName{Name: resourceName + "-abc123", Namespace: "default"}, &rs)).To(Succeed())
+			Expect(rs.Labels).To(HaveKeyWithValue(retiredLabel, "true"))
+
+			By("waiting for the old Deployment to be gone")
+			_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: namespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(c.Get(ctx, namespacedName, &deleting)).To(Succeed())
+			Expect(deleting.Spec.Selector.MatchLabels).To(Equal(oldLabels))
+			deleting.Finalizers = nil
+			Expect(c.Update(ctx, &deleting)).To(Succeed())
+
+			_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: namespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			var dep appsv1.Deployment
+			Expect(c.Get(ctx, namespacedName, &dep)).To(Succeed())
+			Expect(dep.Spec.Selector.MatchLabels).To(Equal(map[string]string{"app": resourceName, trackLabel: "stable"}))
+			Expect(c.Get(ctx, client.ObjectKeyFromObject(&rs), &rs)).To(Succeed(),
+				"the old pods keep serving until the new ones are available")
+
+			dep.Status.AvailableReplicas = 2
+			Expect(c.Status().Update(ctx, &dep)).To(Succeed())
+			_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: namespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(errors.IsNotFound(c.Get(ctx, client.ObjectKeyFromObject(&rs), &rs))).To(BeTrue())
+		})
+	})
+
+	Context("When autoscaling is enabled", func() {
+		const resourceName = "autoscaled-site"
+
+		ctx := context.Background()
+		namespacedName := types.NamespacedName{Name: resourceName, Namespace: "default"}
+
+		var c client.Client
+		var reconciler *WebsiteReconciler
+
+		BeforeEach(func() {
+			c = newFakeClient()
+			reconciler = &WebsiteReconciler{Client: c, Scheme: testScheme}
+			Expect(c.Create(ctx, &sitesv1.Website{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+				Spec: sitesv1.WebsiteSpec{
+					GitURL:   "https://example.com/site.git",
+					Replicas: 3,
+					Autoscaling: &sitesv1.WebsiteAutoscaling{
+						MinReplicas: ptr.To(int32(2)),
+						MaxReplicas: 6,
+					},
+				},
+			})).To(Succeed())
+		})
+
+		reconcileAndGetDeployment := func() *appsv1.Deployment {
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: namespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			dep := &appsv1.Deployment{}
+			Expect(c.Get(ctx, namespacedName, dep)).To(Succeed())
+			return dep
+		}
+
+		It("should hand the replica count to a HorizontalPodAutoscaler", func() {
+			dep := reconcileAndGetDeployment()
+			Expect(dep.ManagedFields).NotTo(BeEmpty())
+			Expect(ownsReplicas(dep, fieldManager)).To(BeFalse(), "the autoscaler sizes a new Deployment")
+
+			var hpa autoscalingv2.HorizontalPodAutoscaler
+			Expect(c.Get(ctx, namespacedName, &hpa)).To(Succeed())
+			Expect(hpa.Spec.ScaleTargetRef).To(Equal(autoscalingv2.CrossVersionObjectReference{
+				APIVersion: "apps/v1", Kind: "Deployment", Name: resourceName,
+			}))
+			Expect(*hpa.Spec.MinReplicas).To(Equal(int32(2)))
+			Expect(hpa.Spec.MaxReplicas).To(Equal(int32(6)))
+			Expect(*hpa.Spec.Metrics[0].Resource.Target.AverageUtilization).To(Equal(int32(80)))
+			Expect(hpa.OwnerReferences).To(HaveLen(1))
+
+			var website sitesv1.Website
+			Expect(c.Get(ctx, namespacedName, &website)).To(Succeed())
+			cond := meta.FindStatusCondition(website.Status.Conditions, conditionAutoscaled)
+			Expect(cond).NotTo(BeNil())
+			Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+			Expect(cond.Reason).To(Equal("HPAAuthoritative"))
+			Expect(cond.Message).To(ContainSubstring("spec.replicas (3) is ignored"))
+		})
+
+		It("should apply spec.autoscaling.behavior to the HorizontalPodAutoscaler", func() {
+			reconcileAndGetDeployment()
+			var hpa autoscalingv2.HorizontalPodAutoscaler
+			Expect(c.Get(ctx, namespacedName, &hpa)).To(Succeed())
+			Expect(hpa.Spec.Behavior).To(BeNil())
+
+			behavior := &autoscalingv2.HorizontalPodAutoscalerBehavior{
+				ScaleDown: &autoscalingv2.HPAScalingRules{
+					StabilizationWindowSeconds: ptr.To(int32(600)),
+					Policies: []autoscalingv2.HPAScalingPolicy{{
+						Type: autoscalingv2.PodsScalingPolicy, Value: 1, PeriodSeconds: 60,
+					}},
+				},
+			}
+			var website sitesv1.Website
+			Expect(c.Get(ctx, namespacedName, &website)).To(Succeed())
+			website.Spec.Autoscaling.Behavior = behavior
+			Expect(c.Update(ctx, &website)).To(Succeed())
+
+			reconcileAndGetDeployment()
+			Expect(c.Get(ctx, namespacedName, &hpa)).To(Succeed())
+			Expect(hpa.Spec.Behavior).To(Equal(behavior))
+		})
+
+		It("should stop touching replicas while the autoscaler owns them", func() {
+			dep := reconcileAndGetDeployment()
+
+			// The autoscaler scales up, then someone edits spec.replicas
+			dep.Spec.Replicas = ptr.To(int32(5))
+			Expect(c.Update(ctx, dep)).To(Succeed())
+			var website sitesv1.Website
+			Expect(c.Get(ctx, namespacedName, &website)).To(Succeed())
+			website.Spec.Replicas = 1
+			Expect(c.Update(ctx, &website)).To(Succeed())
+
+			dep = reconcileAndGetDeployment()
+			Expect(*dep.Spec.Replicas).To(Equal(int32(5)))
+			Expect(ownsReplicas(dep, fieldManager)).To(BeFalse())
+		})
+
+		It("should hand over replicas it applied before autoscaling was turned on", func() {
+			var website sitesv1.Website
+			Expect(c.Get(ctx, namespacedName, &website)).To(Succeed())
+			website.Spec.Autoscaling = nil
+			Expect(c.Update(ctx, &website)).To(Succeed())
+			dep := reconcileAndGetDeployment()
+			Expect(ownsReplicas(dep, fieldManager)).To(BeTrue())
+
+			Expect(c.Get(ctx, namespacedName, &website)).To(Succeed())
+			website.Spec.Autoscaling = &sitesv1.WebsiteAutoscaling{MaxReplicas: 6}
+			Expect(c.Update(ctx, &website)).To(Succeed())
+			dep = reconcileAndGetDeployment()
+			Expect(*dep.Spec.Replicas).To(Equal(int32(3)), "dropping the field must not reset it")
+			Expect(ownsReplicas(dep, fieldManager)).To(BeFalse())
+			Expect(ownsReplicas(dep, replicasHandoverManager)).To(BeTrue())
+		})
+
+		It("should resume managing replicas once autoscaling is turned off", func() {
+			reconcileAndGetDeployment()
+
+			var website sitesv1.Website
+			Expect(c.Get(ctx, namespacedName, &website)).To(Succeed())
+			website.Spec.Autoscaling = nil
+			Expect(c.Update(ctx, &website)).To(Succeed())
+
+			dep := reconcileAndGetDeployment()
+			Expect(*dep.Spec.Replicas).To(Equal(int32(3)))
+			var hpa autoscalingv2.HorizontalPodAutoscaler
+			Expect(errors.IsNotFound(c.Get(ctx, namespacedName, &hpa))).To(BeTrue())
+			Expect(c.Get(ctx, namespacedName, &website)).To(Succeed())
+			Expect(meta.FindStatusCondition(website.Status.Conditions, conditionAutoscaled)).To(BeNil())
+		})
+
+		DescribeTable("rejecting conflicting replica settings",
+			func(mutate func(*sitesv1.WebsiteSpec), message string) {
+				var website sitesv1.Website
+				Expect(c.Get(ctx, namespacedName, &website)).To(Succeed())
+				mutate(&website.Spec)
+				Expect(c.Update(ctx, &website)).To(Succeed())
+				_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: namespacedName})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(c.Get(ctx, namespacedName, &website)).To(Succeed())
+				cond := meta.FindStatusCondition(website.Status.Conditions, conditionSpecInvalid)
+				Expect(cond).NotTo(BeNil())
+				Expect(cond.Message).To(ContainSubstring(message))
+			},
+			Entry("a schedule", func(spec *sitesv1.WebsiteSpec) {
+				spec.Schedule = &sitesv1.WebsiteSchedule{ScaleUpCron: "0 9 * * 1-5", ScaleDownCron: "0 18 * * 1-5"}
+			}, "spec.schedule"),
+			Entry("a canary", func(spec *sitesv1.WebsiteSpec) {
+				spec.Canary = &sitesv1.WebsiteCanary{Image: "nginx:canary", Weight: 10}
+			}, "spec.canary"),
+			Entry("a floor above the ceiling", func(spec *sitesv1.WebsiteSpec) {
+				spec.Autoscaling.MinReplicas = ptr.To(int32(7))
+			}, "greater than maxReplicas"),
+		)
+	})
+
+	Context("When metrics are enabled", func() {
+		const resourceName = "metrics-site"
+
+		ctx := context.Background()
+		namespacedName := types.NamespacedName{Name: resourceName, Namespace: "default"}
+
+		var c client.Client
+		var reconciler *WebsiteReconciler
+
+		BeforeEach(func() {
+			c = newFakeClient()
+			reconciler = &WebsiteReconciler{Client: c, Scheme: testScheme}
+			Expect(c.Create(ctx, &sitesv1.Website{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+				Spec: sitesv1.WebsiteSpec{
+					GitURL:  "https://example.com/site.git",
+					Metrics: &sitesv1.WebsiteMetrics{Enabled: true},
+				},
+			})).To(Succeed())
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: namespacedName})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should run the exporter sidecar and expose it on the Service", func() {
+			dep := &appsv1.Deployment{}
+			Expect(c.Get(ctx, namespacedName, dep)).To(Succeed())
+			containers := dep.Spec.Template.Spec.Containers
+			Expect(containers).To(HaveLen(2))
+			Expect(containers[0].Args[0]).To(HavePrefix("echo 'server { listen 127.0.0.1:8081; location = /stub_status { stub_status; } }'"))
+			Expect(containers[1].Name).To(Equal("nginx-exporter"))
+			Expect(containers[1].Image).To(Equal(defaultExporterImage))
+			Expect(containers[1].Args).To(Equal([]string{"--nginx.scrape-uri=http://127.0.0.1:8081/stub_status"}))
+			Expect(containers[1].Ports).To(Equal([]corev1.ContainerPort{{Name: "metrics", ContainerPort: 9113}}))
+
+			var svc corev1.Service
+			Expect(c.Get(ctx, namespacedName, &svc)).To(Succeed())
+			Expect(svc.Spec.Ports).To(HaveLen(2))
+			Expect(svc.Spec.Ports[1].Name).To(Equal("metrics"))
+			Expect(svc.Spec.Ports[1].Port).To(Equal(int32(9113)))
+			Expect(svc.Spec.Ports[1].TargetPort).To(Equal(intstr.FromString("metrics")))
+		})
+
+		It("should remove the sidecar and the Service port when disabled", func() {
+			var website sitesv1.Website
+			Expect(c.Get(ctx, namespacedName, &website)).To(Succeed())
+			website.Spec.Metrics.Enabled = false
+			Expect(c.Update(ctx, &website)).To(Succeed())
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: namespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			dep := &appsv1.Deployment{}
+			Expect(c.Get(ctx, namespacedName, dep)).To(Succeed())
+			Expect(dep.Spec.Template.Spec.Containers).To(HaveLen(1))
+			Expect(dep.Spec.Template.Spec.Containers[0].Args[0]).NotTo(ContainSubstring("stub_status"))
+
+			var svc corev1.Service
+			Expect(c.Get(ctx, namespacedName, &svc)).To(Succeed())
+			Expect(svc.Spec.Ports).To(HaveLen(1))
+			Expect(svc.Spec.Ports[0].Name).To(Equal("http"))
+		})
+
+		It("should reserve the exporter's container name", func() {
+			var website sitesv1.Website
+			Expect(c.Get(ctx, namespacedName, &website)).To(Succeed())
+			website.Spec.Sidecars = []corev1.Container{{Name: "nginx-exporter", Image: "busybox"}}
+			Expect(c.Update(ctx, &website)).To(Succeed())
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: namespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(c.Get(ctx, namespacedName, &website)).To(Succeed())
+			cond := meta.FindStatusCondition(website.Status.Conditions, conditionSpecInvalid)
+			Expect(cond).NotTo(BeNil())
+			Expect(cond.Message).To(ContainSubstring(`"nginx-exporter" is already in use`))
+		})
+	})
+
+	Context("When graceful shutdown is configured", func() {
+		const resourceName = "graceful-site"
+
+		ctx := context.Background()
+		namespacedName := types.NamespacedName{Name: resourceName, Namespace: "default"}
+
+		var c client.Client
+		var reconciler *WebsiteReconciler
+
+		BeforeEach(func() {
+			c = newFakeClient()
+			reconciler = &WebsiteReconciler{Client: c, Scheme: testScheme}
+			Expect(c.Create(ctx, &sitesv1.Website{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+				Spec: sitesv1.WebsiteSpec{
+					GitURL:                        "https://example.com/site.git",
+					TerminationGracePeriodSeconds: ptr.To(int64(60)),
+					GracefulShutdown:              true,
+				},
+			})).To(Succeed())
+		})
+
+		reconcileAndGetPodSpec := func() corev1.PodSpec {
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: namespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			dep := &appsv1.Deployment{}
+			Expect(c.Get(ctx, namespacedName, dep)).To(Succeed())
+			return dep.Spec.Template.Spec
+		}
+
+		It("should set the grace period and the preStop hook on the web server", func() {
+			podSpec := reconcileAndGetPodSpec()
+			Expect(podSpec.TerminationGracePeriodSeconds).To(Equal(ptr.To(int64(60))))
+			lifecycle := podSpec.Containers[0].Lifecycle
+			Expect(lifecycle).NotTo(BeNil())
+			Expect(lifecycle.PreStop.Exec.Command).To(ContainElement(ContainSubstring("nginx -s quit")))
+		})
+
+		It("should follow changes to the spec", func() {
+			reconcileAndGetPodSpec()
+
+			var website sitesv1.Website
+			Expect(c.Get(ctx, namespacedName, &website)).To(Succeed())
+			website.Spec.TerminationGracePeriodSeconds = ptr.To(int64(120))
+			website.Spec.GracefulShutdown = false
+			Expect(c.Update(ctx, &website)).To(Succeed())
+
+			podSpec := reconcileAndGetPodSpec()
+			Expect(podSpec.TerminationGracePeriodSeconds).To(Equal(ptr.To(int64(120))))
+			Expect(podSpec.Containers[0].Lifecycle).To(BeNil())
+		})
+	})
+
+	Context("When annotations are passed through", func() {
+		const resourceName = "annotated-site"
+
+		ctx := context.Background()
+		namespacedName := types.NamespacedName{Name: resourceName, Namespace: "default"}
+
+		var c client.Client
+		var reconciler *WebsiteReconciler
+
+		BeforeEach(func() {
+			c = newFakeClient()
+			reconciler = &WebsiteReconciler{Client: c, Scheme: testScheme}
+			Expect(c.Create(ctx, &sitesv1.Website{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+				Spec: sitesv1.WebsiteSpec{
+					GitURL:                "https://example.com/site.git",
+					DeploymentAnnotations: map[string]string{"argocd.argoproj.io/sync-wave": "2"},
+					ServiceAnnotations:    map[string]string{"service.beta.kubernetes.io/aws-load-balancer-internal": "true"},
+				},
+			})).To(Succeed())
+		})
+
+		It("should set them on the Deployment and the Service but not the pods", func() {
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: namespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			dep := &appsv1.Deployment{}
+			Expect(c.Get(ctx, namespacedName, dep)).To(Succeed())
+			Expect(dep.Annotations).To(HaveKeyWithValue("argocd.argoproj.io/sync-wave", "2"))
+			Expect(dep.Annotations).NotTo(HaveKey("service.beta.kubernetes.io/aws-load-balancer-internal"))
+			Expect(dep.Spec.Template.Annotations).NotTo(HaveKey("argocd.argoproj.io/sync-wave"))
+
+			var svc corev1.Service
+			Expect(c.Get(ctx, namespacedName, &svc)).To(Succeed())
+			Expect(svc.Annotations).To(HaveKeyWithValue("service.beta.kubernetes.io/aws-load-balancer-internal", "true"))
+			Expect(svc.Annotations).NotTo(HaveKey("argocd.argoproj.io/sync-wave"))
+		})
+
+		It("should reject keys the Deployment controller manages", func() {
+			var website sitesv1.Website
+			Expect(c.Get(ctx, namespacedName, &website)).To(Succeed())
+			website.Spec.DeploymentAnnotations["deployment.kubernetes.io/revision"] = "1"
+			Expect(c.Update(ctx, &website)).To(Succeed())
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: namespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(c.Get(ctx, namespacedName, &website)).To(Succeed())
+			cond := meta.FindStatusCondition(website.Status.Conditions, conditionSpecInvalid)
+			Expect(cond).NotTo(BeNil())
+			Expect(cond.Message).To(ContainSubstring(`"deployment.kubernetes.io/revision" is under the reserved prefix`))
+		})
+	})
+
+	Context("When readiness gates are set", func() {
+		const resourceName = "gated-site"
+
+		ctx := context.Background()
+		namespacedName := types.NamespacedName{Name: resourceName, Namespace: "default"}
+
+		var c client.Client
+		var reconciler *WebsiteReconciler
+
+		BeforeEach(func() {
+			c = newFakeClient()
+			reconciler = &WebsiteReconciler{Client: c, Scheme: testScheme}
+		})
+
+		reconcileAndGetGates := func() []corev1.PodReadinessGate {
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: namespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			var dep appsv1.Deployment
+			Expect(c.Get(ctx, namespacedName, &dep)).To(Succeed())
+			return dep.Spec.Template.Spec.ReadinessGates
+		}
+
+		It("should add them to the pod spec and follow spec updates", func() {
+			Expect(c.Create(ctx, &sitesv1.Website{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+				Spec: sitesv1.WebsiteSpec{
+					GitURL:         "https://example.com/site.git",
+					ReadinessGates: []corev1.PodReadinessGate{{ConditionType: "mesh.example.com/proxy-ready"}},
+				},
+			})).To(Succeed())
+			Expect(reconcileAndGetGates()).To(Equal([]corev1.PodReadinessGate{{ConditionType: "mesh.example.com/proxy-ready"}}))
+
+			var website sitesv1.Website
+			Expect(c.Get(ctx, namespacedName, &website)).To(Succeed())
+			website.Spec.ReadinessGates = append(website.Spec.ReadinessGates, corev1.PodReadinessGate{ConditionType: "lb.example.com/registered"})
+			Expect(c.Update(ctx, &website)).To(Succeed())
+			Expect(reconcileAndGetGates()).To(HaveLen(2))
+
+			Expect(c.Get(ctx, namespacedName, &website)).To(Succeed())
+			website.Spec.ReadinessGates = nil
+			Expect(c.Update(ctx, &website)).To(Succeed())
+			Expect(reconcileAndGetGates()).To(BeEmpty())
+		})
+	})
+
+	Context("When tracing is enabled", func() {
+		const resourceName = "traced-site"
+
+		ctx := context.Background()
+		namespacedName := types.NamespacedName{Name: resourceName, Namespace: "default"}
+
+		It("should record client calls as children of the reconcile span", func() {
+			exporter := tracetest.NewInMemoryExporter()
+			tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+			DeferCleanup(tp.Shutdown, context.Background())
+			c := newFakeClient()
+			Expect(c.Create(ctx, &sitesv1.Website{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+				Spec:       sitesv1.WebsiteSpec{GitURL: "https://example.com/site.git"},
+			})).To(Succeed())
+			tracer := tp.Tracer("test")
+			reconciler := &WebsiteReconciler{Client: NewTracingClient(c, tracer), Scheme: testScheme, Tracer: tracer}
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: namespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			spans := exporter.GetSpans()
+			Expect(spans).NotTo(BeEmpty())
+			root := spans[len(spans)-1]
+			Expect(root.Name).To(Equal("Website.Reconcile"))
+			var children []string
+			for _, span := range spans[:len(spans)-1] {
+				Expect(span.Parent.SpanID()).To(Equal(root.SpanContext.SpanID()), span.Name)
+				children = append(children, span.Name)
+			}
+			Expect(children).To(ContainElements("client.Get", "client.Patch", "client.status.Patch"))
+		})
+	})
 })
@@ -0,0 +1,142 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	sitesv1 "github.com/zhangbiao2009/controller_exercise/simpleoperator/api/v1"
+)
+
+var _ = Describe("Website controller against a real API server", Ordered, func() {
+	const (
+		resourceName = "integration-site"
+		namespace    = "default"
+		timeout      = 10 * time.Second
+		interval     = 100 * time.Millisecond
+	)
+
+	ctx := context.Background()
+	namespacedName := types.NamespacedName{Name: resourceName, Namespace: namespace}
+
+	It("should reject a Website missing required fields", func() {
+		invalid := &sitesv1.Website{
+			ObjectMeta: metav1.ObjectMeta{Name: "invalid-site", Namespace: namespace},
+		}
+		Expect(apierrors.IsInvalid(k8sClient.Create(ctx, invalid))).To(BeTrue())
+	})
+
+	It("should create an owned Deployment and Service", func() {
+		Expect(k8sClient.Create(ctx, &sitesv1.Website{
+			ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: namespace},
+			Spec:       sitesv1.WebsiteSpec{GitURL: "https://example.com/site.git"},
+		})).To(Succeed())
+
+		var website sitesv1.Website
+		Expect(k8sClient.Get(ctx, namespacedName, &website)).To(Succeed())
+		Expect(website.Spec.Replicas).To(Equal(int32(1)), "replicas should be defaulted by the CRD")
+		Expect(website.Spec.Port).To(Equal(int32(80)), "port should be defaulted by the CRD")
+		owner := metav1.OwnerReference{
+			APIVersion:         sitesv1.GroupVersion.String(),
+			Kind:               "Website",
+			Name:               resourceName,
+			UID:                website.UID,
+			Controller:         ptr.To(true),
+			BlockOwnerDeletion: ptr.To(true),
+		}
+
+		Eventually(func(g Gomega) {
+			var dep appsv1.Deployment
+			g.Expect(k8sClient.Get(ctx, namespacedName, &dep)).To(Succeed())
+			g.Expect(dep.OwnerReferences).To(ConsistOf(owner))
+			g.Expect(*dep.Spec.Replicas).To(Equal(int32(1)))
+			g.Expect(dep.Spec.Template.Spec.InitContainers[0].Args).To(ContainElement("--repo=https://example.com/site.git"))
+		}, timeout, interval).Should(Succeed())
+
+		Eventually(func(g Gomega) {
+			var svc corev1.Service
+			g.Expect(k8sClient.Get(ctx, namespacedName, &svc)).To(Succeed())
+			g.Expect(svc.OwnerReferences).To(ConsistOf(owner))
+			g.Expect(svc.Spec.Selector).To(Equal(map[string]string{"app": resourceName}))
+			g.Expect(svc.Spec.Ports).To(HaveLen(1))
+			g.Expect(svc.Spec.Ports[0].Port).To(Equal(int32(80)))
+		}, timeout, interval).Should(Succeed())
+	})
+
+	It("should scale the Deployment when spec.replicas changes", func() {
+		Eventually(func() error {
+			var website sitesv1.Website
+			if err := k8sClient.Get(ctx, namespacedName, &website); err != nil {
+				return err
+			}
+			website.Spec.Replicas = 3
+			return k8sClient.Update(ctx, &website)
+		}, timeout, interval).Should(Succeed())
+
+		Eventually(func(g Gomega) {
+			var dep appsv1.Deployment
+			g.Expect(k8sClient.Get(ctx, namespacedName, &dep)).To(Succeed())
+			g.Expect(*dep.Spec.Replicas).To(Equal(int32(3)))
+		}, timeout, interval).Should(Succeed())
+	})
+
+	It("should report available replicas in status", func() {
+		Eventually(func() error {
+			var dep appsv1.Deployment
+			if err := k8sClient.Get(ctx, namespacedName, &dep); err != nil {
+				return err
+			}
+			dep.Status.Replicas = 3
+			dep.Status.AvailableReplicas = 2
+			return k8sClient.Status().Update(ctx, &dep)
+		}, timeout, interval).Should(Succeed())
+
+		Eventually(func(g Gomega) {
+			var website sitesv1.Website
+			g.Expect(k8sClient.Get(ctx, namespacedName, &website)).To(Succeed())
+			g.Expect(website.Status.AvailableReplicas).To(Equal(int32(2)))
+			g.Expect(website.Status.Phase).To(Equal("Running"))
+		}, timeout, interval).Should(Succeed())
+	})
+
+	It("should delete the Website and leave its children to the garbage collector", func() {
+		var website sitesv1.Website
+		Expect(k8sClient.Get(ctx, namespacedName, &website)).To(Succeed())
+		Expect(k8sClient.Delete(ctx, &website)).To(Succeed())
+
+		Eventually(func() bool {
+			return apierrors.IsNotFound(k8sClient.Get(ctx, namespacedName, &sitesv1.Website{}))
+		}, timeout, interval).Should(BeTrue(), "the Website has no finalizer and should go at once")
+
+		// envtest has no garbage collector; in a cluster the controller owner
+		// reference checked above makes it delete these in the background.
+		var dep appsv1.Deployment
+		Expect(k8sClient.Get(ctx, namespacedName, &dep)).To(Succeed())
+		Expect(dep.OwnerReferences).To(HaveLen(1))
+		Expect(dep.OwnerReferences[0].UID).To(Equal(website.UID))
+	})
+})